@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
+	"sync"
 
 	"github.com/ivanenkomaksym/remindme_bot/domain/usecases"
 	"github.com/ivanenkomaksym/remindme_bot/keyboards"
@@ -17,6 +19,9 @@ type BotController struct {
 	userUsecase usecases.UserUseCase
 	dateUseCase usecases.DateUseCase
 	bot         *tgbotapi.BotAPI
+
+	// inFlight tracks updates still being processed after the webhook was acknowledged
+	inFlight sync.WaitGroup
 }
 
 // NewBotController creates a new bot controller
@@ -47,13 +52,32 @@ func (c *BotController) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 
 	// Process the update in the background using a goroutine.
+	c.inFlight.Add(1)
 	go func() {
+		defer c.inFlight.Done()
 		if err := c.processUpdate(update); err != nil {
 			log.Printf("ERROR: Failed to process update: %v", err)
 		}
 	}()
 }
 
+// Drain waits for updates that are still being processed in the background,
+// or until the context is done
+func (c *BotController) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		c.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // processUpdate processes a Telegram update
 func (c *BotController) processUpdate(update tgbotapi.Update) error {
 	if update.CallbackQuery != nil {
@@ -2,10 +2,13 @@ package controllers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/ivanenkomaksym/remindme_bot/domain/usecases"
@@ -100,3 +103,28 @@ func TestHandleWebhook_ValidUpdate(t *testing.T) {
 		t.Fatalf("expected 200, got %d", rw.Code)
 	}
 }
+
+func TestDrain_WaitsForInFlightUpdates(t *testing.T) {
+	update := tgbotapi.Update{UpdateID: 1, Message: &tgbotapi.Message{MessageID: 2, From: &tgbotapi.User{ID: 10}, Chat: &tgbotapi.Chat{ID: 10}, Text: "hi"}}
+	body, _ := json.Marshal(update)
+
+	// Block inside the background processing and fail there to avoid sending through the bot
+	release := make(chan struct{})
+	userUC := &userUseCaseMock{getOrCreateUserFn: func(userID int64, userName, firstName, lastName, language string) (*entities.User, error) {
+		<-release
+		return nil, errors.New("stop")
+	}}
+	ctrl := NewBotController(&botUseCaseMock{}, userUC, &dateUseCaseMock{}, &tgbotapi.BotAPI{})
+	ctrl.HandleWebhook(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := ctrl.Drain(ctx); err == nil {
+		t.Fatalf("expected Drain to time out while the update is still processed")
+	}
+
+	close(release)
+	if err := ctrl.Drain(context.Background()); err != nil {
+		t.Fatalf("expected Drain to finish, got %v", err)
+	}
+}
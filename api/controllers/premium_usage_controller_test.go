@@ -140,6 +140,46 @@ func (m *mockUserRepository) CreateUser(userID int64, userName, firstName, lastN
 	return user, nil
 }
 
+func (m *mockUserRepository) MarkUnreachable(userID int64, reason string) error {
+	if user, exists := m.users[userID]; exists {
+		user.MarkUnreachable(reason)
+		return nil
+	}
+	return errors.ErrUserNotFound
+}
+
+func (m *mockUserRepository) MarkReachable(userID int64) error {
+	if user, exists := m.users[userID]; exists {
+		user.MarkReachable()
+		return nil
+	}
+	return errors.ErrUserNotFound
+}
+
+func (m *mockUserRepository) SetPausedUntil(userID int64, until *time.Time) error {
+	if user, exists := m.users[userID]; exists {
+		user.PausedUntil = until
+		return nil
+	}
+	return errors.ErrUserNotFound
+}
+
+func (m *mockUserRepository) SetDigest(userID int64, digest *entities.Digest) error {
+	if user, exists := m.users[userID]; exists {
+		user.Digest = digest
+		return nil
+	}
+	return errors.ErrUserNotFound
+}
+
+func (m *mockUserRepository) GetUsersWithDueDigest(now time.Time) ([]entities.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepository) ClaimDigest(userID int64, scheduledAt, next time.Time) (bool, error) {
+	return false, nil
+}
+
 func (m *mockUserRepository) UpdateLocation(userID int64, location string) error {
 	if user, exists := m.users[userID]; exists {
 		user.LocationName = location
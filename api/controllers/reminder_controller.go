@@ -6,8 +6,10 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/errors"
 	"github.com/ivanenkomaksym/remindme_bot/domain/services"
 	"github.com/ivanenkomaksym/remindme_bot/domain/usecases"
 )
@@ -86,6 +88,15 @@ func (c *ReminderController) CreateReminder(w http.ResponseWriter, r *http.Reque
 	reminder, err := c.reminderUseCase.CreateReminder(userID, &userSelection)
 	if err != nil {
 		log.Printf("Failed to create reminder: %v", err)
+		if err == errors.ErrUserNotFound {
+			http.Error(w, "User not found", http.StatusNotFound)
+			return
+		}
+		// Invalid selections, e.g. a malformed recurrence rule, are the caller's to fix
+		if _, ok := err.(*errors.DomainError); ok {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
@@ -221,6 +232,53 @@ func (c *ReminderController) GetReminder(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(reminder)
 }
 
+// GetReminderHistory returns the delivery history of a specific reminder, newest first
+func (c *ReminderController) GetReminderHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET requests are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userIDStr := r.PathValue("user_id")
+	reminderIDStr := r.PathValue("reminder_id")
+
+	if userIDStr == "" || reminderIDStr == "" {
+		http.Error(w, "user_id and reminder_id parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	reminderID, err := strconv.ParseInt(reminderIDStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid reminder_id", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	history, err := c.reminderUseCase.GetReminderHistory(userID, reminderID, limit)
+	if err != nil {
+		log.Printf("Failed to get reminder history: %v", err)
+		http.Error(w, "Reminder not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
 // UpdateReminder updates a specific reminder
 func (c *ReminderController) UpdateReminder(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPut {
@@ -265,6 +323,130 @@ func (c *ReminderController) UpdateReminder(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(updatedReminder)
 }
 
+// PauseReminder deactivates a specific reminder until it is resumed
+func (c *ReminderController) PauseReminder(w http.ResponseWriter, r *http.Request) {
+	c.setPaused(w, r, c.reminderUseCase.PauseReminder)
+}
+
+// ResumeReminder reactivates a paused reminder from its next occurrence after now
+func (c *ReminderController) ResumeReminder(w http.ResponseWriter, r *http.Request) {
+	c.setPaused(w, r, c.reminderUseCase.ResumeReminder)
+}
+
+func (c *ReminderController) setPaused(w http.ResponseWriter, r *http.Request, action func(userID, reminderID int64) (*entities.Reminder, error)) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST requests are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userIDStr := r.PathValue("user_id")
+	reminderIDStr := r.PathValue("reminder_id")
+
+	if userIDStr == "" || reminderIDStr == "" {
+		http.Error(w, "user_id and reminder_id parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	userID, err := strconv.ParseInt(userIDStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	reminderID, err := strconv.ParseInt(reminderIDStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid reminder_id", http.StatusBadRequest)
+		return
+	}
+
+	reminder, err := action(userID, reminderID)
+	if err != nil {
+		log.Printf("Failed to pause or resume reminder: %v", err)
+		writePauseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reminder)
+}
+
+// PauseAllRemindersRequest represents the request body for pausing all reminders of a user
+type PauseAllRemindersRequest struct {
+	Until time.Time `json:"until"`
+}
+
+// PauseAllReminders pauses all reminders of a user until the given time (vacation mode)
+func (c *ReminderController) PauseAllReminders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST requests are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := strconv.ParseInt(r.PathValue("user_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	var req PauseAllRemindersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Until.IsZero() {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := c.reminderUseCase.PauseAllReminders(userID, req.Until)
+	if err != nil {
+		log.Printf("Failed to pause reminders: %v", err)
+		writePauseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// ResumeAllReminders ends a pause of all reminders of a user
+func (c *ReminderController) ResumeAllReminders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST requests are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID, err := strconv.ParseInt(r.PathValue("user_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		return
+	}
+
+	user, err := c.reminderUseCase.ResumeAllReminders(userID)
+	if err != nil {
+		log.Printf("Failed to resume reminders: %v", err)
+		writePauseError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// writePauseError reports a missing reminder or user as not found and a reminder that cannot
+// change state, e.g. resuming one that is not paused, as a conflict
+func writePauseError(w http.ResponseWriter, err error) {
+	switch err {
+	case errors.ErrReminderNotFound, errors.ErrUnauthorized:
+		http.Error(w, "Reminder not found", http.StatusNotFound)
+		return
+	case errors.ErrUserNotFound:
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+	if _, ok := err.(*errors.DomainError); ok {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+}
+
 // GetAllReminders returns all reminders (admin endpoint)
 func (c *ReminderController) GetAllReminders(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -283,6 +465,24 @@ func (c *ReminderController) GetAllReminders(w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(reminders)
 }
 
+// GetDeadLetterDeliveries returns all deliveries that failed permanently or ran out of retries
+func (c *ReminderController) GetDeadLetterDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET requests are allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	deliveries, err := c.reminderUseCase.GetDeadLetterDeliveries()
+	if err != nil {
+		log.Printf("Failed to get dead letter deliveries: %v", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
 // DeleteReminder deletes a specific reminder
 func (c *ReminderController) DeleteReminder(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/errors"
 	"github.com/ivanenkomaksym/remindme_bot/domain/usecases"
 )
 
@@ -71,6 +72,22 @@ func TestReminderController_ValidationChecks(t *testing.T) {
 			reminderID: "x",
 			handler:    c.UpdateReminder,
 		},
+		{
+			name:       "GetReminderHistory - Invalid reminder_id",
+			method:     http.MethodGet,
+			path:       "/reminders/1/x/history",
+			userID:     "1",
+			reminderID: "x",
+			handler:    c.GetReminderHistory,
+		},
+		{
+			name:       "GetReminderHistory - Invalid limit",
+			method:     http.MethodGet,
+			path:       "/reminders/1/2/history?limit=abc",
+			userID:     "1",
+			reminderID: "2",
+			handler:    c.GetReminderHistory,
+		},
 	}
 
 	for _, tt := range tests {
@@ -100,6 +117,8 @@ type reminderUseCaseMock struct {
 	deleteReminderFn     func(reminderID, userID int64) error
 	updateReminderFn     func(userID, reminderID int64, reminder *entities.Reminder) (*entities.Reminder, error)
 	getActiveRemindersFn func() ([]entities.Reminder, error)
+	pauseReminderFn      func(userID, reminderID int64) (*entities.Reminder, error)
+	resumeReminderFn     func(userID, reminderID int64) (*entities.Reminder, error)
 }
 
 func (m *reminderUseCaseMock) CreateReminder(userID int64, selection *entities.UserSelection) (*entities.Reminder, error) {
@@ -134,6 +153,14 @@ func (m *reminderUseCaseMock) GetReminder(userID, reminderID int64) (*entities.R
 	return &entities.Reminder{}, nil
 }
 
+func (m *reminderUseCaseMock) PauseReminder(userID, reminderID int64) (*entities.Reminder, error) {
+	return m.pauseReminderFn(userID, reminderID)
+}
+
+func (m *reminderUseCaseMock) ResumeReminder(userID, reminderID int64) (*entities.Reminder, error) {
+	return m.resumeReminderFn(userID, reminderID)
+}
+
 func (m *reminderUseCaseMock) UpdateReminder(userID, reminderID int64, reminder *entities.Reminder) (*entities.Reminder, error) {
 	if m.updateReminderFn != nil {
 		return m.updateReminderFn(userID, reminderID, reminder)
@@ -199,6 +226,12 @@ func TestReminderController_MethodGuards(t *testing.T) {
 			path:    "/reminders/1",
 			handler: c.CreateReminder,
 		},
+		{
+			name:    "Wrong method for GetDeadLetterDeliveries",
+			method:  http.MethodPost,
+			path:    "/deliveries/dead-letter",
+			handler: c.GetDeadLetterDeliveries,
+		},
 	}
 
 	for _, tt := range tests {
@@ -360,6 +393,37 @@ func TestReminderController_CreateReminder_Success(t *testing.T) {
 	}
 }
 
+func TestReminderController_CreateReminder_RRule(t *testing.T) {
+	mock := &reminderUseCaseMock{
+		createReminderFn: func(userID int64, selection *entities.UserSelection) (*entities.Reminder, error) {
+			if selection.RecurrenceType != entities.RRule {
+				t.Fatalf("expected RRule recurrence, got %v", selection.RecurrenceType)
+			}
+			if selection.RRule != "FREQ=MONTHLY;BYDAY=2TU" {
+				return nil, errors.NewDomainError("INVALID_RRULE", "Recurrence rule is invalid", nil)
+			}
+			return &entities.Reminder{ID: 1, UserID: userID}, nil
+		},
+	}
+	c := NewReminderController(mock, &mockNLPService{}, &mockUserUseCase{})
+
+	for rule, want := range map[string]int{
+		"FREQ=MONTHLY;BYDAY=2TU": http.StatusOK,
+		"FREQ=FORTNIGHTLY":       http.StatusBadRequest,
+	} {
+		body := `{"recurrenceType": "RRule", "rrule": "` + rule + `", "selectedTime": "10:00", "reminderMessage": "Team sync"}`
+		rw := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/reminders/123", strings.NewReader(body))
+		req.SetPathValue("user_id", "123")
+
+		c.CreateReminder(rw, req)
+
+		if rw.Code != want {
+			t.Fatalf("rule %q: expected %d, got %d", rule, want, rw.Code)
+		}
+	}
+}
+
 func TestReminderController_GetAllReminders_Success(t *testing.T) {
 	expectedReminders := []entities.Reminder{
 		{
@@ -449,6 +513,53 @@ func TestReminderController_DeleteReminder_Success(t *testing.T) {
 }
 
 // Mock services for testing
+func TestReminderController_PauseResumeReminder(t *testing.T) {
+	mock := &reminderUseCaseMock{
+		pauseReminderFn: func(userID, reminderID int64) (*entities.Reminder, error) {
+			if reminderID != 7 {
+				return nil, errors.ErrReminderNotFound
+			}
+			return &entities.Reminder{ID: reminderID, UserID: userID, Paused: true}, nil
+		},
+		resumeReminderFn: func(userID, reminderID int64) (*entities.Reminder, error) {
+			return nil, errors.NewDomainError("NOT_PAUSED", "Reminder is not paused", nil)
+		},
+	}
+	c := NewReminderController(mock, &mockNLPService{}, &mockUserUseCase{})
+
+	tests := []struct {
+		name       string
+		handler    func(http.ResponseWriter, *http.Request)
+		reminderID string
+		want       int
+	}{
+		{"pause", c.PauseReminder, "7", http.StatusOK},
+		{"pause unknown reminder", c.PauseReminder, "8", http.StatusNotFound},
+		{"pause invalid reminder_id", c.PauseReminder, "x", http.StatusBadRequest},
+		{"resume not paused", c.ResumeReminder, "7", http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/reminders/123/"+tt.reminderID+"/pause", nil)
+			req.SetPathValue("user_id", "123")
+			req.SetPathValue("reminder_id", tt.reminderID)
+
+			tt.handler(rw, req)
+
+			if rw.Code != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, rw.Code)
+			}
+		})
+	}
+
+	rw := httptest.NewRecorder()
+	c.PauseReminder(rw, httptest.NewRequest(http.MethodGet, "/reminders/123/7/pause", nil))
+	if rw.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rw.Code)
+	}
+}
+
 type mockNLPService struct{}
 
 func (m *mockNLPService) ParseReminderText(userID int64, text string, userTimezone string, userLanguage string) (*entities.UserSelection, error) {
@@ -493,6 +604,10 @@ func (m *mockUserUseCase) ClearUserSelection(userID int64) error {
 	return nil
 }
 
+func (m *mockUserUseCase) MarkUserReachable(userID int64) error {
+	return nil
+}
+
 func (m *mockUserUseCase) DeleteUser(userID int64) error {
 	return nil
 }
@@ -1,8 +1,14 @@
 package route
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/api/middleware"
 	"github.com/ivanenkomaksym/remindme_bot/bootstrap"
@@ -46,8 +52,16 @@ func Setup(app *bootstrap.Application) {
 	mux.HandleFunc("GET /api/reminders/{user_id}/{reminder_id}", app.Container.ReminderController.GetReminder)
 	mux.HandleFunc("PUT /api/reminders/{user_id}/{reminder_id}", app.Container.ReminderController.UpdateReminder)
 	mux.HandleFunc("DELETE /api/reminders/{user_id}/{reminder_id}", app.Container.ReminderController.DeleteReminder)
+	mux.HandleFunc("GET /api/reminders/{user_id}/{reminder_id}/history", app.Container.ReminderController.GetReminderHistory)
+	mux.HandleFunc("POST /api/reminders/{user_id}/{reminder_id}/pause", app.Container.ReminderController.PauseReminder)
+	mux.HandleFunc("POST /api/reminders/{user_id}/{reminder_id}/resume", app.Container.ReminderController.ResumeReminder)
+	mux.HandleFunc("POST /api/reminders/{user_id}/pause", app.Container.ReminderController.PauseAllReminders)
+	mux.HandleFunc("POST /api/reminders/{user_id}/resume", app.Container.ReminderController.ResumeAllReminders)
 	mux.HandleFunc("GET /api/reminders/{user_id}/active", app.Container.ReminderController.GetActiveReminders)
 
+	// API endpoints - Deliveries
+	mux.HandleFunc("GET /api/deliveries/dead-letter", app.Container.ReminderController.GetDeadLetterDeliveries)
+
 	// API endpoints - Premium Usage
 	mux.HandleFunc("GET /api/premium", app.Container.PremiumUsageController.GetAllPremiumUsage)
 	mux.HandleFunc("GET /api/premium/{user_id}", app.Container.PremiumUsageController.GetUserPremiumUsage)
@@ -63,13 +77,72 @@ func Setup(app *bootstrap.Application) {
 		w.Write([]byte("OK"))
 	})
 
+	// Stop on Ctrl+C locally and on SIGTERM from Cloud Run
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	notifierDone := make(chan struct{})
 	if app.Env.Config.Bot.Enabled {
-		go notifier.StartReminderNotifier(app.Container.ReminderRepo, app.Env.Config.App, app.Env.Config.Bot, app.Bot)
+		go func() {
+			defer close(notifierDone)
+			notifier.StartReminderNotifier(ctx, app.Container.ReminderRepo, app.Container.UserRepo, app.Container.DeliveryRepo, app.Container.ReminderTimer, app.Env.Config.App, app.Env.Config.Bot, app.Bot)
+		}()
+	} else {
+		close(notifierDone)
+	}
+
+	serverConfig := app.Env.Config.Server
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  serverConfig.ReadTimeout,
+		WriteTimeout: serverConfig.WriteTimeout,
+		IdleTimeout:  serverConfig.IdleTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Starting HTTP server on %s", addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("HTTP server failed: %v", err)
+		}
+	case <-ctx.Done():
+		log.Printf("Shutdown signal received, stopping within %v", serverConfig.ShutdownTimeout)
+	}
+	stop()
+
+	shutdown(app, server, notifierDone, serverConfig.ShutdownTimeout)
+}
+
+// shutdown stops accepting requests, lets the notifier and in-flight webhook updates finish
+// and closes the database connections, all within the shutdown timeout
+func shutdown(app *bootstrap.Application, server *http.Server, notifierDone <-chan struct{}, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("HTTP server shutdown: %v", err)
+	}
+
+	select {
+	case <-notifierDone:
+	case <-ctx.Done():
+		log.Printf("Reminder notifier did not stop in time")
+	}
+
+	if app.Container.BotController != nil {
+		if err := app.Container.BotController.Drain(ctx); err != nil {
+			log.Printf("Webhook updates still in flight: %v", err)
+		}
 	}
 
-	log.Printf("Starting HTTP server on %s", addr)
-	// Start the HTTP server. This will block indefinitely, serving requests.
-	if err := http.ListenAndServe(addr, handler); err != nil {
-		log.Fatalf("HTTP server failed: %v", err)
+	if err := app.Close(ctx); err != nil {
+		log.Printf("Failed to close repositories: %v", err)
 	}
+	log.Printf("Shutdown complete")
 }
@@ -1,6 +1,8 @@
 package bootstrap
 
 import (
+	"context"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -20,3 +22,8 @@ func App() Application {
 
 	return *app
 }
+
+// Close releases the resources held by the application
+func (app *Application) Close(ctx context.Context) error {
+	return app.Container.Close(ctx)
+}
@@ -67,6 +67,9 @@ func setupCommandsForLanguage(bot *tgbotapi.BotAPI, langCode string) error {
 	commands := []tgbotapi.BotCommand{
 		{Command: "start", Description: s.CmdStartDesc},
 		{Command: "list", Description: s.CmdListDesc},
+		{Command: "today", Description: s.CmdTodayDesc},
+		{Command: "tomorrow", Description: s.CmdTomorrowDesc},
+		{Command: "week", Description: s.CmdWeekDesc},
 		{Command: "setup", Description: s.CmdSetupDesc},
 		{Command: "account", Description: s.CmdAccountDesc},
 	}
@@ -1,6 +1,8 @@
 package bootstrap
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
 	"github.com/ivanenkomaksym/remindme_bot/domain/services"
 	"github.com/ivanenkomaksym/remindme_bot/domain/usecases"
+	"github.com/ivanenkomaksym/remindme_bot/notifier"
 	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
 	"github.com/ivanenkomaksym/remindme_bot/repositories/persistent"
 	"github.com/sashabaranov/go-openai"
@@ -26,9 +29,11 @@ type Container struct {
 	ReminderRepo      repositories.ReminderRepository
 	UserSelectionRepo repositories.UserSelectionRepository
 	PremiumUsageRepo  repositories.PremiumUsageRepository
+	DeliveryRepo      repositories.DeliveryRepository
 
 	// Services
-	NLPService services.NLPService
+	NLPService    services.NLPService
+	ReminderTimer *notifier.Timer
 
 	// Use Cases
 	UserUseCase         usecases.UserUseCase
@@ -72,6 +77,7 @@ func (c *Container) initRepositories(env *Env) {
 		c.ReminderRepo = inmemory.NewInMemoryReminderRepository()
 		c.UserSelectionRepo = inmemory.NewInMemoryUserSelectionRepository()
 		c.PremiumUsageRepo = inmemory.NewInMemoryPremiumUsageRepository()
+		c.DeliveryRepo = inmemory.NewInMemoryDeliveryRepository()
 	case repositories.Mongo:
 		// Expect connection string and database name from config
 		conn := env.Config.Database.ConnectionString
@@ -91,9 +97,14 @@ func (c *Container) initRepositories(env *Env) {
 		if err != nil {
 			log.Fatalf("Failed to init Mongo premium usage repo: %v", err)
 		}
+		deliveryRepo, err := persistent.NewMongoDeliveryRepository(conn, dbName)
+		if err != nil {
+			log.Fatalf("Failed to init Mongo delivery repo: %v", err)
+		}
 		c.UserRepo = userRepo
 		c.ReminderRepo = remRepo
 		c.PremiumUsageRepo = premiumRepo
+		c.DeliveryRepo = deliveryRepo
 		// User selections still in-memory for now
 		c.UserSelectionRepo = inmemory.NewInMemoryUserSelectionRepository()
 	default:
@@ -101,6 +112,24 @@ func (c *Container) initRepositories(env *Env) {
 	}
 }
 
+// disconnecter is implemented by repositories that hold a database connection
+type disconnecter interface {
+	Disconnect(ctx context.Context) error
+}
+
+// Close disconnects the database clients opened by the repositories
+func (c *Container) Close(ctx context.Context) error {
+	var errs []error
+	for _, repo := range []any{c.UserRepo, c.ReminderRepo, c.UserSelectionRepo, c.PremiumUsageRepo, c.DeliveryRepo} {
+		if d, ok := repo.(disconnecter); ok {
+			if err := d.Disconnect(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // initServices initializes all services
 func (c *Container) initServices() {
 	c.NLPService = &noOpNLPService{}
@@ -128,7 +157,13 @@ func (c *Container) initServices() {
 // initUseCases initializes all use cases
 func (c *Container) initUseCases() {
 	c.UserUseCase = usecases.NewUserUseCase(c.UserRepo, c.UserSelectionRepo)
-	c.ReminderUseCase = usecases.NewReminderUseCase(c.ReminderRepo, c.UserRepo)
+	// The timer is only consumed by the notifier, which runs when the bot is enabled
+	var timer services.ReminderScheduler
+	if c.Config.Bot.Enabled {
+		c.ReminderTimer = notifier.NewTimer()
+		timer = c.ReminderTimer
+	}
+	c.ReminderUseCase = usecases.NewReminderUseCase(c.ReminderRepo, c.UserRepo, c.DeliveryRepo, timer)
 	c.PremiumUsageUseCase = usecases.NewPremiumUsageUseCase(c.PremiumUsageRepo)
 }
 
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
 	"github.com/spf13/viper"
 )
@@ -58,6 +59,7 @@ type AppConfig struct {
 	Timezone        string
 	APIKey          string
 	NotifierTimeout time.Duration
+	CatchUpPolicy   entities.CatchUpPolicy
 }
 
 // OpenAIConfig holds OpenAI-related configuration
@@ -135,6 +137,7 @@ func (c *Config) setDefaults() {
 		Timezone:        "UTC",
 		APIKey:          "",
 		NotifierTimeout: 1 * time.Minute,
+		CatchUpPolicy:   entities.CatchUpFireOnce,
 	}
 
 	c.OpenAI = OpenAIConfig{
@@ -261,6 +264,15 @@ func (c *Config) loadAppConfig() {
 			c.App.NotifierTimeout = duration
 		}
 	}
+	if catchUp := viper.GetString("CATCH_UP_POLICY"); catchUp != "" {
+		policy, err := entities.ToCatchUpPolicy(catchUp)
+		if err != nil {
+			log.Fatalf("Invalid CATCH_UP_POLICY %q, expected %s, %s or %s", catchUp, entities.CatchUpFireOnce, entities.CatchUpFireAll, entities.CatchUpSkip)
+		}
+		if policy != entities.CatchUpDefault {
+			c.App.CatchUpPolicy = policy
+		}
+	}
 }
 
 // loadOpenAIConfig loads OpenAI configuration
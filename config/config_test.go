@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/spf13/viper"
 )
 
@@ -80,3 +81,18 @@ func TestLoadConfig_OverridesDurations(t *testing.T) {
 		t.Errorf("expected ShutdownTimeout 5s, got %v", cfg.Server.ShutdownTimeout)
 	}
 }
+
+func TestLoadConfig_CatchUpPolicy(t *testing.T) {
+	resetViper()
+
+	t.Setenv("BOT_TOKEN", "token")
+	t.Setenv("PUBLIC_URL", "https://example.com")
+	t.Setenv("PORT", "9090")
+	t.Setenv("CATCH_UP_POLICY", "skip")
+
+	cfg := LoadConfig()
+
+	if cfg.App.CatchUpPolicy != entities.CatchUpSkip {
+		t.Errorf("expected CatchUpPolicy skip, got %q", cfg.App.CatchUpPolicy)
+	}
+}
@@ -0,0 +1,10 @@
+package entities
+
+import "time"
+
+// AgendaItem is a single upcoming occurrence of a reminder
+type AgendaItem struct {
+	ReminderID int64     `json:"reminderId"`
+	Message    string    `json:"message"`
+	At         time.Time `json:"at"`
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// HolidayPolicy decides what happens to an occurrence that falls on a weekend or a holiday
+type HolidayPolicy string
+
+const (
+	HolidayPolicyNone  HolidayPolicy = ""      // Fire on any day
+	HolidayPolicySkip  HolidayPolicy = "skip"  // Drop the occurrence and wait for the next one on a business day
+	HolidayPolicyShift HolidayPolicy = "shift" // Move the occurrence to the next business day at the same time
+)
+
+// HolidayPolicies lists the selectable policies in the order they are cycled through
+var HolidayPolicies = []HolidayPolicy{HolidayPolicyNone, HolidayPolicySkip, HolidayPolicyShift}
+
+// IsValid reports whether the policy is known; the empty policy is valid
+func (p HolidayPolicy) IsValid() bool {
+	switch p {
+	case HolidayPolicyNone, HolidayPolicySkip, HolidayPolicyShift:
+		return true
+	default:
+		return false
+	}
+}
+
+// Next returns the policy following p in HolidayPolicies, wrapping around
+func (p HolidayPolicy) Next() HolidayPolicy {
+	for i, policy := range HolidayPolicies {
+		if policy == p {
+			return HolidayPolicies[(i+1)%len(HolidayPolicies)]
+		}
+	}
+	return HolidayPolicyNone
+}
+
+// BusinessDays keeps a recurring reminder off weekends, public holidays and days off of the user's choosing
+type BusinessDays struct {
+	Policy        HolidayPolicy `json:"policy" bson:"policy"`
+	Country       string        `json:"country,omitempty" bson:"country,omitempty"`               // Holiday calendar, e.g. "UA"; weekends only when empty
+	ExcludedDates []string      `json:"excluded_dates,omitempty" bson:"excluded_dates,omitempty"` // YYYY-MM-DD days off defined by the user
+}
+
+// IsActive reports whether occurrences are moved off non-business days at all
+func (b *BusinessDays) IsActive() bool {
+	return b != nil && b.Policy != HolidayPolicyNone
+}
+
+// Validate checks the policy and that every excluded date is YYYY-MM-DD
+func (b *BusinessDays) Validate() error {
+	if !b.Policy.IsValid() {
+		return errors.New("unknown holiday policy")
+	}
+	for _, date := range b.ExcludedDates {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return errors.New("excluded dates must be YYYY-MM-DD")
+		}
+	}
+	return nil
+}
+
+// Excludes reports whether the calendar day of day is one of the excluded dates
+func (b *BusinessDays) Excludes(day time.Time) bool {
+	date := day.Format("2006-01-02")
+	for _, excluded := range b.ExcludedDates {
+		if excluded == date {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWeekend reports whether day falls on a Saturday or a Sunday
+func IsWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+}
@@ -0,0 +1,43 @@
+package entities
+
+import "errors"
+
+// CatchUpPolicy decides what happens to occurrences that were missed while the notifier was down
+type CatchUpPolicy string
+
+const (
+	CatchUpDefault  CatchUpPolicy = ""          // Use the global policy
+	CatchUpFireOnce CatchUpPolicy = "fire_once" // Send a single late notification, then move on to the next future trigger
+	CatchUpFireAll  CatchUpPolicy = "fire_all"  // Send a late notification for every missed occurrence
+	CatchUpSkip     CatchUpPolicy = "skip"      // Drop missed occurrences silently
+)
+
+// ToCatchUpPolicy parses a catch-up policy name
+func ToCatchUpPolicy(s string) (CatchUpPolicy, error) {
+	policy := CatchUpPolicy(s)
+	if !policy.IsValid() {
+		return CatchUpDefault, errors.New("unknown catch-up policy")
+	}
+	return policy, nil
+}
+
+// IsValid reports whether the policy is known; the empty default is valid
+func (p CatchUpPolicy) IsValid() bool {
+	switch p {
+	case CatchUpDefault, CatchUpFireOnce, CatchUpFireAll, CatchUpSkip:
+		return true
+	default:
+		return false
+	}
+}
+
+// EffectiveCatchUpPolicy returns the reminder's own policy, or the given fallback when it has none
+func (r *Reminder) EffectiveCatchUpPolicy(fallback CatchUpPolicy) CatchUpPolicy {
+	if r.CatchUp != CatchUpDefault {
+		return r.CatchUp
+	}
+	if fallback != CatchUpDefault {
+		return fallback
+	}
+	return CatchUpFireOnce
+}
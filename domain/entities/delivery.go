@@ -0,0 +1,100 @@
+package entities
+
+import "time"
+
+// DeliveryKind tells what caused a reminder to be sent
+type DeliveryKind string
+
+const (
+	DeliveryKindScheduled DeliveryKind = "scheduled"
+	DeliveryKindSnooze    DeliveryKind = "snooze"
+	DeliveryKindNag       DeliveryKind = "nag"
+)
+
+// DeliveryStatus represents the outcome of a delivery attempt
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSent       DeliveryStatus = "sent"
+	DeliveryStatusRetrying   DeliveryStatus = "retrying"
+	DeliveryStatusDeadLetter DeliveryStatus = "dead_letter"
+)
+
+// Delivery records a single occurrence of a reminder being sent to the user
+type Delivery struct {
+	ID             int64          `json:"id,string" bson:"id"`
+	ReminderID     int64          `json:"reminderId,string" bson:"reminderId"`
+	UserID         int64          `json:"userId,string" bson:"userId"`
+	Kind           DeliveryKind   `json:"kind" bson:"kind"`
+	ScheduledAt    time.Time      `json:"scheduledAt" bson:"scheduledAt"`
+	SentAt         time.Time      `json:"sentAt" bson:"sentAt"` // Time of the last attempt
+	MessageID      int            `json:"messageId,omitempty" bson:"messageId"`
+	Status         DeliveryStatus `json:"status" bson:"status"`
+	Error          string         `json:"error,omitempty" bson:"error"`
+	Attempts       int            `json:"attempts" bson:"attempts"`
+	NextRetryAt    *time.Time     `json:"nextRetryAt,omitempty" bson:"nextRetryAt"`
+	Acknowledged   bool           `json:"acknowledged" bson:"acknowledged"`
+	AcknowledgedAt *time.Time     `json:"acknowledgedAt,omitempty" bson:"acknowledgedAt"`
+}
+
+// NewDelivery creates a delivery record for a reminder occurrence
+func NewDelivery(reminder *Reminder, kind DeliveryKind, scheduledAt, sentAt time.Time) *Delivery {
+	return &Delivery{
+		ReminderID:  reminder.ID,
+		UserID:      reminder.UserID,
+		Kind:        kind,
+		ScheduledAt: scheduledAt,
+		SentAt:      sentAt,
+	}
+}
+
+// RecordAttempt counts a send attempt made at the given time
+func (d *Delivery) RecordAttempt(at time.Time) {
+	d.Attempts++
+	d.SentAt = at
+}
+
+// MarkSent records a successful delivery with the resulting Telegram message ID
+func (d *Delivery) MarkSent(messageID int) {
+	d.Status = DeliveryStatusSent
+	d.MessageID = messageID
+	d.Error = ""
+	d.NextRetryAt = nil
+}
+
+// ScheduleRetry records a failed attempt that should be retried at the given time
+func (d *Delivery) ScheduleRetry(err error, at time.Time) {
+	d.Status = DeliveryStatusRetrying
+	d.Error = errorText(err)
+	d.NextRetryAt = &at
+}
+
+// MarkDeadLetter records a delivery that will not be retried anymore
+func (d *Delivery) MarkDeadLetter(err error) {
+	d.Status = DeliveryStatusDeadLetter
+	d.Error = errorText(err)
+	d.NextRetryAt = nil
+}
+
+// IsRetryDue reports whether a failed delivery should be attempted again at the given time
+func (d *Delivery) IsRetryDue(now time.Time) bool {
+	return d.Status == DeliveryStatusRetrying && d.NextRetryAt != nil && !d.NextRetryAt.After(now)
+}
+
+// IsSuccessful reports whether the message reached Telegram
+func (d *Delivery) IsSuccessful() bool {
+	return d.Status == DeliveryStatusSent
+}
+
+// Acknowledge marks the delivery as handled by the user
+func (d *Delivery) Acknowledge(at time.Time) {
+	d.Acknowledged = true
+	d.AcknowledgedAt = &at
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// Digest is a daily message summarising the upcoming reminders of a user, sent instead of
+// pinging for each of them
+type Digest struct {
+	Time   string    `json:"time" bson:"time"`     // HH:MM in the user's location
+	NextAt time.Time `json:"nextAt" bson:"nextAt"` // When the next digest is due
+	Since  time.Time `json:"since" bson:"since"`   // Occurrences before the first digest still ping
+}
+
+// Covers reports whether an occurrence of a reminder is summarised in a digest instead of sent
+// on its own. Critical reminders and spaced repetition reviews, which ask for a recall grade,
+// always ping.
+func (d *Digest) Covers(reminder *Reminder, at time.Time) bool {
+	if d == nil || reminder.Critical || reminder.Recurrence == nil {
+		return false
+	}
+	return reminder.Recurrence.Type != SpacedBasedRepetition && !at.Before(d.Since)
+}
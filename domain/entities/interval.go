@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// IntervalUnit is the unit of an interval recurrence
+type IntervalUnit string
+
+const (
+	IntervalUnitDefault IntervalUnit = ""        // Days, for reminders created before units existed
+	IntervalUnitMinutes IntervalUnit = "minutes" // Every N minutes
+	IntervalUnitHours   IntervalUnit = "hours"   // Every N hours
+	IntervalUnitDays    IntervalUnit = "days"    // Every N days
+	IntervalUnitWeeks   IntervalUnit = "weeks"   // Every N weeks
+)
+
+// IntervalUnits lists the selectable units from the shortest to the longest
+var IntervalUnits = []IntervalUnit{IntervalUnitMinutes, IntervalUnitHours, IntervalUnitDays, IntervalUnitWeeks}
+
+// ToIntervalUnit parses an interval unit name; an empty name means days
+func ToIntervalUnit(s string) (IntervalUnit, error) {
+	unit := IntervalUnit(s)
+	if !unit.IsValid() {
+		return IntervalUnitDefault, errors.New("unknown interval unit")
+	}
+	return unit.Normalize(), nil
+}
+
+// IsValid reports whether the unit is known; the empty default is valid
+func (u IntervalUnit) IsValid() bool {
+	switch u {
+	case IntervalUnitDefault, IntervalUnitMinutes, IntervalUnitHours, IntervalUnitDays, IntervalUnitWeeks:
+		return true
+	default:
+		return false
+	}
+}
+
+// Normalize resolves the empty default to days
+func (u IntervalUnit) Normalize() IntervalUnit {
+	if u == IntervalUnitDefault {
+		return IntervalUnitDays
+	}
+	return u
+}
+
+// IsSubDaily reports whether the unit repeats several times a day
+func (u IntervalUnit) IsSubDaily() bool {
+	return u == IntervalUnitMinutes || u == IntervalUnitHours
+}
+
+// Duration returns the length of a single unit
+func (u IntervalUnit) Duration() time.Duration {
+	switch u.Normalize() {
+	case IntervalUnitMinutes:
+		return time.Minute
+	case IntervalUnitHours:
+		return time.Hour
+	case IntervalUnitWeeks:
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// MaxInterval returns the largest N accepted for "every N units"
+func (u IntervalUnit) MaxInterval() int {
+	switch u.Normalize() {
+	case IntervalUnitMinutes:
+		return 720
+	case IntervalUnitHours:
+		return 24
+	case IntervalUnitWeeks:
+		return 12
+	default:
+		return 31
+	}
+}
+
+// ActiveWindow bounds sub-daily interval reminders to a part of the day, e.g. 08:00–22:00
+type ActiveWindow struct {
+	From string `json:"from" bson:"from"` // HH:MM, inclusive
+	To   string `json:"to" bson:"to"`     // HH:MM, inclusive
+}
+
+// NewActiveWindow validates and creates an active window; it must not wrap past midnight
+func NewActiveWindow(from, to string) (*ActiveWindow, error) {
+	window := &ActiveWindow{From: from, To: to}
+	if err := window.Validate(); err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// Validate checks that both bounds are HH:MM and that the window starts before it ends
+func (w *ActiveWindow) Validate() error {
+	from, err := time.Parse("15:04", w.From)
+	if err != nil {
+		return errors.New("invalid active window start")
+	}
+	to, err := time.Parse("15:04", w.To)
+	if err != nil {
+		return errors.New("invalid active window end")
+	}
+	if !from.Before(to) {
+		return errors.New("active window must start before it ends")
+	}
+	return nil
+}
+
+// Bounds returns the window on the calendar day of day, in day's location
+func (w *ActiveWindow) Bounds(day time.Time) (time.Time, time.Time) {
+	from, _ := time.Parse("15:04", w.From)
+	to, _ := time.Parse("15:04", w.To)
+	start := time.Date(day.Year(), day.Month(), day.Day(), from.Hour(), from.Minute(), 0, 0, day.Location())
+	end := time.Date(day.Year(), day.Month(), day.Day(), to.Hour(), to.Minute(), 0, 0, day.Location())
+	return start, end
+}
+
+// GetIntervalUnit returns the interval unit, treating the empty default as days
+func (r *Recurrence) GetIntervalUnit() IntervalUnit {
+	return r.IntervalUnit.Normalize()
+}
+
+// IntervalStep returns the time between two interval triggers
+func (r *Recurrence) IntervalStep() time.Duration {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+	return time.Duration(interval) * r.GetIntervalUnit().Duration()
+}
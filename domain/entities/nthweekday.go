@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// OrdinalLast selects the last weekday of a month, e.g. "the last Thursday"
+const OrdinalLast = -1
+
+// NthWeekday is a weekday within a month such as "the first Monday" or "the last Thursday"
+type NthWeekday struct {
+	Ordinal int          `json:"ordinal" bson:"ordinal"` // 1 to 4, or OrdinalLast
+	Weekday time.Weekday `json:"weekday" bson:"weekday"`
+}
+
+// IsValid reports whether the ordinal is 1 to 4 or last and the weekday is known
+func (o NthWeekday) IsValid() bool {
+	validOrdinal := o.Ordinal == OrdinalLast || (o.Ordinal >= 1 && o.Ordinal <= 4)
+	return validOrdinal && o.Weekday >= time.Sunday && o.Weekday <= time.Saturday
+}
+
+// DayIn returns the day of month on which the ordinal weekday falls in the given month
+func (o NthWeekday) DayIn(year int, month time.Month) int {
+	if o.Ordinal == OrdinalLast {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		return last.Day() - (int(last.Weekday())-int(o.Weekday)+7)%7
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return 1 + (int(o.Weekday)-int(first.Weekday())+7)%7 + (o.Ordinal-1)*7
+}
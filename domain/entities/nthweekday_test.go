@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNthWeekday_DayIn(t *testing.T) {
+	tests := []struct {
+		name  string
+		nth   NthWeekday
+		year  int
+		month time.Month
+		want  int
+	}{
+		{"first Monday when the month starts on Monday", NthWeekday{1, time.Monday}, 2025, time.September, 1},
+		{"first Monday later in the week", NthWeekday{1, time.Monday}, 2025, time.October, 6},
+		{"fourth Friday", NthWeekday{4, time.Friday}, 2025, time.October, 24},
+		{"last Thursday of a 30-day month", NthWeekday{OrdinalLast, time.Thursday}, 2025, time.November, 27},
+		{"last Friday on the last day", NthWeekday{OrdinalLast, time.Friday}, 2025, time.October, 31},
+		{"last Sunday of a leap February", NthWeekday{OrdinalLast, time.Sunday}, 2024, time.February, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.nth.DayIn(tt.year, tt.month); got != tt.want {
+				t.Fatalf("expected day %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNthWeekday_IsValid(t *testing.T) {
+	if !(NthWeekday{OrdinalLast, time.Saturday}).IsValid() || !(NthWeekday{4, time.Sunday}).IsValid() {
+		t.Fatalf("expected the fourth and last weekdays to be valid")
+	}
+	if (NthWeekday{5, time.Monday}).IsValid() || (NthWeekday{0, time.Monday}).IsValid() || (NthWeekday{-2, time.Monday}).IsValid() {
+		t.Fatalf("expected only the 1st to 4th and last to be valid")
+	}
+}
@@ -8,12 +8,23 @@ type Recurrence struct {
 	Type                      RecurrenceType `json:"type" bson:"type"`                   // e.g., "once", "daily", "weekly", "monthly", "interval", "custom"
 	Interval                  int            `json:"interval" bson:"interval"`           // e.g., every N days/hours/minutes
 	Weekdays                  []time.Weekday `json:"weekdays" bson:"weekdays"`           // For weekly recurrence (e.g., [Tuesday, Thursday])
-	DayOfMonth                []int          `json:"days_of_month" bson:"days_of_month"` // For monthly recurrence (e.g., [1, 15])
+	DayOfMonth                []int          `json:"days_of_month" bson:"days_of_month"` // For monthly recurrence (e.g., [1, 15], or [-1] for the last day)
 	StartDate                 *time.Time     `json:"start_date" bson:"start_date"`       // When recurrence begins (includes time)
 	LocationName              string         `json:"location" bson:"location"`
 	Location                  *time.Location `json:"-" bson:"-"`                                                       // Ignore
 	EndDate                   *time.Time     `json:"end_date" bson:"end_date"`                                         // When recurrence ends (optional)
 	SpacedBasedRepetitionDays []int          `json:"spaced_based_repetition_days" bson:"spaced_based_repetition_days"` // For spaced-based repetition (e.g., [1, 3, 7, 14])
+	IntervalUnit              IntervalUnit   `json:"interval_unit,omitempty" bson:"interval_unit,omitempty"`           // Unit of Interval, days when empty
+	ActiveWindow              *ActiveWindow  `json:"active_window,omitempty" bson:"active_window,omitempty"`           // Daily window for minute and hour intervals (optional)
+	RRule                     string         `json:"rrule,omitempty" bson:"rrule,omitempty"`                           // RFC 5545 rule for RRule recurrence (e.g., "FREQ=MONTHLY;BYDAY=-1FR")
+	MaxOccurrences            int            `json:"max_occurrences,omitempty" bson:"max_occurrences,omitempty"`       // Stop after this many occurrences (optional)
+	OccurrenceCount           int            `json:"occurrence_count,omitempty" bson:"occurrence_count,omitempty"`     // Occurrences fired so far
+	TimesOfDay                []string       `json:"times_of_day,omitempty" bson:"times_of_day,omitempty"`             // HH:MM times for daily, weekly and monthly recurrence, when more than one
+	ClampToMonthEnd           bool           `json:"clamp_to_month_end,omitempty" bson:"clamp_to_month_end,omitempty"` // Move days past the month end to its last day instead of skipping the month
+	NthWeekdays               []NthWeekday   `json:"nth_weekdays,omitempty" bson:"nth_weekdays,omitempty"`             // For monthly recurrence by weekday instead of DayOfMonth (e.g., first Monday)
+	BusinessDays              *BusinessDays  `json:"business_days,omitempty" bson:"business_days,omitempty"`           // Skip or shift occurrences on weekends and holidays (optional)
+	LadderStep                int            `json:"ladder_step,omitempty" bson:"ladder_step,omitempty"`               // Next step of SpacedBasedRepetitionDays
+	Recall                    *RecallState   `json:"recall,omitempty" bson:"recall,omitempty"`                         // Adaptive spaced repetition instead of a fixed ladder (optional)
 }
 
 type Option func(dp *Recurrence)
@@ -24,6 +35,55 @@ func WithInterval(interval int) Option {
 	}
 }
 
+func WithIntervalUnit(unit IntervalUnit) Option {
+	return func(r *Recurrence) {
+		r.IntervalUnit = unit
+	}
+}
+
+func WithActiveWindow(window *ActiveWindow) Option {
+	return func(r *Recurrence) {
+		r.ActiveWindow = window
+	}
+}
+
+func WithRRule(rule string) Option {
+	return func(r *Recurrence) {
+		r.RRule = rule
+	}
+}
+
+func WithEnd(endDate *time.Time, maxOccurrences int) Option {
+	return func(r *Recurrence) {
+		r.EndDate = endDate
+		r.MaxOccurrences = maxOccurrences
+	}
+}
+
+func WithTimesOfDay(timesOfDay []string) Option {
+	return func(r *Recurrence) {
+		r.TimesOfDay = timesOfDay
+	}
+}
+
+func WithClampToMonthEnd(clamp bool) Option {
+	return func(r *Recurrence) {
+		r.ClampToMonthEnd = clamp
+	}
+}
+
+func WithNthWeekdays(nthWeekdays []NthWeekday) Option {
+	return func(r *Recurrence) {
+		r.NthWeekdays = nthWeekdays
+	}
+}
+
+func WithBusinessDays(businessDays *BusinessDays) Option {
+	return func(r *Recurrence) {
+		r.BusinessDays = businessDays
+	}
+}
+
 func WithWeekdays(weekdays []time.Weekday) Option {
 	return func(r *Recurrence) {
 		r.Weekdays = weekdays
@@ -37,9 +97,7 @@ func WithDaysOfMonth(daysOfMonth []int) Option {
 }
 
 func WithSpacedBasedRepetition() Option {
-	return func(r *Recurrence) {
-		r.SpacedBasedRepetitionDays = []int{0, 1, 2, 3, 5, 7, 7, 7}
-	}
+	return WithSpacedRepetitionLadder(DefaultSpacedRepetitionLadder)
 }
 
 func New(recurrenceType RecurrenceType, startDate *time.Time, location *time.Location, opts ...Option) *Recurrence {
@@ -85,6 +143,14 @@ func (r *Recurrence) GetTimeOfDay() string {
 	return r.StartDate.In(r.GetLocation()).Format("15:04")
 }
 
+// GetTimesOfDay returns all times of day in "HH:MM" format, falling back to the time of StartDate
+func (r *Recurrence) GetTimesOfDay() []string {
+	if len(r.TimesOfDay) > 0 {
+		return r.TimesOfDay
+	}
+	return []string{r.GetTimeOfDay()}
+}
+
 // CustomWeekly creates a custom weekly recurrence on specific weekdays
 func CustomWeekly(weekdays []time.Weekday, timeOfDay time.Time, location *time.Location) *Recurrence {
 	return New(Weekly, &timeOfDay, location, WithWeekdays(weekdays))
@@ -104,11 +170,27 @@ func IntervalEveryDays(intervalDays int, timeOfDay time.Time, location *time.Loc
 	return New(Interval, &timeOfDay, location, WithInterval(intervalDays))
 }
 
+// IntervalEvery creates a recurrence that triggers every N units starting at a specific time,
+// optionally limited to a daily active window for minute and hour units
+func IntervalEvery(interval int, unit IntervalUnit, window *ActiveWindow, timeOfDay time.Time, location *time.Location) *Recurrence {
+	return New(Interval, &timeOfDay, location, WithInterval(interval), WithIntervalUnit(unit), WithActiveWindow(window))
+}
+
 // MonthlyOnDay creates a monthly recurrence on a specific day of the month
 func MonthlyOnDay(daysOfMonth []int, timeOfDay time.Time, location *time.Location) *Recurrence {
 	return New(Monthly, &timeOfDay, location, WithDaysOfMonth(daysOfMonth))
 }
 
+// RRuleFrom creates a recurrence following an RFC 5545 rule; startDate is the rule's DTSTART
+func RRuleFrom(rule string, startDate time.Time, location *time.Location) *Recurrence {
+	return New(RRule, &startDate, location, WithRRule(rule))
+}
+
+// YearlyOn creates a yearly recurrence on the month and day of date, at its time of day
+func YearlyOn(date time.Time, location *time.Location) *Recurrence {
+	return New(Yearly, &date, location)
+}
+
 func SpacedBasedRepetitionInterval(timeOfDay time.Time, location *time.Location) *Recurrence {
 	return New(SpacedBasedRepetition, &timeOfDay, location, WithSpacedBasedRepetition())
 }
@@ -121,6 +203,11 @@ func (r *Recurrence) IsInterval() bool {
 	return r.Type == Interval && r.Interval > 0
 }
 
+// IsValidDayOfMonth reports whether day is 1..31 or a negative offset from the month end, -1..-31
+func IsValidDayOfMonth(day int) bool {
+	return day != 0 && day >= -31 && day <= 31
+}
+
 func (r *Recurrence) IsMonthly() bool {
 	return r.Type == Monthly && len(r.DayOfMonth) > 0
 }
@@ -128,3 +215,31 @@ func (r *Recurrence) IsMonthly() bool {
 func (r *Recurrence) IsDaily() bool {
 	return r.Type == Daily
 }
+
+// HasEnd reports whether the recurrence stops at a date or after a number of occurrences
+func (r *Recurrence) HasEnd() bool {
+	return r.EndDate != nil || r.MaxOccurrences > 0
+}
+
+// RemainingOccurrences returns how many more times the recurrence may fire, or -1 when unlimited
+func (r *Recurrence) RemainingOccurrences() int {
+	if r.MaxOccurrences <= 0 {
+		return -1
+	}
+	return max(r.MaxOccurrences-r.OccurrenceCount, 0)
+}
+
+// RecordOccurrence counts a fired occurrence towards MaxOccurrences
+func (r *Recurrence) RecordOccurrence() {
+	r.OccurrenceCount++
+}
+
+// IsExhausted reports whether the recurrence has fired MaxOccurrences times
+func (r *Recurrence) IsExhausted() bool {
+	return r.RemainingOccurrences() == 0
+}
+
+// EndsBefore reports whether t lies past the end date
+func (r *Recurrence) EndsBefore(t time.Time) bool {
+	return r.EndDate != nil && t.After(*r.EndDate)
+}
@@ -15,6 +15,8 @@ const (
 	Monthly
 	Interval
 	SpacedBasedRepetition
+	RRule
+	Yearly
 )
 
 var RecurrenceTypeValues = []RecurrenceType{
@@ -24,6 +26,8 @@ var RecurrenceTypeValues = []RecurrenceType{
 	Monthly,
 	Interval,
 	SpacedBasedRepetition,
+	RRule,
+	Yearly,
 }
 
 func (r RecurrenceType) String() string {
@@ -40,11 +44,20 @@ func (r RecurrenceType) String() string {
 		return "Interval"
 	case SpacedBasedRepetition:
 		return "SpacedBasedRepetition"
+	case RRule:
+		return "RRule"
+	case Yearly:
+		return "Yearly"
 	default:
 		return "unknown"
 	}
 }
 
+// SupportsTimesOfDay reports whether the recurrence may fire at several times of day
+func (r RecurrenceType) SupportsTimesOfDay() bool {
+	return r == Daily || r == Weekly || r == Monthly
+}
+
 func ToRecurrenceType(s string) (RecurrenceType, error) {
 	switch s {
 	case "Once":
@@ -59,6 +72,10 @@ func ToRecurrenceType(s string) (RecurrenceType, error) {
 		return Interval, nil
 	case "SpacedBasedRepetition":
 		return SpacedBasedRepetition, nil
+	case "RRule":
+		return RRule, nil
+	case "Yearly":
+		return Yearly, nil
 	default:
 		return 0, errors.New("invalid recurrence type")
 	}
@@ -6,13 +6,32 @@ import (
 
 // Reminder represents a reminder in the system
 type Reminder struct {
-	ID          int64       `json:"id,string" bson:"id"`
-	UserID      int64       `json:"userId,string" bson:"userId"`
-	Message     string      `json:"message" bson:"message"`
-	CreatedAt   time.Time   `json:"createdAt" bson:"createdAt"`
-	NextTrigger *time.Time  `json:"nextTrigger" bson:"nextTrigger"`
-	Recurrence  *Recurrence `json:"recurrence" bson:"recurrence"`
-	IsActive    bool        `json:"isActive" bson:"isActive"`
+	ID           int64         `json:"id,string" bson:"id"`
+	UserID       int64         `json:"userId,string" bson:"userId"`
+	Message      string        `json:"message" bson:"message"`
+	CreatedAt    time.Time     `json:"createdAt" bson:"createdAt"`
+	NextTrigger  *time.Time    `json:"nextTrigger" bson:"nextTrigger"`
+	Recurrence   *Recurrence   `json:"recurrence" bson:"recurrence"`
+	IsActive     bool          `json:"isActive" bson:"isActive"`
+	SnoozedUntil *time.Time    `json:"snoozedUntil,omitempty" bson:"snoozedUntil"`   // One-off re-delivery, independent of Recurrence
+	Nagging      *Nagging      `json:"nagging,omitempty" bson:"nagging"`             // Opt-in "repeat until acknowledged" mode
+	CatchUp      CatchUpPolicy `json:"catchUp,omitempty" bson:"catchUp,omitempty"`   // Missed-occurrence policy, empty uses the global one
+	Paused       bool          `json:"paused,omitempty" bson:"paused,omitempty"`     // Deactivated by the user until resumed
+	Critical     bool          `json:"critical,omitempty" bson:"critical,omitempty"` // Pings on its own even with a daily digest
+}
+
+// Default cadence for "repeat until acknowledged" reminders
+const (
+	DefaultNagIntervalMinutes = 15
+	DefaultMaxNags            = 5
+)
+
+// Nagging holds the configuration and progress of "repeat until acknowledged" delivery
+type Nagging struct {
+	IntervalMinutes int        `json:"intervalMinutes" bson:"intervalMinutes"`
+	MaxNags         int        `json:"maxNags" bson:"maxNags"`
+	Count           int        `json:"count" bson:"count"`
+	NextNag         *time.Time `json:"nextNag,omitempty" bson:"nextNag"`
 }
 
 // NewReminder creates a new reminder entity
@@ -33,7 +52,127 @@ func (r *Reminder) Deactivate() {
 	r.IsActive = false
 }
 
+// Pause deactivates the reminder until it is resumed and drops a pending snooze or nag
+func (r *Reminder) Pause() {
+	r.Acknowledge()
+	r.Paused = true
+	r.IsActive = false
+}
+
 // UpdateNextTrigger updates the next trigger time
 func (r *Reminder) UpdateNextTrigger(nextTrigger *time.Time) {
 	r.NextTrigger = nextTrigger
 }
+
+// Snooze schedules a one-off re-delivery of the reminder at the given time
+func (r *Reminder) Snooze(until time.Time) {
+	r.SnoozedUntil = &until
+}
+
+// ClearSnooze cancels a pending snoozed re-delivery
+func (r *Reminder) ClearSnooze() {
+	r.SnoozedUntil = nil
+}
+
+// IsSnoozeDue reports whether a snoozed re-delivery should be sent at the given time
+func (r *Reminder) IsSnoozeDue(now time.Time) bool {
+	return r.SnoozedUntil != nil && !r.SnoozedUntil.After(now)
+}
+
+// EnableNagging opts the reminder into re-sending until acknowledged.
+// Non-positive values fall back to the defaults.
+func (r *Reminder) EnableNagging(intervalMinutes, maxNags int) {
+	if intervalMinutes <= 0 {
+		intervalMinutes = DefaultNagIntervalMinutes
+	}
+	if maxNags <= 0 {
+		maxNags = DefaultMaxNags
+	}
+	r.Nagging = &Nagging{IntervalMinutes: intervalMinutes, MaxNags: maxNags}
+}
+
+// DisableNagging opts the reminder out of re-sending until acknowledged
+func (r *Reminder) DisableNagging() {
+	r.Nagging = nil
+}
+
+// IsNagging reports whether the reminder is in "repeat until acknowledged" mode
+func (r *Reminder) IsNagging() bool {
+	return r.Nagging != nil
+}
+
+// StartNagging begins a new nag cycle after the reminder has been delivered
+func (r *Reminder) StartNagging(sentAt time.Time) {
+	if r.Nagging == nil {
+		return
+	}
+	next := sentAt.Add(time.Duration(r.Nagging.IntervalMinutes) * time.Minute)
+	nagging := r.nagCopy()
+	nagging.Count = 0
+	nagging.NextNag = &next
+	r.Nagging = nagging
+}
+
+// IsNagDue reports whether an unacknowledged reminder should be re-sent at the given time
+func (r *Reminder) IsNagDue(now time.Time) bool {
+	return r.Nagging != nil && r.Nagging.NextNag != nil && !r.Nagging.NextNag.After(now)
+}
+
+// RecordNag counts a re-send and schedules the next one until the limit is reached
+func (r *Reminder) RecordNag(sentAt time.Time) {
+	if r.Nagging == nil {
+		return
+	}
+	nagging := r.nagCopy()
+	nagging.Count++
+	nagging.NextNag = nil
+	if nagging.Count < nagging.MaxNags {
+		next := sentAt.Add(time.Duration(nagging.IntervalMinutes) * time.Minute)
+		nagging.NextNag = &next
+	}
+	r.Nagging = nagging
+}
+
+// StopNagging cancels pending re-sends of the current occurrence
+func (r *Reminder) StopNagging() {
+	if r.Nagging == nil {
+		return
+	}
+	nagging := r.nagCopy()
+	nagging.Count = 0
+	nagging.NextNag = nil
+	r.Nagging = nagging
+}
+
+// Acknowledge marks the current occurrence as handled by the user
+func (r *Reminder) Acknowledge() {
+	r.ClearSnooze()
+	r.StopNagging()
+}
+
+// NextDueAt returns the earliest time the notifier has work for this reminder:
+// the next occurrence of an active reminder, a snoozed re-delivery or a nag.
+// Returns nil when nothing is pending.
+func (r *Reminder) NextDueAt() *time.Time {
+	var due *time.Time
+	consider := func(t *time.Time) {
+		if t != nil && (due == nil || t.Before(*due)) {
+			due = t
+		}
+	}
+	if r.IsActive {
+		consider(r.NextTrigger)
+	}
+	consider(r.SnoozedUntil)
+	if r.Nagging != nil {
+		consider(r.Nagging.NextNag)
+	}
+	return due
+}
+
+// nagCopy returns a copy of the nagging state so that reminder copies handed out
+// by repositories never share progress with the stored reminder
+func (r *Reminder) nagCopy() *Nagging {
+	nagging := *r.Nagging
+	return &nagging
+}
@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"errors"
+	"math"
+)
+
+// DefaultSpacedRepetitionLadder are the days between reviews of a fixed ladder reminder
+var DefaultSpacedRepetitionLadder = []int{2, 3, 4, 6, 8, 8, 8}
+
+// MaxLadderSteps and MaxLadderGapDays bound a custom ladder
+const (
+	MaxLadderSteps   = 20
+	MaxLadderGapDays = 365
+)
+
+// RecallGrade is the answer to an adaptive review: how well the item was remembered
+type RecallGrade string
+
+const (
+	RecallAgain RecallGrade = "again" // Forgotten, start over
+	RecallHard  RecallGrade = "hard"  // Remembered with serious difficulty
+	RecallGood  RecallGrade = "good"  // Remembered after some hesitation
+	RecallEasy  RecallGrade = "easy"  // Remembered perfectly
+)
+
+// RecallGrades lists the grades from the worst to the best
+var RecallGrades = []RecallGrade{RecallAgain, RecallHard, RecallGood, RecallEasy}
+
+// Defaults of a new adaptive review schedule
+const (
+	DefaultEaseFactor            = 2.5
+	MinEaseFactor                = 1.3
+	DefaultMaxRecallIntervalDays = 365
+)
+
+// ToRecallGrade parses a recall grade name
+func ToRecallGrade(s string) (RecallGrade, error) {
+	grade := RecallGrade(s)
+	if grade.quality() < 0 {
+		return "", errors.New("unknown recall grade")
+	}
+	return grade, nil
+}
+
+// quality maps a grade to the SM-2 response quality from 0 to 5, or -1 when unknown
+func (g RecallGrade) quality() int {
+	switch g {
+	case RecallAgain:
+		return 1
+	case RecallHard:
+		return 3
+	case RecallGood:
+		return 4
+	case RecallEasy:
+		return 5
+	default:
+		return -1
+	}
+}
+
+// RecallState is the SM-2 state of an adaptive spaced repetition reminder
+type RecallState struct {
+	EaseFactor      float64     `json:"ease_factor" bson:"ease_factor"`
+	IntervalDays    int         `json:"interval_days" bson:"interval_days"`                             // Days until the next review
+	Repetitions     int         `json:"repetitions" bson:"repetitions"`                                 // Successful reviews in a row
+	MaxIntervalDays int         `json:"max_interval_days,omitempty" bson:"max_interval_days,omitempty"` // Learned once the interval grows past it, never when 0
+	LastGrade       RecallGrade `json:"last_grade,omitempty" bson:"last_grade,omitempty"`
+}
+
+// NewRecallState starts an adaptive schedule with a review every day until the first grade
+func NewRecallState() *RecallState {
+	return &RecallState{
+		EaseFactor:      DefaultEaseFactor,
+		IntervalDays:    1,
+		MaxIntervalDays: DefaultMaxRecallIntervalDays,
+	}
+}
+
+// Apply updates the schedule with a grade following SM-2. The ease factor is adjusted first,
+// so the grade already affects the interval it was given for.
+func (s *RecallState) Apply(grade RecallGrade) error {
+	q := grade.quality()
+	if q < 0 {
+		return errors.New("unknown recall grade")
+	}
+
+	s.EaseFactor += 0.1 - float64(5-q)*(0.08+float64(5-q)*0.02)
+	if s.EaseFactor < MinEaseFactor {
+		s.EaseFactor = MinEaseFactor
+	}
+
+	switch {
+	case q < 3:
+		s.Repetitions = 0
+		s.IntervalDays = 1
+	case s.Repetitions == 0:
+		s.Repetitions = 1
+		s.IntervalDays = 1
+	case s.Repetitions == 1:
+		s.Repetitions = 2
+		s.IntervalDays = 6
+	default:
+		s.Repetitions++
+		s.IntervalDays = int(math.Round(float64(s.IntervalDays) * s.EaseFactor))
+	}
+	s.LastGrade = grade
+	return nil
+}
+
+// IsLearned reports whether the reviews are spaced far enough apart to stop
+func (s *RecallState) IsLearned() bool {
+	return s.MaxIntervalDays > 0 && s.IntervalDays > s.MaxIntervalDays
+}
+
+// ValidateLadder checks the days between reviews of a custom ladder
+func ValidateLadder(gaps []int) error {
+	if len(gaps) == 0 || len(gaps) > MaxLadderSteps {
+		return errors.New("a ladder needs 1 to 20 steps")
+	}
+	for _, gap := range gaps {
+		if gap < 1 || gap > MaxLadderGapDays {
+			return errors.New("days between reviews must be 1 to 365")
+		}
+	}
+	return nil
+}
+
+// WithSpacedRepetitionLadder reviews at the next time of day, then after each of the given
+// numbers of days. Steps are stored as the days added after the following day.
+func WithSpacedRepetitionLadder(gaps []int) Option {
+	return func(r *Recurrence) {
+		days := []int{0}
+		for _, gap := range gaps {
+			days = append(days, gap-1)
+		}
+		r.SpacedBasedRepetitionDays = days
+		r.LadderStep = 0
+	}
+}
+
+// WithRecall switches a spaced repetition recurrence to adaptive reviews
+func WithRecall(state *RecallState) Option {
+	return func(r *Recurrence) {
+		r.Recall = state
+	}
+}
+
+// IsAdaptive reports whether the reviews adapt to recall grades instead of following a ladder
+func (r *Recurrence) IsAdaptive() bool {
+	return r.Type == SpacedBasedRepetition && r.Recall != nil
+}
+
+// RemainingLadder returns the days between the remaining reviews of a fixed ladder
+func (r *Recurrence) RemainingLadder() []int {
+	var gaps []int
+	for i := r.LadderStep; i < len(r.SpacedBasedRepetitionDays); i++ {
+		gaps = append(gaps, r.SpacedBasedRepetitionDays[i]+1)
+	}
+	return gaps
+}
@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRecallState_Apply(t *testing.T) {
+	state := NewRecallState()
+	steps := []struct {
+		grade    RecallGrade
+		interval int
+		ease     float64
+	}{
+		{RecallGood, 1, 2.5},
+		{RecallGood, 6, 2.5},
+		{RecallGood, 15, 2.5},
+		{RecallEasy, 39, 2.6},
+		{RecallHard, 96, 2.46},
+		{RecallAgain, 1, 1.92},
+		{RecallGood, 1, 1.92},
+	}
+	for i, step := range steps {
+		if err := state.Apply(step.grade); err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+		if state.IntervalDays != step.interval || math.Abs(state.EaseFactor-step.ease) > 1e-9 {
+			t.Fatalf("step %d (%s): expected interval %d and ease %.2f, got %d and %.2f", i, step.grade, step.interval, step.ease, state.IntervalDays, state.EaseFactor)
+		}
+	}
+	if state.LastGrade != RecallGood || state.Repetitions != 1 {
+		t.Fatalf("expected the last grade and repetitions to be tracked, got %+v", state)
+	}
+
+	if err := state.Apply("meh"); err == nil {
+		t.Fatalf("expected error for an unknown grade")
+	}
+}
+
+func TestRecallState_EaseFactorFloor(t *testing.T) {
+	state := NewRecallState()
+	for range 10 {
+		state.Apply(RecallAgain)
+	}
+	if state.EaseFactor != MinEaseFactor {
+		t.Fatalf("expected the ease factor to stop at %.1f, got %.2f", MinEaseFactor, state.EaseFactor)
+	}
+}
+
+func TestRecallState_IsLearned(t *testing.T) {
+	state := &RecallState{IntervalDays: 400, MaxIntervalDays: 365}
+	if !state.IsLearned() {
+		t.Fatalf("expected an interval past the maximum to be learned")
+	}
+	state.MaxIntervalDays = 0
+	if state.IsLearned() {
+		t.Fatalf("expected no maximum to never be learned")
+	}
+}
+
+func TestSpacedRepetitionLadder(t *testing.T) {
+	tod := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	rec := SpacedBasedRepetitionInterval(tod, time.UTC)
+	// The default ladder keeps the schedule reminders were created with before ladders were configurable
+	if !slices.Equal(rec.SpacedBasedRepetitionDays, []int{0, 1, 2, 3, 5, 7, 7, 7}) {
+		t.Fatalf("unexpected default ladder %v", rec.SpacedBasedRepetitionDays)
+	}
+
+	rec = New(SpacedBasedRepetition, &tod, time.UTC, WithSpacedRepetitionLadder([]int{1, 3, 7}))
+	rec.LadderStep = 1
+	if got := rec.RemainingLadder(); !slices.Equal(got, []int{1, 3, 7}) {
+		t.Fatalf("expected the remaining days between reviews, got %v", got)
+	}
+
+	if ValidateLadder([]int{1, 3, 7}) != nil || ValidateLadder(nil) == nil || ValidateLadder([]int{0}) == nil || ValidateLadder([]int{366}) == nil {
+		t.Fatalf("unexpected ladder validation")
+	}
+}
@@ -15,6 +15,17 @@ type User struct {
 	Location     *time.Location `json:"-" bson:"-"` // Ignore
 	CreatedAt    time.Time      `json:"createdAt" bson:"createdAt"`
 	UpdatedAt    time.Time      `json:"updatedAt" bson:"updatedAt"`
+
+	// Set when Telegram refuses delivery (bot blocked, chat not found); reminders are paused until /start
+	Unreachable       bool       `json:"unreachable" bson:"unreachable"`
+	UnreachableSince  *time.Time `json:"unreachableSince,omitempty" bson:"unreachableSince"`
+	UnreachableReason string     `json:"unreachableReason,omitempty" bson:"unreachableReason"`
+
+	// Set while all reminders are paused (vacation mode); occurrences falling before it are skipped
+	PausedUntil *time.Time `json:"pausedUntil,omitempty" bson:"pausedUntil"`
+
+	// Set when the user gets one daily digest instead of individual reminders
+	Digest *Digest `json:"digest,omitempty" bson:"digest"`
 }
 
 // NewUser creates a new user entity
@@ -45,6 +56,46 @@ func (u *User) UpdateInfo(userName, firstName, lastName string) {
 	u.UpdatedAt = time.Now()
 }
 
+// MarkUnreachable records that messages can no longer be delivered to the user
+func (u *User) MarkUnreachable(reason string) {
+	now := time.Now()
+	u.Unreachable = true
+	u.UnreachableSince = &now
+	u.UnreachableReason = reason
+	u.UpdatedAt = now
+}
+
+// MarkReachable clears the unreachable state after the user contacted the bot again
+func (u *User) MarkReachable() {
+	u.Unreachable = false
+	u.UnreachableSince = nil
+	u.UnreachableReason = ""
+	u.UpdatedAt = time.Now()
+}
+
+// PauseUntil pauses all reminders of the user until the given time
+func (u *User) PauseUntil(until time.Time) {
+	u.PausedUntil = &until
+	u.UpdatedAt = time.Now()
+}
+
+// Resume ends a pause of all reminders
+func (u *User) Resume() {
+	u.PausedUntil = nil
+	u.UpdatedAt = time.Now()
+}
+
+// IsPaused reports whether all reminders of the user are paused at the given time
+func (u *User) IsPaused(now time.Time) bool {
+	return u.PausedUntil != nil && now.Before(*u.PausedUntil)
+}
+
+// SetDigest switches the user to a daily digest, or back to individual reminders when nil
+func (u *User) SetDigest(digest *Digest) {
+	u.Digest = digest
+	u.UpdatedAt = time.Now()
+}
+
 func (u *User) GetLocation() *time.Location {
 	// If the private field is nil, try to load it from the stored string.
 	if u.Location == nil && u.LocationName != "" {
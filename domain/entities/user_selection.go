@@ -1,19 +1,43 @@
 package entities
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // UserSelection represents a user's current selection state for creating reminders
 type UserSelection struct {
 	RecurrenceType  RecurrenceType `json:"recurrenceType" bson:"recurrenceType"`
 	WeekOptions     []time.Weekday `json:"weekOptions" bson:"weekOptions"`
 	MonthOptions    []int          `json:"monthOptions" bson:"monthOptions"`
+	ClampToMonthEnd bool           `json:"clampToMonthEnd,omitempty" bson:"clampToMonthEnd,omitempty"`
+	NthWeekdays     []NthWeekday   `json:"nthWeekdays,omitempty" bson:"nthWeekdays,omitempty"`
 	SelectedDate    time.Time      `json:"selectedDate" bson:"selectedDate"`
 	SelectedTime    string         `json:"selectedTime" bson:"selectedTime"`
-	IntervalDays    int            `json:"intervalDays" bson:"intervalDays"`
+	SelectedTimes   []string       `json:"selectedTimes,omitempty" bson:"selectedTimes,omitempty"`
+	Interval        int            `json:"interval" bson:"interval"`
+	IntervalUnit    IntervalUnit   `json:"intervalUnit" bson:"intervalUnit"`
+	ActiveWindow    *ActiveWindow  `json:"activeWindow,omitempty" bson:"activeWindow,omitempty"`
+	RRule           string         `json:"rrule,omitempty" bson:"rrule,omitempty"`
+	EndDate         *time.Time     `json:"endDate,omitempty" bson:"endDate,omitempty"`
+	MaxOccurrences  int            `json:"maxOccurrences,omitempty" bson:"maxOccurrences,omitempty"`
+	BusinessDays    *BusinessDays  `json:"businessDays,omitempty" bson:"businessDays,omitempty"`
 	ReminderMessage string         `json:"reminderMessage" bson:"reminderMessage"`
 	CustomTime      bool           `json:"customTime" bson:"customTime"`
 	CustomText      bool           `json:"customText" bson:"customText"`
 	CustomInterval  bool           `json:"customInterval" bson:"customInterval"`
+	AdaptiveRecall  bool           `json:"adaptiveRecall,omitempty" bson:"adaptiveRecall,omitempty"`
+	Ladder          []int          `json:"ladder,omitempty" bson:"ladder,omitempty"`
+	CustomLadder    bool           `json:"customLadder,omitempty" bson:"customLadder,omitempty"`
+
+	// EditReminderID is the reminder being edited, zero while creating a new one
+	EditReminderID int64 `json:"editReminderId,omitempty" bson:"editReminderId,omitempty"`
+	// CustomPauseDate waits for the date to pause all reminders until
+	CustomPauseDate bool `json:"customPauseDate,omitempty" bson:"customPauseDate,omitempty"`
+
+	// The filter and page shown in the reminders list
+	ListFilter string `json:"listFilter,omitempty" bson:"listFilter,omitempty"`
+	ListPage   int    `json:"listPage,omitempty" bson:"listPage,omitempty"`
 }
 
 // NewUserSelection creates a new user selection with default values
@@ -27,14 +51,54 @@ func NewUserSelection() *UserSelection {
 // SetRecurrenceType sets the recurrence type and updates weekly flag
 func (us *UserSelection) SetRecurrenceType(recurrenceType RecurrenceType) {
 	us.RecurrenceType = recurrenceType
+	us.SelectedTimes = nil
+	us.NthWeekdays = nil
+	us.EditReminderID = 0
 }
 
 // SetSelectedTime sets the selected time
 func (us *UserSelection) SetSelectedTime(time string) {
 	us.SelectedTime = time
+	us.SelectedTimes = nil
 	us.CustomTime = false
 }
 
+// ToggleSelectedTime adds a time of day to the selection or removes it if already picked.
+// The times are kept sorted and SelectedTime holds the earliest one.
+func (us *UserSelection) ToggleSelectedTime(time string) {
+	times := us.GetSelectedTimes()
+	if i := slices.Index(times, time); i >= 0 {
+		times = slices.Delete(slices.Clone(times), i, i+1)
+	} else {
+		times = append(slices.Clone(times), time)
+	}
+	us.SetSelectedTimes(times)
+}
+
+// SetSelectedTimes replaces the selected times of day
+func (us *UserSelection) SetSelectedTimes(times []string) {
+	times = slices.Clone(times)
+	slices.Sort(times)
+	times = slices.Compact(times)
+	us.SelectedTimes = times
+	us.SelectedTime = ""
+	if len(times) > 0 {
+		us.SelectedTime = times[0]
+	}
+	us.CustomTime = false
+}
+
+// GetSelectedTimes returns every selected time of day
+func (us *UserSelection) GetSelectedTimes() []string {
+	if len(us.SelectedTimes) > 0 {
+		return us.SelectedTimes
+	}
+	if us.SelectedTime != "" {
+		return []string{us.SelectedTime}
+	}
+	return nil
+}
+
 // SetCustomTime enables custom time input
 func (us *UserSelection) SetCustomTime() {
 	us.CustomTime = true
@@ -61,7 +125,43 @@ func (us *UserSelection) StartCustomInterval() {
 // Enables custom interval input
 func (us *UserSelection) SetCustomInterval(interval int) {
 	us.CustomInterval = false
-	us.IntervalDays = interval
+	us.Interval = interval
+}
+
+// SetSpacedRepetition chooses adaptive reviews or a fixed ladder of days between reviews;
+// an empty ladder means the default one
+func (us *UserSelection) SetSpacedRepetition(adaptive bool, ladder []int) {
+	us.AdaptiveRecall = adaptive
+	us.Ladder = ladder
+	us.CustomLadder = false
+}
+
+// StartCustomLadder asks for the days between reviews next
+func (us *UserSelection) StartCustomLadder() {
+	us.SetSpacedRepetition(false, nil)
+	us.CustomLadder = true
+}
+
+// StartCustomPauseDate asks for the date to pause all reminders until next
+func (us *UserSelection) StartCustomPauseDate() {
+	us.CustomPauseDate = true
+}
+
+// SetListFilter shows the reminders matching a filter in the reminders list, from the first page
+func (us *UserSelection) SetListFilter(filter string) {
+	us.ListFilter = filter
+	us.ListPage = 0
+}
+
+// SetIntervalUnit sets the interval unit and asks for the number of units next
+func (us *UserSelection) SetIntervalUnit(unit IntervalUnit) {
+	us.IntervalUnit = unit
+	us.StartCustomInterval()
+}
+
+// SetActiveWindow limits a minute or hour interval to a daily window; nil means all day
+func (us *UserSelection) SetActiveWindow(window *ActiveWindow) {
+	us.ActiveWindow = window
 }
 
 // SetCustomText enables custom text input
@@ -69,6 +169,62 @@ func (us *UserSelection) SetSelectedDate(selectedDate time.Time) {
 	us.SelectedDate = selectedDate
 }
 
+// SetNthWeekday schedules a monthly reminder on a weekday of the month instead of on days of the month
+func (us *UserSelection) SetNthWeekday(nthWeekday NthWeekday) {
+	us.NthWeekdays = []NthWeekday{nthWeekday}
+	us.MonthOptions = []int{}
+	us.ClampToMonthEnd = false
+}
+
+// SetEnd sets when a recurring reminder stops; a nil date and zero count mean never
+func (us *UserSelection) SetEnd(endDate *time.Time, maxOccurrences int) {
+	us.EndDate = endDate
+	us.MaxOccurrences = maxOccurrences
+}
+
+// GetHolidayPolicy returns how weekends and holidays are handled, firing on any day by default
+func (us *UserSelection) GetHolidayPolicy() HolidayPolicy {
+	if us.BusinessDays == nil {
+		return HolidayPolicyNone
+	}
+	return us.BusinessDays.Policy
+}
+
+// CycleHolidayPolicy switches to the next way of handling weekends and holidays and returns it
+func (us *UserSelection) CycleHolidayPolicy() HolidayPolicy {
+	if us.BusinessDays == nil {
+		us.BusinessDays = &BusinessDays{}
+	}
+	us.BusinessDays.Policy = us.BusinessDays.Policy.Next()
+	return us.BusinessDays.Policy
+}
+
+// StartEdit loads the schedule and message of an existing reminder for editing
+func (us *UserSelection) StartEdit(reminder *Reminder) {
+	us.Clear()
+	us.EditReminderID = reminder.ID
+	us.ReminderMessage = reminder.Message
+
+	rec := reminder.Recurrence
+	if rec == nil {
+		return
+	}
+	us.RecurrenceType = rec.Type
+	us.WeekOptions = append([]time.Weekday{}, rec.Weekdays...)
+	us.MonthOptions = append([]int{}, rec.DayOfMonth...)
+	us.ClampToMonthEnd = rec.ClampToMonthEnd
+	us.NthWeekdays = slices.Clone(rec.NthWeekdays)
+	if rec.StartDate != nil {
+		us.SelectedDate = rec.StartDate.In(rec.GetLocation())
+	}
+	us.SetSelectedTimes(rec.GetTimesOfDay())
+}
+
+// IsEditing reports whether the selection changes an existing reminder instead of creating one
+func (us *UserSelection) IsEditing() bool {
+	return us.EditReminderID != 0
+}
+
 // Clear resets the user selection to default values
 func (us *UserSelection) Clear() {
 	*us = *NewUserSelection()
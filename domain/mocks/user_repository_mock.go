@@ -17,6 +17,10 @@ type MockUserRepository struct {
 	UpdateUserLanguageFunc  func(userID int64, language string) error
 	UpdateLocationFunc      func(userID int64, location string) error
 	UpdateUserInfoFunc      func(userID int64, userName, firstName, lastName string) error
+	MarkUnreachableFunc     func(userID int64, reason string) error
+	MarkReachableFunc       func(userID int64) error
+	SetPausedUntilFunc      func(userID int64, until *time.Time) error
+	SetDigestFunc           func(userID int64, digest *entities.Digest) error
 	DeleteUserFunc          func(userID int64) error
 	GetUserSelectionFunc    func(userID int64) (*entities.UserSelection, error)
 	UpdateUserSelectionFunc func(userID int64, selection *entities.UserSelection) error
@@ -107,6 +111,69 @@ func (m *MockUserRepository) UpdateUserInfo(userID int64, userName, firstName, l
 	return nil
 }
 
+func (m *MockUserRepository) MarkUnreachable(userID int64, reason string) error {
+	if m.MarkUnreachableFunc != nil {
+		return m.MarkUnreachableFunc(userID, reason)
+	}
+	if user, exists := m.Users[userID]; exists {
+		user.MarkUnreachable(reason)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) MarkReachable(userID int64) error {
+	if m.MarkReachableFunc != nil {
+		return m.MarkReachableFunc(userID)
+	}
+	if user, exists := m.Users[userID]; exists {
+		user.MarkReachable()
+	}
+	return nil
+}
+
+func (m *MockUserRepository) SetPausedUntil(userID int64, until *time.Time) error {
+	if m.SetPausedUntilFunc != nil {
+		return m.SetPausedUntilFunc(userID, until)
+	}
+	if user, exists := m.Users[userID]; exists {
+		if until == nil {
+			user.Resume()
+		} else {
+			user.PauseUntil(*until)
+		}
+	}
+	return nil
+}
+
+func (m *MockUserRepository) SetDigest(userID int64, digest *entities.Digest) error {
+	if m.SetDigestFunc != nil {
+		return m.SetDigestFunc(userID, digest)
+	}
+	if user, exists := m.Users[userID]; exists {
+		user.SetDigest(digest)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) GetUsersWithDueDigest(now time.Time) ([]entities.User, error) {
+	var users []entities.User
+	for _, user := range m.Users {
+		if user.Digest != nil && !user.Digest.NextAt.After(now) {
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
+func (m *MockUserRepository) ClaimDigest(userID int64, scheduledAt, next time.Time) (bool, error) {
+	user, exists := m.Users[userID]
+	if !exists || user.Digest == nil || !user.Digest.NextAt.Equal(scheduledAt) {
+		return false, nil
+	}
+	user.Digest.NextAt = next
+	return true, nil
+}
+
 func (m *MockUserRepository) DeleteUser(userID int64) error {
 	if m.DeleteUserFunc != nil {
 		return m.DeleteUserFunc(userID)
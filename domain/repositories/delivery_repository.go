@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// DeliveryRepository defines the interface for reminder delivery history persistence
+type DeliveryRepository interface {
+	// CreateDelivery stores a new delivery record and assigns its ID
+	CreateDelivery(delivery *entities.Delivery) (*entities.Delivery, error)
+
+	// UpdateDelivery replaces an existing delivery record
+	UpdateDelivery(delivery *entities.Delivery) error
+
+	// GetDelivery returns a delivery by ID, or nil if it does not exist
+	GetDelivery(deliveryID int64) (*entities.Delivery, error)
+
+	// GetDeliveriesByReminder returns the delivery history of a reminder, newest first.
+	// A non-positive limit returns all records.
+	GetDeliveriesByReminder(reminderID int64, limit int) ([]entities.Delivery, error)
+
+	// GetDueRetries returns deliveries waiting for a retry that is due at the given time
+	GetDueRetries(now time.Time) ([]entities.Delivery, error)
+
+	// GetDeliveriesByStatus returns all deliveries with the given status, newest first
+	GetDeliveriesByStatus(status entities.DeliveryStatus) ([]entities.Delivery, error)
+
+	// AcknowledgeDeliveries marks all unacknowledged successful deliveries of a reminder as acknowledged
+	AcknowledgeDeliveries(reminderID int64, at time.Time) error
+
+	// DeleteDeliveriesByReminder removes the delivery history of a reminder
+	DeleteDeliveriesByReminder(reminderID int64) error
+}
@@ -13,8 +13,10 @@ type ReminderRepository interface {
 	CreateDailyReminder(timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error)
 	CreateWeeklyReminder(daysOfWeek []time.Weekday, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error)
 	CreateMonthlyReminder(daysOfMonth []int, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error)
-	CreateIntervalReminder(intervalDays int, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error)
+	CreateIntervalReminder(interval int, unit entities.IntervalUnit, window *entities.ActiveWindow, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error)
 	CreateSpaceBasedRepetitionReminder(timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error)
+	// CreateReminder stores a reminder built by the caller and assigns its ID
+	CreateReminder(reminder *entities.Reminder) (*entities.Reminder, error)
 
 	// Reminder retrieval
 	GetReminders() ([]entities.Reminder, error)
@@ -28,5 +30,33 @@ type ReminderRepository interface {
 
 	// Reminder scheduling
 	GetActiveReminders() ([]entities.Reminder, error)
+	// GetDueReminders returns up to limit reminders with work due at now (an active occurrence,
+	// a snoozed re-delivery or a nag), ordered by the time it is due and ID and starting after
+	// the cursor for paging
+	GetDueReminders(now time.Time, after DueCursor, limit int) ([]entities.Reminder, error)
 	UpdateNextTrigger(reminderID int64, nextTrigger time.Time) error
+
+	// Reminder leasing, so that only one notifier instance processes a reminder at a time.
+	// ClaimReminder atomically takes a lease on a reminder that is not leased or whose lease
+	// has expired and returns its current state; it returns nil if another claim holds it.
+	ClaimReminder(reminderID int64, owner string, now time.Time, leaseFor time.Duration) (*entities.Reminder, error)
+	// ReleaseReminder gives up a lease held by owner
+	ReleaseReminder(reminderID int64, owner string) error
+}
+
+// DueCursor is the position of a reminder among due reminders, ordered by the time the notifier
+// has work for it and ID. The zero value starts from the beginning.
+type DueCursor struct {
+	At time.Time
+	ID int64
+}
+
+// DueCursorAfter returns the cursor continuing after the last reminder of a page
+func DueCursorAfter(page []entities.Reminder) DueCursor {
+	last := page[len(page)-1]
+	cursor := DueCursor{ID: last.ID}
+	if at := last.NextDueAt(); at != nil {
+		cursor.At = *at
+	}
+	return cursor
 }
@@ -1,6 +1,10 @@
 package repositories
 
-import "github.com/ivanenkomaksym/remindme_bot/domain/entities"
+import (
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
@@ -12,6 +16,17 @@ type UserRepository interface {
 	UpdateUserLanguage(userID int64, language string) error
 	UpdateLocation(userID int64, location string) error
 	UpdateUserInfo(userID int64, userName, firstName, lastName string) error
+	MarkUnreachable(userID int64, reason string) error
+	MarkReachable(userID int64) error
+	// SetPausedUntil pauses all reminders of the user until the given time, or resumes them when nil
+	SetPausedUntil(userID int64, until *time.Time) error
+	// SetDigest switches the user to a daily digest, or back to individual reminders when nil
+	SetDigest(userID int64, digest *entities.Digest) error
+	// GetUsersWithDueDigest returns the users whose digest is due at the given time
+	GetUsersWithDueDigest(now time.Time) ([]entities.User, error)
+	// ClaimDigest moves the next digest of a user from scheduledAt to next. Returns false when
+	// another instance claimed it first, so each digest is sent once.
+	ClaimDigest(userID int64, scheduledAt, next time.Time) (bool, error)
 	DeleteUser(userID int64) error
 }
 
@@ -53,12 +53,26 @@ type ReminderRequest struct {
 	RecurrenceType  string         `json:"recurrenceType"`
 	WeekOptions     []time.Weekday `json:"weekOptions,omitempty"`
 	MonthOptions    []int          `json:"monthOptions,omitempty"`
+	ClampToMonthEnd bool           `json:"clampToMonthEnd,omitempty"`
 	SelectedDate    string         `json:"selectedDate,omitempty"` // ISO format date
 	SelectedTime    string         `json:"selectedTime"`           // HH:MM format
-	IntervalDays    int            `json:"intervalDays,omitempty"`
+	SelectedTimes   []string       `json:"selectedTimes,omitempty"`
+	Interval        int            `json:"interval,omitempty"`
+	IntervalUnit    string         `json:"intervalUnit,omitempty"` // minutes, hours, days or weeks
+	ActiveFrom      string         `json:"activeFrom,omitempty"`   // HH:MM, only for minute and hour intervals
+	ActiveTo        string         `json:"activeTo,omitempty"`     // HH:MM, only for minute and hour intervals
+	RRule           string         `json:"rrule,omitempty"`        // RFC 5545 rule, only for RRule type
+	EndDate         string         `json:"endDate,omitempty"`      // ISO format date, last day of a recurring reminder
+	MaxOccurrences  int            `json:"maxOccurrences,omitempty"`
+	HolidayPolicy   string         `json:"holidayPolicy,omitempty"`  // skip or shift, for weekends and public holidays
+	HolidayCountry  string         `json:"holidayCountry,omitempty"` // ISO 3166 code of the holiday calendar
+	ExcludedDates   []string       `json:"excludedDates,omitempty"`  // ISO format dates to skip
 	ReminderMessage string         `json:"reminderMessage"`
 	IsValid         bool           `json:"isValid"`
 	ErrorMessage    string         `json:"errorMessage,omitempty"`
+
+	// Only for Monthly on a weekday of the month, e.g. the first Monday
+	NthWeekdays []entities.NthWeekday `json:"nthWeekdays,omitempty"`
 }
 
 // NewNLPService creates a new NLP service
@@ -157,12 +171,24 @@ Current date and time: %s
 
 You must respond ONLY with valid JSON in the following format:
 {
-    "recurrenceType": "Once|Daily|Weekly|Monthly|Interval",
+    "recurrenceType": "Once|Daily|Weekly|Monthly|Yearly|Interval|RRule",
     "weekOptions": [0,1,2,3,4,5,6], // Only for Weekly - Sunday=0, Monday=1, etc.
-    "monthOptions": [1,2,3,...,31], // Only for Monthly - days of month
-    "selectedDate": "2025-01-15", // ISO date format, only for Once
+    "monthOptions": [1,2,3,...,31], // Only for Monthly - days of month, negative counts from the month end (-1 = last day)
+    "clampToMonthEnd": true, // Optional, only for Monthly - move days a month lacks (e.g. 31st) to its last day
+    "nthWeekdays": [{"ordinal": 1, "weekday": 1}], // Only for Monthly instead of monthOptions - ordinal 1 to 4 or -1 for last, weekday as in weekOptions
+    "selectedDate": "2025-01-15", // ISO date format, only for Once and Yearly, optional start date for RRule
     "selectedTime": "14:30", // HH:MM format (24-hour)
-    "intervalDays": 5, // Only for Interval type
+    "selectedTimes": ["08:00", "14:00", "20:00"], // Optional, only for Daily, Weekly and Monthly with several times a day
+    "interval": 5, // Only for Interval type
+    "intervalUnit": "minutes|hours|days|weeks", // Only for Interval type
+    "activeFrom": "08:00", // Optional, only for minute and hour intervals
+    "activeTo": "22:00", // Optional, only for minute and hour intervals
+    "rrule": "FREQ=MONTHLY;BYDAY=-1FR", // Only for RRule type
+    "endDate": "2026-12-31", // Optional last day of a recurring reminder
+    "maxOccurrences": 10, // Optional number of times a recurring reminder fires
+    "holidayPolicy": "skip|shift", // Optional, recurring only - skip weekends and holidays or move to the next business day
+    "holidayCountry": "UA", // Optional, only with holidayPolicy when the country is named
+    "excludedDates": ["2025-12-24"], // Optional, recurring only - days the reminder must not fire
     "reminderMessage": "extracted message",
     "isValid": true, // false if request is incomplete or unclear
     "errorMessage": "reason why invalid" // only if isValid is false
@@ -170,15 +196,26 @@ You must respond ONLY with valid JSON in the following format:
 
 Rules:
 1. For "Once": set selectedDate and selectedTime
-2. For "Daily": set selectedTime only
+2. For "Daily": set selectedTime only; for several times a day ("at 8, 14 and 20") also set selectedTimes with every time, the same applies to Weekly and Monthly
 3. For "Weekly": set selectedTime and weekOptions (array of weekday numbers)
-4. For "Monthly": set selectedTime and monthOptions (array of day numbers)
-5. For "Interval": set selectedTime and intervalDays
-6. Time parsing: "in X minutes/hours" means from now, "at X" means specific time, "tomorrow" means next day
-7. Week parsing: "weekdays" = [1,2,3,4,5], "weekends" = [0,6], "every day" = Daily
-8. If time is missing or unclear, set isValid to false
-9. Extract the actual reminder message/task from the text
-10. Handle both English and Ukrainian text`, userTimezone, time.Now().Format("2006-01-02 15:04:05 MST"))
+4. For "Monthly": set selectedTime and monthOptions (array of day numbers); "last day of the month" = [-1], "second to last day" = [-2].
+   When a day such as the 31st should still fire in shorter months ("on the 31st or the last day"), set clampToMonthEnd to true.
+   For a weekday of the month set nthWeekdays instead: "first Monday of the month" = [{"ordinal": 1, "weekday": 1}], "last Thursday" = [{"ordinal": -1, "weekday": 4}]
+5. For "Yearly" (birthdays, anniversaries): set selectedDate to the month and day in any year, and selectedTime
+6. For "Interval": set selectedTime, interval and intervalUnit; "every 2 hours" = interval 2, intervalUnit "hours".
+   For minute and hour intervals limited to part of the day ("from 8 to 22", "during the day") also set activeFrom and activeTo, and use activeFrom as selectedTime
+7. For patterns the other types cannot express use "RRule": set selectedTime and rrule, an RFC 5545 rule using only FREQ=DAILY|WEEKLY|MONTHLY|YEARLY, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and BYSETPOS.
+   "2nd Tuesday of every other month" = "FREQ=MONTHLY;INTERVAL=2;BYDAY=2TU",
+   "every other week on Mon/Wed" = "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+   "first weekday of the quarter" = "FREQ=MONTHLY;BYMONTH=1,4,7,10;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1"
+8. For recurring reminders with an end: "until 2026-12-31" = endDate "2026-12-31", "for 10 days" with Daily = maxOccurrences 10
+9. For work reminders: "on business days" or "except holidays" = holidayPolicy "skip", "if it falls on a holiday, the next working day" = holidayPolicy "shift";
+   days off named by the user ("except December 24") go to excludedDates. Set holidayCountry only when the country is named ("Ukrainian holidays" = "UA")
+10. Time parsing: "in X minutes/hours" means from now, "at X" means specific time, "tomorrow" means next day
+11. Week parsing: "weekdays" = [1,2,3,4,5], "weekends" = [0,6], "every day" = Daily
+12. If time is missing or unclear, set isValid to false
+13. Extract the actual reminder message/task from the text
+14. Handle both English and Ukrainian text`, userTimezone, time.Now().Format("2006-01-02 15:04:05 MST"))
 }
 
 // buildPrompt creates the user prompt
@@ -200,6 +237,9 @@ func (s *nlpService) convertToUserSelection(req *ReminderRequest, userTimezone s
 
 	// Set time
 	selection.SetSelectedTime(req.SelectedTime)
+	if len(req.SelectedTimes) > 1 && recurrenceType.SupportsTimesOfDay() {
+		selection.SetSelectedTimes(req.SelectedTimes)
+	}
 
 	// Set message
 	selection.SetReminderMessage(req.ReminderMessage)
@@ -235,8 +275,81 @@ func (s *nlpService) convertToUserSelection(req *ReminderRequest, userTimezone s
 		selection.WeekOptions = req.WeekOptions
 	case entities.Monthly:
 		selection.MonthOptions = req.MonthOptions
+		selection.ClampToMonthEnd = req.ClampToMonthEnd
+		for _, n := range req.NthWeekdays {
+			if !n.IsValid() {
+				return nil, fmt.Errorf("invalid weekday of month: %+v", n)
+			}
+		}
+		selection.NthWeekdays = req.NthWeekdays
+	case entities.Yearly:
+		date, err := time.Parse("2006-01-02", req.SelectedDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date format: %s", req.SelectedDate)
+		}
+		loc, err := time.LoadLocation(userTimezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		selection.SetSelectedDate(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc))
+	case entities.RRule:
+		selection.RRule = req.RRule
+		if req.SelectedDate != "" {
+			date, err := time.Parse("2006-01-02", req.SelectedDate)
+			if err != nil {
+				return nil, fmt.Errorf("invalid date format: %s", req.SelectedDate)
+			}
+			loc, err := time.LoadLocation(userTimezone)
+			if err != nil {
+				loc = time.UTC
+			}
+			selection.SetSelectedDate(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc))
+		}
 	case entities.Interval:
-		selection.SetCustomInterval(req.IntervalDays)
+		unit, err := entities.ToIntervalUnit(req.IntervalUnit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval unit: %s", req.IntervalUnit)
+		}
+		selection.IntervalUnit = unit
+		selection.SetCustomInterval(req.Interval)
+		if req.ActiveFrom != "" || req.ActiveTo != "" {
+			window, err := entities.NewActiveWindow(req.ActiveFrom, req.ActiveTo)
+			if err != nil {
+				return nil, fmt.Errorf("invalid active window %s-%s: %w", req.ActiveFrom, req.ActiveTo, err)
+			}
+			selection.SetActiveWindow(window)
+		}
+	}
+
+	if recurrenceType != entities.Once && (req.EndDate != "" || req.MaxOccurrences > 0) {
+		var endDate *time.Time
+		if req.EndDate != "" {
+			date, err := time.Parse("2006-01-02", req.EndDate)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end date format: %s", req.EndDate)
+			}
+			loc, err := time.LoadLocation(userTimezone)
+			if err != nil {
+				loc = time.UTC
+			}
+			// The reminder still fires on its last day
+			end := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, loc)
+			endDate = &end
+		}
+		selection.SetEnd(endDate, req.MaxOccurrences)
+	}
+
+	if recurrenceType != entities.Once && (req.HolidayPolicy != "" || len(req.ExcludedDates) > 0) {
+		policy := entities.HolidayPolicy(req.HolidayPolicy)
+		if policy == entities.HolidayPolicyNone {
+			// Excluded dates alone mean skipping them
+			policy = entities.HolidayPolicySkip
+		}
+		businessDays := &entities.BusinessDays{Policy: policy, Country: strings.ToUpper(req.HolidayCountry), ExcludedDates: req.ExcludedDates}
+		if err := businessDays.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid business days: %w", err)
+		}
+		selection.BusinessDays = businessDays
 	}
 
 	return selection, nil
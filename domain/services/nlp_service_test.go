@@ -386,3 +386,166 @@ func TestNLPService_LanguageHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestNLPService_ConvertIntervalWithActiveWindow(t *testing.T) {
+	s := &nlpService{}
+	req := &ReminderRequest{
+		RecurrenceType:  "Interval",
+		SelectedTime:    "08:00",
+		Interval:        2,
+		IntervalUnit:    "hours",
+		ActiveFrom:      "08:00",
+		ActiveTo:        "22:00",
+		ReminderMessage: "drink water",
+	}
+
+	selection, err := s.convertToUserSelection(req, "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selection.Interval != 2 || selection.IntervalUnit != entities.IntervalUnitHours {
+		t.Fatalf("expected every 2 hours, got every %d %s", selection.Interval, selection.IntervalUnit)
+	}
+	if selection.ActiveWindow == nil || selection.ActiveWindow.From != "08:00" || selection.ActiveWindow.To != "22:00" {
+		t.Fatalf("expected active window 08:00-22:00, got %+v", selection.ActiveWindow)
+	}
+
+	req.IntervalUnit = "fortnights"
+	if _, err := s.convertToUserSelection(req, "UTC"); err == nil {
+		t.Fatalf("expected error for unknown interval unit")
+	}
+}
+
+func TestNLPService_ConvertEnd(t *testing.T) {
+	s := &nlpService{}
+	req := &ReminderRequest{
+		RecurrenceType:  "Weekly",
+		WeekOptions:     []time.Weekday{time.Monday},
+		SelectedTime:    "09:00",
+		EndDate:         "2026-12-31",
+		ReminderMessage: "standup",
+	}
+
+	selection, err := s.convertToUserSelection(req, "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC)
+	if selection.EndDate == nil || !selection.EndDate.Equal(want) {
+		t.Fatalf("expected end at %v, got %v", want, selection.EndDate)
+	}
+
+	req.EndDate = ""
+	req.MaxOccurrences = 10
+	selection, err = s.convertToUserSelection(req, "UTC")
+	if err != nil || selection.MaxOccurrences != 10 || selection.EndDate != nil {
+		t.Fatalf("expected 10 occurrences, got %+v (%v)", selection, err)
+	}
+}
+
+func TestNLPService_ConvertMultipleTimesOfDay(t *testing.T) {
+	s := &nlpService{}
+	req := &ReminderRequest{
+		RecurrenceType:  "Daily",
+		SelectedTime:    "08:00",
+		SelectedTimes:   []string{"20:00", "08:00", "14:00"},
+		ReminderMessage: "take a pill",
+	}
+
+	selection, err := s.convertToUserSelection(req, "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := selection.GetSelectedTimes(); len(got) != 3 || got[0] != "08:00" || got[2] != "20:00" || selection.SelectedTime != "08:00" {
+		t.Fatalf("expected three sorted times, got %v", got)
+	}
+}
+
+func TestNLPService_ConvertLastDayOfMonth(t *testing.T) {
+	s := &nlpService{}
+	req := &ReminderRequest{
+		RecurrenceType:  "Monthly",
+		MonthOptions:    []int{31, -1},
+		ClampToMonthEnd: true,
+		SelectedTime:    "10:00",
+		ReminderMessage: "pay rent",
+	}
+
+	selection, err := s.convertToUserSelection(req, "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(selection.MonthOptions) != 2 || selection.MonthOptions[1] != -1 || !selection.ClampToMonthEnd {
+		t.Fatalf("expected the 31st and last day with clamping, got %v (clamp %v)", selection.MonthOptions, selection.ClampToMonthEnd)
+	}
+}
+
+func TestNLPService_ConvertYearly(t *testing.T) {
+	s := &nlpService{}
+	req := &ReminderRequest{
+		RecurrenceType:  "Yearly",
+		SelectedDate:    "2024-02-29",
+		SelectedTime:    "09:00",
+		ReminderMessage: "birthday",
+	}
+
+	selection, err := s.convertToUserSelection(req, "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selection.RecurrenceType != entities.Yearly || selection.SelectedDate.Month() != time.February || selection.SelectedDate.Day() != 29 {
+		t.Fatalf("expected a yearly reminder on February 29, got %+v", selection)
+	}
+
+	req.SelectedDate = ""
+	if _, err := s.convertToUserSelection(req, "UTC"); err == nil {
+		t.Fatalf("expected error without a date")
+	}
+}
+
+func TestNLPService_ConvertNthWeekday(t *testing.T) {
+	s := &nlpService{}
+	req := &ReminderRequest{
+		RecurrenceType:  "Monthly",
+		NthWeekdays:     []entities.NthWeekday{{Ordinal: entities.OrdinalLast, Weekday: time.Thursday}},
+		SelectedTime:    "18:00",
+		ReminderMessage: "book club",
+	}
+
+	selection, err := s.convertToUserSelection(req, "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(selection.NthWeekdays) != 1 || selection.NthWeekdays[0].Ordinal != -1 || selection.NthWeekdays[0].Weekday != time.Thursday {
+		t.Fatalf("expected the last Thursday, got %v", selection.NthWeekdays)
+	}
+
+	req.NthWeekdays = []entities.NthWeekday{{Ordinal: 5, Weekday: time.Monday}}
+	if _, err := s.convertToUserSelection(req, "UTC"); err == nil {
+		t.Fatalf("expected error for a fifth weekday")
+	}
+}
+
+func TestNLPService_ConvertBusinessDays(t *testing.T) {
+	s := &nlpService{}
+	req := &ReminderRequest{
+		RecurrenceType:  "Daily",
+		SelectedTime:    "09:30",
+		HolidayCountry:  "ua",
+		ExcludedDates:   []string{"2025-12-24"},
+		ReminderMessage: "stand-up",
+	}
+
+	selection, err := s.convertToUserSelection(req, "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selection.BusinessDays == nil || selection.BusinessDays.Policy != entities.HolidayPolicySkip || selection.BusinessDays.Country != "UA" {
+		t.Fatalf("expected excluded dates to skip with the UA calendar, got %+v", selection.BusinessDays)
+	}
+
+	req.HolidayPolicy = "postpone"
+	if _, err := s.convertToUserSelection(req, "UTC"); err == nil {
+		t.Fatalf("expected error for an unknown holiday policy")
+	}
+}
@@ -86,7 +86,8 @@ func NewMockOpenAIClient() *MockOpenAIClient {
 					Message: openai.ChatCompletionMessage{
 						Content: `{
 							"recurrenceType": "Interval",
-							"intervalDays": 3,
+							"interval": 3,
+							"intervalUnit": "days",
 							"selectedTime": "16:00",
 							"reminderMessage": "Water plants",
 							"isValid": true
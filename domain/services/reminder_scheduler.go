@@ -0,0 +1,14 @@
+package services
+
+import "github.com/ivanenkomaksym/remindme_bot/domain/entities"
+
+// ReminderScheduler is told about reminder and digest changes so that deliveries can be timed
+// precisely instead of waiting for the next notifier poll
+type ReminderScheduler interface {
+	// Schedule (re)arms the reminder at its next due time, or forgets it when nothing is pending
+	Schedule(reminder *entities.Reminder)
+	// Unschedule forgets a deleted reminder
+	Unschedule(reminderID int64)
+	// ScheduleDigest (re)arms the user's next digest, or forgets it when the digest is turned off
+	ScheduleDigest(user *entities.User)
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/config"
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/ivanenkomaksym/remindme_bot/domain/errors"
 	"github.com/ivanenkomaksym/remindme_bot/keyboards"
+	"github.com/ivanenkomaksym/remindme_bot/scheduler"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -63,6 +65,18 @@ func (b *botUseCase) HandleStartCommand(user *tgbotapi.User) (*keyboards.Selecti
 		return nil, err
 	}
 
+	// Coming back after blocking the bot resumes reminder delivery from now on
+	if userEntity.Unreachable {
+		if err := b.reminderUseCase.RescheduleMissedReminders(user.ID); err != nil {
+			log.Printf("Failed to reschedule reminders of user %d: %v", user.ID, err)
+		}
+		if err := b.userUseCase.MarkUserReachable(user.ID); err != nil {
+			log.Printf("Failed to mark user %d as reachable: %v", user.ID, err)
+		} else {
+			userEntity.MarkReachable()
+		}
+	}
+
 	// Auto-detect language if not set
 	if userEntity.Language == "" {
 		if lang, supported := keyboards.MapTelegramLanguageCodeToSupported(user.LanguageCode); supported {
@@ -131,6 +145,11 @@ func (b *botUseCase) HandleCallbackQuery(user *tgbotapi.User, message *tgbotapi.
 		return b.handleNlpTextInputCallback(user, userEntity)
 	}
 
+	// Handle snooze/done actions on delivered reminders
+	if keyboards.IsNotificationCallback(callbackData) {
+		return b.handleNotificationAction(user, message, callbackData, userEntity)
+	}
+
 	// Handle other callback types
 	keyboardType := keyboards.GetKeyboardType(callbackData)
 	switch keyboardType {
@@ -148,9 +167,41 @@ func (b *botUseCase) HandleCallbackQuery(user *tgbotapi.User, message *tgbotapi.
 		return b.handleWeekSelection(user, callbackData, userEntity, selection)
 	case keyboards.Month:
 		return b.handleMonthSelection(user, callbackData, userEntity, selection)
+	case keyboards.Interval:
+		return b.handleIntervalSelection(user, callbackData, userEntity, selection)
+	case keyboards.NthWeekday:
+		return b.handleNthWeekdaySelection(user, callbackData, userEntity, selection)
+	case keyboards.SpacedRepetition:
+		return b.handleSpacedRepetitionSelection(user, callbackData, userEntity, selection)
+	case keyboards.Edit:
+		return b.handleEditSelection(user, callbackData, userEntity, selection)
+	case keyboards.Agenda:
+		period, _ := keyboards.ParseAgendaPeriod(callbackData)
+		return b.handleAgenda(user, period, userEntity)
+	case keyboards.Digest:
+		return b.handleDigestSelection(user, callbackData, userEntity)
 	case keyboards.Message:
 		return b.handleMessageSelection(user, callbackData, userEntity, selection)
+	case keyboards.End:
+		return b.handleEndSelection(user, callbackData, userEntity, selection)
 	case keyboards.Reminders:
+		if callbackData == keyboards.CallbackRemindersFilters {
+			return &keyboards.SelectionResult{Text: keyboards.T(userEntity.Language).MsgSelectFilter, Markup: keyboards.GetRemindersFilterMarkup(userEntity.Language)}, nil
+		}
+		if filter, ok := keyboards.ParseRemindersFilter(callbackData); ok {
+			selection.SetListFilter(filter)
+			b.updateListView(user.ID, selection)
+		}
+		if page, ok := keyboards.ParseRemindersPage(callbackData); ok {
+			selection.ListPage = page
+			b.updateListView(user.ID, selection)
+		}
+		if id, ok := keyboards.ParseEditReminderID(callbackData); ok {
+			return b.handleEditReminder(user, id, userEntity, selection)
+		}
+		if id, ok := keyboards.ParseHistoryReminderID(callbackData); ok {
+			return b.handleReminderHistory(user, id, userEntity)
+		}
 		// Check if this is a delete action
 		if id, ok := keyboards.ParseDeleteReminderID(callbackData); ok {
 			err := b.reminderUseCase.DeleteReminder(id, user.ID)
@@ -158,6 +209,25 @@ func (b *botUseCase) HandleCallbackQuery(user *tgbotapi.User, message *tgbotapi.
 				log.Printf("Failed to delete reminder: %v", err)
 			}
 		}
+		if id, ok := keyboards.ParseToggleNagReminderID(callbackData); ok {
+			b.toggleNagging(user.ID, id)
+		}
+		if id, ok := keyboards.ParseCriticalReminderID(callbackData); ok {
+			b.toggleCritical(user.ID, id)
+		}
+		if id, ok := keyboards.ParsePauseReminderID(callbackData); ok {
+			if _, err := b.reminderUseCase.PauseReminder(user.ID, id); err != nil {
+				log.Printf("Failed to pause reminder %d: %v", id, err)
+			}
+		}
+		if id, ok := keyboards.ParseResumeReminderID(callbackData); ok {
+			if _, err := b.reminderUseCase.ResumeReminder(user.ID, id); err != nil {
+				log.Printf("Failed to resume reminder %d: %v", id, err)
+			}
+		}
+		if keyboards.IsPauseAllCallback(callbackData) {
+			return b.handlePauseAll(user, callbackData, userEntity, selection)
+		}
 		return b.handleRemindersList(user, userEntity)
 	default:
 		return nil, errors.NewDomainError("UNKNOWN_CALLBACK", "Unknown callback type", nil)
@@ -186,6 +256,16 @@ func (b *botUseCase) HandleTextMessage(user *tgbotapi.User, text string) (*keybo
 		return b.handleCustomIntervalInput(user, text, userEntity, selection)
 	}
 
+	// Handle custom ladder input
+	if selection.CustomLadder {
+		return b.handleCustomLadderInput(user, text, userEntity, selection)
+	}
+
+	// Handle the date to pause all reminders until
+	if selection.CustomPauseDate {
+		return b.handleCustomPauseDateInput(user, text, userEntity, selection)
+	}
+
 	return &keyboards.SelectionResult{Text: keyboards.T(userEntity.Language).MsgParsingFailed, Markup: keyboards.GetNavigationMenuMarkup(userEntity.Language)}, nil
 }
 
@@ -215,7 +295,7 @@ func (b *botUseCase) ProcessUserInput(message *tgbotapi.Message) (*keyboards.Sel
 			if err != nil {
 				return nil, err
 			}
-			return b.handleRemindersList(message.From, userEntity)
+			return b.openRemindersList(message.From, userEntity)
 		case "setup":
 			// Handle /setup command directly
 			userEntity, err := b.userUseCase.GetUser(message.From.ID)
@@ -237,6 +317,13 @@ func (b *botUseCase) ProcessUserInput(message *tgbotapi.Message) (*keyboards.Sel
 				Text:   keyboards.FormatAccountInfo(userEntity, userEntity.Language),
 				Markup: keyboards.GetAccountMenuMarkup(userEntity.Language),
 			}, nil
+		case keyboards.AgendaToday, keyboards.AgendaTomorrow, keyboards.AgendaWeek:
+			// Handle /today, /tomorrow and /week commands - show upcoming occurrences
+			userEntity, err := b.userUseCase.GetUser(message.From.ID)
+			if err != nil {
+				return nil, err
+			}
+			return b.handleAgenda(message.From, message.Command(), userEntity)
 		}
 	}
 
@@ -291,7 +378,7 @@ func (b *botUseCase) handleNavigationSelection(user *tgbotapi.User, callbackData
 	switch callbackData {
 	case keyboards.CallbackList:
 		// Handle /list command - show user's reminders
-		return b.handleRemindersList(user, userEntity)
+		return b.openRemindersList(user, userEntity)
 	case keyboards.CallbackSetup:
 		// Handle /setup command - show setup menu for creating reminders
 		return &keyboards.SelectionResult{
@@ -360,6 +447,91 @@ func (b *botUseCase) handleNlpTextInputCallback(user *tgbotapi.User, userEntity
 	return keyboards.HandleNlpTextInputCallback(userEntity, b.userUseCase.UpdateUserSelection)
 }
 
+func (b *botUseCase) handleNotificationAction(user *tgbotapi.User, message *tgbotapi.Message, callbackData string, userEntity *entities.User) (*keyboards.SelectionResult, error) {
+	action, ok := keyboards.ParseNotificationCallback(callbackData)
+	if !ok {
+		return nil, errors.NewDomainError("UNKNOWN_CALLBACK", "Unknown notification action", nil)
+	}
+
+	originalText := ""
+	if message != nil {
+		originalText = message.Text
+	}
+
+	if action.Grade != "" {
+		reminder, err := b.reminderUseCase.GradeRecall(user.ID, action.ReminderID, action.Grade)
+		if err != nil {
+			log.Printf("Failed to grade reminder %d: %v", action.ReminderID, err)
+			return nil, err
+		}
+		return keyboards.FormatGradedNotification(originalText, reminder.NextTrigger, userEntity.GetLocation(), userEntity.Language), nil
+	}
+
+	if action.Done {
+		if _, err := b.reminderUseCase.MarkReminderDone(user.ID, action.ReminderID); err != nil {
+			log.Printf("Failed to mark reminder %d as done: %v", action.ReminderID, err)
+			return nil, err
+		}
+		return keyboards.FormatDoneNotification(originalText, userEntity.Language), nil
+	}
+
+	until, ok := keyboards.SnoozeUntil(action.Snooze, time.Now(), userEntity.GetLocation())
+	if !ok {
+		return nil, errors.NewDomainError("INVALID_SNOOZE_OPTION", "Unknown snooze option", nil)
+	}
+	if _, err := b.reminderUseCase.SnoozeReminder(user.ID, action.ReminderID, until); err != nil {
+		log.Printf("Failed to snooze reminder %d: %v", action.ReminderID, err)
+		return nil, err
+	}
+	return keyboards.FormatSnoozedNotification(originalText, until, userEntity.GetLocation(), userEntity.Language), nil
+}
+
+func (b *botUseCase) handleReminderHistory(user *tgbotapi.User, reminderID int64, userEntity *entities.User) (*keyboards.SelectionResult, error) {
+	reminder, err := b.reminderUseCase.GetReminder(user.ID, reminderID)
+	if err != nil {
+		log.Printf("Failed to get reminder %d: %v", reminderID, err)
+		return b.handleRemindersList(user, userEntity)
+	}
+
+	history, err := b.reminderUseCase.GetReminderHistory(user.ID, reminderID, keyboards.HistoryLimit)
+	if err != nil {
+		log.Printf("Failed to get history of reminder %d: %v", reminderID, err)
+		return nil, err
+	}
+
+	text := keyboards.FormatReminderHistoryText(reminder, history, userEntity.GetLocation(), userEntity.Language)
+	return &keyboards.SelectionResult{Text: text, Markup: keyboards.GetReminderHistoryMarkup(userEntity.Language)}, nil
+}
+
+// toggleNagging switches "repeat until acknowledged" mode using the default cadence
+func (b *botUseCase) toggleNagging(userID, reminderID int64) {
+	reminder, err := b.reminderUseCase.GetReminder(userID, reminderID)
+	if err != nil {
+		log.Printf("Failed to get reminder %d: %v", reminderID, err)
+		return
+	}
+
+	var nagging *entities.Nagging
+	if !reminder.IsNagging() {
+		nagging = &entities.Nagging{}
+	}
+	if _, err := b.reminderUseCase.SetNagging(userID, reminderID, nagging); err != nil {
+		log.Printf("Failed to toggle nagging for reminder %d: %v", reminderID, err)
+	}
+}
+
+// toggleCritical switches whether a reminder notifies on its own despite the daily digest
+func (b *botUseCase) toggleCritical(userID, reminderID int64) {
+	reminder, err := b.reminderUseCase.GetReminder(userID, reminderID)
+	if err != nil {
+		log.Printf("Failed to get reminder %d: %v", reminderID, err)
+		return
+	}
+	if _, err := b.reminderUseCase.SetCritical(userID, reminderID, !reminder.Critical); err != nil {
+		log.Printf("Failed to toggle critical for reminder %d: %v", reminderID, err)
+	}
+}
+
 func (b *botUseCase) handleRecurrenceSelection(message *tgbotapi.Message, user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
 	result, err := keyboards.HandleRecurrenceTypeSelection(callbackData, userEntity, selection)
 	if err != nil {
@@ -371,8 +543,8 @@ func (b *botUseCase) handleRecurrenceSelection(message *tgbotapi.Message, user *
 		log.Printf("Failed to update user selection: %v", err)
 	}
 
-	// If this is a "Once" recurrence, trigger the date picker
-	if selection.RecurrenceType == entities.Once {
+	// If this is a "Once" or "Yearly" recurrence, trigger the date picker
+	if selection.RecurrenceType == entities.Once || selection.RecurrenceType == entities.Yearly {
 		return b.dateUseCase.CreateDatepicker(message, userEntity, selection), nil
 	}
 
@@ -395,6 +567,9 @@ func (b *botUseCase) handleTimeSelection(user *tgbotapi.User, callbackData strin
 
 func (b *botUseCase) handleWeekSelection(user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
 	result := keyboards.HandleWeekSelection(callbackData, &selection.WeekOptions, userEntity.Language)
+	if selection.IsEditing() && callbackData == keyboards.CallbackWeekSelect {
+		result = keyboards.EditReminderResult(selection, userEntity.Language)
+	}
 	err := b.userUseCase.UpdateUserSelection(user.ID, selection)
 	if err != nil {
 		log.Printf("Failed to update user selection: %v", err)
@@ -403,7 +578,41 @@ func (b *botUseCase) handleWeekSelection(user *tgbotapi.User, callbackData strin
 }
 
 func (b *botUseCase) handleMonthSelection(user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
-	result := keyboards.HandleMonthSelection(callbackData, &selection.MonthOptions, userEntity.Language)
+	result := keyboards.HandleMonthSelection(callbackData, &selection.MonthOptions, &selection.ClampToMonthEnd, userEntity.Language)
+	if selection.IsEditing() && callbackData == keyboards.CallbackMonthSelect {
+		// Picked days of the month replace weekdays of the month
+		if len(selection.MonthOptions) > 0 {
+			selection.NthWeekdays = nil
+		}
+		result = keyboards.EditReminderResult(selection, userEntity.Language)
+	}
+	err := b.userUseCase.UpdateUserSelection(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+	return result, nil
+}
+
+func (b *botUseCase) handleIntervalSelection(user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	result := keyboards.HandleIntervalSelection(callbackData, userEntity, selection)
+	err := b.userUseCase.UpdateUserSelection(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+	return result, nil
+}
+
+func (b *botUseCase) handleNthWeekdaySelection(user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	result := keyboards.HandleNthWeekdaySelection(callbackData, userEntity, selection)
+	err := b.userUseCase.UpdateUserSelection(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+	return result, nil
+}
+
+func (b *botUseCase) handleSpacedRepetitionSelection(user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	result := keyboards.HandleSpacedRepetitionSelection(callbackData, userEntity, selection)
 	err := b.userUseCase.UpdateUserSelection(user.ID, selection)
 	if err != nil {
 		log.Printf("Failed to update user selection: %v", err)
@@ -418,21 +627,50 @@ func (b *botUseCase) handleMessageSelection(user *tgbotapi.User, callbackData st
 		log.Printf("Failed to update user selection: %v", err)
 	}
 	if completed {
-		_, err := b.reminderUseCase.CreateReminder(user.ID, selection)
-		if err != nil {
-			log.Printf("Failed to create reminder: %v", err)
-		} else {
-			result = keyboards.FormatReminderConfirmation(userEntity, selection)
-
-			err = b.userUseCase.ClearUserSelection(user.ID)
-			if err != nil {
-				log.Printf("Failed to clear user selection: %v", err)
-			}
+		if selection.IsEditing() {
+			return keyboards.EditReminderResult(selection, userEntity.Language), nil
 		}
+		if keyboards.NeedsEndSelection(selection) {
+			return &keyboards.SelectionResult{Text: keyboards.T(userEntity.Language).MsgSelectEnd, Markup: keyboards.GetEndMarkup(selection.GetHolidayPolicy(), userEntity.Language)}, nil
+		}
+		result = b.createReminder(user, userEntity, selection, result)
 	}
 	return result, nil
 }
 
+func (b *botUseCase) handleEndSelection(user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	result, completed := keyboards.HandleEndSelection(callbackData, userEntity, selection)
+	err := b.userUseCase.UpdateUserSelection(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+	if completed {
+		// Offer the end step again if the chosen end is rejected
+		rejected := &keyboards.SelectionResult{Text: keyboards.T(userEntity.Language).MsgEndRejected, Markup: keyboards.GetEndMarkup(selection.GetHolidayPolicy(), userEntity.Language)}
+		result = b.createReminder(user, userEntity, selection, rejected)
+	}
+	return result, nil
+}
+
+// createReminder creates the reminder from a completed selection and returns its confirmation,
+// or onFailure if it cannot be created
+func (b *botUseCase) createReminder(user *tgbotapi.User, userEntity *entities.User, selection *entities.UserSelection, onFailure *keyboards.SelectionResult) *keyboards.SelectionResult {
+	_, err := b.reminderUseCase.CreateReminder(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to create reminder: %v", err)
+		return onFailure
+	}
+
+	result := keyboards.FormatReminderConfirmation(userEntity, selection)
+
+	// Clear user selection after successful reminder creation
+	err = b.userUseCase.ClearUserSelection(user.ID)
+	if err != nil {
+		log.Printf("Failed to clear user selection: %v", err)
+	}
+	return result
+}
+
 func (b *botUseCase) handleRemindersList(user *tgbotapi.User, userEntity *entities.User) (*keyboards.SelectionResult, error) {
 	// Note: Reminder deletion is handled in the callback processing
 	// This function just displays the reminders list
@@ -443,7 +681,233 @@ func (b *botUseCase) handleRemindersList(user *tgbotapi.User, userEntity *entiti
 		return nil, err
 	}
 
-	return &keyboards.SelectionResult{Text: keyboards.FormatRemindersListText(reminders, userEntity.Language), Markup: keyboards.GetRemindersListMarkup(reminders, userEntity.Language)}, nil
+	// Stay on the filter and page the user picked
+	view, err := b.userUseCase.GetUserSelection(user.ID)
+	if err != nil {
+		log.Printf("Failed to get user selection: %v", err)
+		view = entities.NewUserSelection()
+	}
+	page := keyboards.NewRemindersPage(reminders, view.ListFilter, view.ListPage)
+
+	return &keyboards.SelectionResult{Text: keyboards.FormatRemindersListText(page, userEntity), Markup: keyboards.GetRemindersListMarkup(page, userEntity)}, nil
+}
+
+// handleAgenda lists the occurrences of all active reminders in a period, today by default
+func (b *botUseCase) handleAgenda(user *tgbotapi.User, period string, userEntity *entities.User) (*keyboards.SelectionResult, error) {
+	from, to, ok := keyboards.AgendaWindow(period, time.Now(), userEntity.GetLocation())
+	if !ok {
+		period = keyboards.AgendaToday
+		from, to, _ = keyboards.AgendaWindow(period, time.Now(), userEntity.GetLocation())
+	}
+
+	agenda, err := b.reminderUseCase.GetAgenda(user.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &keyboards.SelectionResult{
+		Text:   keyboards.FormatAgenda(agenda, period, userEntity.GetLocation(), userEntity.Language),
+		Markup: keyboards.GetAgendaMarkup(userEntity.Language),
+	}, nil
+}
+
+// handleDigestSelection shows the daily digest settings and turns the digest on, off or to another time
+func (b *botUseCase) handleDigestSelection(user *tgbotapi.User, callbackData string, userEntity *entities.User) (*keyboards.SelectionResult, error) {
+	digestTime, ok := keyboards.ParseDigestTime(callbackData)
+	if ok || callbackData == keyboards.CallbackDigestOff {
+		updated, err := b.reminderUseCase.SetDigest(user.ID, digestTime)
+		if err != nil {
+			return nil, err
+		}
+		userEntity = updated
+	}
+	return &keyboards.SelectionResult{Text: keyboards.FormatDigestSettings(userEntity), Markup: keyboards.GetDigestMarkup(userEntity)}, nil
+}
+
+// openRemindersList shows the reminders list from its first page, keeping the filter
+func (b *botUseCase) openRemindersList(user *tgbotapi.User, userEntity *entities.User) (*keyboards.SelectionResult, error) {
+	selection, err := b.userUseCase.GetUserSelection(user.ID)
+	if err != nil {
+		log.Printf("Failed to get user selection: %v", err)
+	} else if selection.ListPage != 0 {
+		selection.ListPage = 0
+		b.updateListView(user.ID, selection)
+	}
+	return b.handleRemindersList(user, userEntity)
+}
+
+func (b *botUseCase) updateListView(userID int64, selection *entities.UserSelection) {
+	err := b.userUseCase.UpdateUserSelection(userID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+}
+
+// handlePauseAll pauses all reminders of the user for a preset number of days or asks for the
+// date to pause them until, and resumes them early
+func (b *botUseCase) handlePauseAll(user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	s := keyboards.T(userEntity.Language)
+	switch {
+	case callbackData == keyboards.CallbackResumeAll:
+		resumed, err := b.reminderUseCase.ResumeAllReminders(user.ID)
+		if err != nil {
+			log.Printf("Failed to resume reminders of user %d: %v", user.ID, err)
+			return b.handleRemindersList(user, userEntity)
+		}
+		return b.handleRemindersList(user, resumed)
+
+	case callbackData == keyboards.CallbackPauseAllDate:
+		selection.StartCustomPauseDate()
+		err := b.userUseCase.UpdateUserSelection(user.ID, selection)
+		if err != nil {
+			log.Printf("Failed to update user selection: %v", err)
+		}
+		return &keyboards.SelectionResult{Text: s.MsgEnterPauseDate, Markup: nil}, nil
+	}
+
+	if days, ok := keyboards.ParsePauseAllDays(callbackData); ok {
+		paused, err := b.reminderUseCase.PauseAllReminders(user.ID, keyboards.PauseUntilDays(days, time.Now(), userEntity.GetLocation()))
+		if err != nil {
+			log.Printf("Failed to pause reminders of user %d: %v", user.ID, err)
+			return b.handleRemindersList(user, userEntity)
+		}
+		return b.handleRemindersList(user, paused)
+	}
+	return &keyboards.SelectionResult{Text: s.MsgPauseAll, Markup: keyboards.GetPauseAllMarkup(userEntity.Language)}, nil
+}
+
+func (b *botUseCase) handleCustomPauseDateInput(user *tgbotapi.User, text string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	s := keyboards.T(userEntity.Language)
+	until, ok := keyboards.ParsePauseDate(text, time.Now(), userEntity.GetLocation())
+	if !ok {
+		return &keyboards.SelectionResult{Text: s.MsgInvalidPauseDate, Markup: nil}, nil
+	}
+	paused, err := b.reminderUseCase.PauseAllReminders(user.ID, until)
+	if err != nil {
+		log.Printf("Failed to pause reminders of user %d: %v", user.ID, err)
+		return &keyboards.SelectionResult{Text: s.MsgInvalidPauseDate, Markup: nil}, nil
+	}
+
+	selection.CustomPauseDate = false
+	err = b.userUseCase.UpdateUserSelection(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+	return b.handleRemindersList(user, paused)
+}
+
+// handleEditReminder opens the editor for a reminder picked from the list
+func (b *botUseCase) handleEditReminder(user *tgbotapi.User, reminderID int64, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	reminder, err := b.reminderUseCase.GetReminder(user.ID, reminderID)
+	if err != nil {
+		log.Printf("Failed to get reminder %d for editing: %v", reminderID, err)
+		return b.handleRemindersList(user, userEntity)
+	}
+
+	selection.StartEdit(reminder)
+	err = b.userUseCase.UpdateUserSelection(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+	return keyboards.EditReminderResult(selection, userEntity.Language), nil
+}
+
+func (b *botUseCase) handleEditSelection(user *tgbotapi.User, callbackData string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	switch callbackData {
+	case keyboards.CallbackEditSave:
+		return b.saveReminderEdit(user, userEntity, selection)
+	case keyboards.CallbackEditCancel:
+		if err := b.userUseCase.ClearUserSelection(user.ID); err != nil {
+			log.Printf("Failed to clear user selection: %v", err)
+		}
+		return b.handleRemindersList(user, userEntity)
+	}
+
+	result := keyboards.HandleEditSelection(callbackData, userEntity, selection)
+	err := b.userUseCase.UpdateUserSelection(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+	return result, nil
+}
+
+// saveReminderEdit updates the reminder in place with the edited message and schedule,
+// which recomputes its next trigger, and returns to the reminders list
+func (b *botUseCase) saveReminderEdit(user *tgbotapi.User, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	s := keyboards.T(userEntity.Language)
+	if !selection.IsEditing() {
+		// The editor was left already, e.g. by starting a new reminder
+		return b.handleRemindersList(user, userEntity)
+	}
+	rejected := &keyboards.SelectionResult{
+		Text:   s.MsgEditRejected + "\n\n" + keyboards.FormatEditReminder(selection, userEntity.Language),
+		Markup: keyboards.GetEditReminderMarkup(selection, userEntity.Language),
+	}
+
+	reminder, err := b.reminderUseCase.GetReminder(user.ID, selection.EditReminderID)
+	if err != nil {
+		log.Printf("Failed to get reminder %d for editing: %v", selection.EditReminderID, err)
+		return rejected, nil
+	}
+	recurrence, err := editedRecurrence(reminder.Recurrence, selection)
+	if err != nil {
+		log.Printf("Failed to edit reminder %d: %v", reminder.ID, err)
+		return rejected, nil
+	}
+	_, err = b.reminderUseCase.UpdateReminder(user.ID, reminder.ID, &entities.Reminder{Message: selection.ReminderMessage, Recurrence: recurrence})
+	if err != nil {
+		log.Printf("Failed to update reminder %d: %v", reminder.ID, err)
+		return rejected, nil
+	}
+
+	err = b.userUseCase.ClearUserSelection(user.ID)
+	if err != nil {
+		log.Printf("Failed to clear user selection: %v", err)
+	}
+	result, err := b.handleRemindersList(user, userEntity)
+	if err != nil {
+		return nil, err
+	}
+	result.Text = s.MsgReminderUpdated + "\n\n" + result.Text
+	return result, nil
+}
+
+// editedRecurrence copies a recurrence with the times of day and days of an edit selection,
+// keeping everything else such as its end, holiday policy and spaced repetition progress
+func editedRecurrence(rec *entities.Recurrence, selection *entities.UserSelection) (*entities.Recurrence, error) {
+	if rec == nil || rec.StartDate == nil {
+		return nil, errors.NewDomainError("INVALID_RECURRENCE", "Recurrence must have a start date and time of day", nil)
+	}
+	times := selection.GetSelectedTimes()
+	if len(times) == 0 {
+		return nil, errors.ErrInvalidTimeFormat
+	}
+	for _, t := range times {
+		if _, _, ok := scheduler.ParseHourMinute(t); !ok {
+			return nil, errors.ErrInvalidTimeFormat
+		}
+	}
+
+	edited := *rec
+	// The date of the start is kept, it anchors once, yearly and rule recurrences
+	start, err := buildDateTimeFromSelection(*rec.StartDate, times[0], rec.GetLocation())
+	if err != nil {
+		return nil, err
+	}
+	edited.StartDate = &start
+	edited.TimesOfDay = nil
+	if len(times) > 1 && rec.Type.SupportsTimesOfDay() {
+		edited.TimesOfDay = times
+	}
+
+	switch rec.Type {
+	case entities.Weekly:
+		edited.Weekdays = selection.WeekOptions
+	case entities.Monthly:
+		edited.DayOfMonth = selection.MonthOptions
+		edited.NthWeekdays = selection.NthWeekdays
+		edited.ClampToMonthEnd = selection.ClampToMonthEnd
+	}
+	return &edited, nil
 }
 
 func (b *botUseCase) handleCustomTimeInput(user *tgbotapi.User, text string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
@@ -473,20 +937,15 @@ func (b *botUseCase) handleCustomTextInput(user *tgbotapi.User, text string, use
 		log.Printf("Failed to update user selection: %v", err)
 	}
 
-	// If custom text was successful, create the reminder
+	// If custom text was successful, ask for an end or create the reminder
 	if completed {
-		_, err := b.reminderUseCase.CreateReminder(user.ID, selection)
-		if err != nil {
-			log.Printf("Failed to create reminder: %v", err)
-		} else {
-			selectionResult = keyboards.FormatReminderConfirmation(userEntity, selection)
-
-			// Clear user selection after successful reminder creation
-			err = b.userUseCase.ClearUserSelection(user.ID)
-			if err != nil {
-				log.Printf("Failed to clear user selection: %v", err)
-			}
+		if selection.IsEditing() {
+			return keyboards.EditReminderResult(selection, userEntity.Language), nil
 		}
+		if keyboards.NeedsEndSelection(selection) {
+			return &keyboards.SelectionResult{Text: keyboards.T(userEntity.Language).MsgSelectEnd, Markup: keyboards.GetEndMarkup(selection.GetHolidayPolicy(), userEntity.Language)}, nil
+		}
+		selectionResult = b.createReminder(user, userEntity, selection, selectionResult)
 	}
 
 	return selectionResult, nil
@@ -513,3 +972,14 @@ func (b *botUseCase) handleCustomIntervalInput(user *tgbotapi.User, text string,
 
 	return selectionResult, nil
 }
+
+func (b *botUseCase) handleCustomLadderInput(user *tgbotapi.User, text string, userEntity *entities.User, selection *entities.UserSelection) (*keyboards.SelectionResult, error) {
+	selectionResult := keyboards.HandleCustomLadderInput(text, userEntity, selection)
+
+	err := b.userUseCase.UpdateUserSelection(user.ID, selection)
+	if err != nil {
+		log.Printf("Failed to update user selection: %v", err)
+	}
+
+	return selectionResult, nil
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/config"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/mocks"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+func TestHandleStartCommand_UnreachableUserResumesFromNow(t *testing.T) {
+	userRepo := mocks.NewMockUserRepository()
+	user, _ := userRepo.CreateUser(1, "u", "f", "l", "en")
+	// Like the Mongo repository, /start finds the stored user instead of replacing it
+	userRepo.GetOrCreateUserFunc = func(userID int64, userName, firstName, lastName, language string) (*entities.User, error) {
+		return userRepo.GetUser(userID)
+	}
+	reminderRepo := inmemory.NewInMemoryReminderRepository()
+	userUC := NewUserUseCase(userRepo, inmemory.NewInMemoryUserSelectionRepository())
+	reminderUC := NewReminderUseCase(reminderRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+	bot := NewBotUseCase(userUC, reminderUC, nil, config.Config{}, nil, nil, nil)
+
+	// Both reminders came due while the user had blocked the bot
+	now := time.Now()
+	stale := now.Add(-3 * 24 * time.Hour)
+	daily, _ := reminderRepo.CreateDailyReminder(now.Add(-time.Hour), user, "daily")
+	daily.NextTrigger = &stale
+	reminderRepo.UpdateReminder(daily)
+	once, _ := reminderRepo.CreateOnceReminder(stale, user, "once")
+	userRepo.MarkUnreachable(user.ID, "Forbidden: bot was blocked by the user")
+
+	if _, err := bot.HandleStartCommand(&tgbotapi.User{ID: user.ID, UserName: "u"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stored, _ := userRepo.GetUser(user.ID); stored.Unreachable {
+		t.Fatalf("expected /start to mark the user reachable, got %+v", stored)
+	}
+	rem, _ := reminderRepo.GetReminder(daily.ID)
+	if !rem.IsActive || rem.NextTrigger == nil || !rem.NextTrigger.After(now) {
+		t.Fatalf("expected the daily reminder to continue after now instead of catching up, got %+v", rem)
+	}
+	rem, _ = reminderRepo.GetReminder(once.ID)
+	if !rem.IsActive || rem.NextTrigger == nil || !rem.NextTrigger.Equal(stale) {
+		t.Fatalf("expected the one-time reminder to still fire once, got %+v", rem)
+	}
+}
@@ -1,11 +1,15 @@
 package usecases
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/ivanenkomaksym/remindme_bot/domain/errors"
 	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+	"github.com/ivanenkomaksym/remindme_bot/domain/services"
+	"github.com/ivanenkomaksym/remindme_bot/holidays"
 	"github.com/ivanenkomaksym/remindme_bot/scheduler"
 )
 
@@ -18,18 +22,44 @@ type ReminderUseCase interface {
 	DeleteReminder(reminderID, userID int64) error
 	UpdateReminder(userID, reminderID int64, reminder *entities.Reminder) (*entities.Reminder, error)
 	GetActiveReminders() ([]entities.Reminder, error)
+	SnoozeReminder(userID, reminderID int64, until time.Time) (*entities.Reminder, error)
+	MarkReminderDone(userID, reminderID int64) (*entities.Reminder, error)
+	GradeRecall(userID, reminderID int64, grade entities.RecallGrade) (*entities.Reminder, error)
+	SetNagging(userID, reminderID int64, nagging *entities.Nagging) (*entities.Reminder, error)
+	PauseReminder(userID, reminderID int64) (*entities.Reminder, error)
+	ResumeReminder(userID, reminderID int64) (*entities.Reminder, error)
+	PauseAllReminders(userID int64, until time.Time) (*entities.User, error)
+	ResumeAllReminders(userID int64) (*entities.User, error)
+	RescheduleMissedReminders(userID int64) error
+	SetDigest(userID int64, digestTime string) (*entities.User, error)
+	SetCritical(userID, reminderID int64, critical bool) (*entities.Reminder, error)
+	GetAgenda(userID int64, from, to time.Time) ([]entities.AgendaItem, error)
+	GetReminderHistory(userID, reminderID int64, limit int) ([]entities.Delivery, error)
+	GetDeadLetterDeliveries() ([]entities.Delivery, error)
 }
 
 type reminderUseCase struct {
 	reminderRepo repositories.ReminderRepository
 	userRepo     repositories.UserRepository
+	deliveryRepo repositories.DeliveryRepository
+	timer        services.ReminderScheduler
 }
 
-// NewReminderUseCase creates a new reminder use case
-func NewReminderUseCase(reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository) ReminderUseCase {
+// NewReminderUseCase creates a new reminder use case.
+// The timer is optional and is kept in sync with every reminder change when provided.
+func NewReminderUseCase(reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, timer services.ReminderScheduler) ReminderUseCase {
 	return &reminderUseCase{
 		reminderRepo: reminderRepo,
 		userRepo:     userRepo,
+		deliveryRepo: deliveryRepo,
+		timer:        timer,
+	}
+}
+
+// schedule re-arms the timer after a reminder was created or changed
+func (r *reminderUseCase) schedule(reminder *entities.Reminder) {
+	if r.timer != nil {
+		r.timer.Schedule(reminder)
 	}
 }
 
@@ -69,7 +99,11 @@ func (r *reminderUseCase) CreateReminder(userID int64, selection *entities.UserS
 	}
 
 	date := time.Now()
-	if selection.RecurrenceType == entities.Once {
+	if selection.RecurrenceType == entities.Once || selection.RecurrenceType == entities.Yearly {
+		date = selection.SelectedDate
+	}
+	// The selected date of a rule is its DTSTART, which anchors INTERVAL and COUNT
+	if selection.RecurrenceType == entities.RRule && !selection.SelectedDate.IsZero() {
 		date = selection.SelectedDate
 	}
 
@@ -83,86 +117,236 @@ func (r *reminderUseCase) CreateReminder(userID int64, selection *entities.UserS
 		}
 	}
 
-	// Create reminder based on recurrence type
+	multipleTimes := selection.RecurrenceType.SupportsTimesOfDay() && len(selection.GetSelectedTimes()) > 1
+	if multipleTimes {
+		for _, t := range selection.GetSelectedTimes() {
+			if _, _, ok := scheduler.ParseHourMinute(t); !ok {
+				return nil, errors.ErrInvalidTimeFormat
+			}
+		}
+	}
+
+	hasEnd := selection.RecurrenceType != entities.Once && (selection.EndDate != nil || selection.MaxOccurrences != 0)
+	if hasEnd {
+		if err := validateEnd(selection); err != nil {
+			return nil, err
+		}
+	}
+
+	hasBusinessDays := selection.RecurrenceType != entities.Once && selection.BusinessDays.IsActive()
+	if hasBusinessDays {
+		if err := validateBusinessDays(selection.BusinessDays); err != nil {
+			return nil, err
+		}
+	}
+
+	// Build the complete reminder before storing it, so that a rejected option leaves nothing behind
+	var reminder *entities.Reminder
 	switch selection.RecurrenceType {
 	case entities.Once:
-		return r.createOnceReminder(user, selection, timeOfDay)
+		reminder, err = newOnceReminder(user, selection, timeOfDay)
 	case entities.Daily:
-		return r.createDailyReminder(user, selection, timeOfDay)
+		reminder, err = newDailyReminder(user, selection, timeOfDay)
 	case entities.Weekly:
-		return r.createWeeklyReminder(user, selection, timeOfDay)
+		reminder, err = newWeeklyReminder(user, selection, timeOfDay)
 	case entities.Monthly:
-		return r.createMonthlyReminder(user, selection, timeOfDay)
+		reminder, err = newMonthlyReminder(user, selection, timeOfDay)
 	case entities.Interval:
-		return r.createIntervalReminder(user, selection, timeOfDay)
+		reminder, err = newIntervalReminder(user, selection, timeOfDay)
 	case entities.SpacedBasedRepetition:
-		return r.createSpaceBasedRepetitionReminder(user, selection, timeOfDay)
+		reminder, err = newSpaceBasedRepetitionReminder(user, selection, timeOfDay)
+	case entities.RRule:
+		reminder, err = newRRuleReminder(user, selection, timeOfDay)
+	case entities.Yearly:
+		reminder, err = newYearlyReminder(user, selection, timeOfDay)
 	default:
 		return nil, errors.ErrInvalidRecurrenceType
 	}
-}
-
-func (r *reminderUseCase) createOnceReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
-	reminder, err := r.reminderRepo.CreateOnceReminder(timeOfDay, user, selection.ReminderMessage)
 	if err != nil {
 		return nil, err
 	}
+	monthlyOptions := selection.RecurrenceType == entities.Monthly && (selection.ClampToMonthEnd || len(selection.NthWeekdays) > 0)
+	if multipleTimes || monthlyOptions {
+		applyScheduleOptions(reminder, selection, timeOfDay, multipleTimes)
+	}
+	if hasBusinessDays {
+		applyBusinessDays(reminder, selection, user, timeOfDay)
+	}
+	if hasEnd {
+		if err := applyEnd(reminder, selection); err != nil {
+			return nil, err
+		}
+	}
 
+	reminder, err = r.reminderRepo.CreateReminder(reminder)
+	if err != nil {
+		return nil, err
+	}
+	r.schedule(reminder)
 	return reminder, nil
 }
 
-func (r *reminderUseCase) createDailyReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
-	reminder, err := r.reminderRepo.CreateDailyReminder(timeOfDay, user, selection.ReminderMessage)
-	if err != nil {
-		return nil, err
+// applyScheduleOptions sets every selected time of day and the monthly month end clamp or
+// weekdays of the month, then recomputes the first trigger with them
+func applyScheduleOptions(reminder *entities.Reminder, selection *entities.UserSelection, timeOfDay time.Time, multipleTimes bool) {
+	if multipleTimes {
+		reminder.Recurrence.TimesOfDay = selection.GetSelectedTimes()
 	}
+	if selection.RecurrenceType == entities.Monthly {
+		reminder.Recurrence.ClampToMonthEnd = selection.ClampToMonthEnd
+		reminder.Recurrence.NthWeekdays = selection.NthWeekdays
+	}
+	reminder.NextTrigger = scheduler.NextForRecurrence(time.Now(), timeOfDay, reminder.Recurrence)
+}
 
-	return reminder, nil
+// validateEnd checks the optional end date and occurrence limit of a recurring reminder
+func validateEnd(selection *entities.UserSelection) error {
+	if selection.MaxOccurrences < 0 {
+		return errors.NewDomainError("INVALID_MAX_OCCURRENCES", "Number of occurrences must be positive", nil)
+	}
+	if selection.EndDate != nil && !selection.EndDate.After(time.Now()) {
+		return errors.NewDomainError("INVALID_END_DATE", "End date must be in the future", nil)
+	}
+	return nil
 }
 
-func (r *reminderUseCase) createWeeklyReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
-	if len(selection.WeekOptions) == 0 {
-		return nil, errors.NewDomainError("NO_WEEKDAYS_SELECTED", "At least one weekday must be selected", nil)
+func validateBusinessDays(businessDays *entities.BusinessDays) error {
+	if err := businessDays.Validate(); err != nil {
+		return errors.NewDomainError("INVALID_BUSINESS_DAYS", err.Error(), err)
+	}
+	if _, ok := holidays.Get(businessDays.Country); businessDays.Country != "" && !ok {
+		return errors.NewDomainError("UNKNOWN_HOLIDAY_CALENDAR", fmt.Sprintf("No holiday calendar for %s, available: %v", businessDays.Country, holidays.Countries()), nil)
 	}
+	return nil
+}
 
-	reminder, err := r.reminderRepo.CreateWeeklyReminder(selection.WeekOptions, timeOfDay, user, selection.ReminderMessage)
-	if err != nil {
-		return nil, err
+// applyBusinessDays sets the holiday policy of a new recurring reminder and moves its first
+// trigger off weekends and holidays. Without a country the calendar follows the user's timezone.
+func applyBusinessDays(reminder *entities.Reminder, selection *entities.UserSelection, user *entities.User, timeOfDay time.Time) {
+	businessDays := *selection.BusinessDays
+	if businessDays.Country == "" {
+		businessDays.Country = holidays.CountryForLocation(user.GetLocation())
 	}
+	reminder.Recurrence.BusinessDays = &businessDays
+	if reminder.NextTrigger != nil {
+		reminder.NextTrigger = scheduler.ApplyHolidayPolicy(*reminder.NextTrigger, timeOfDay, reminder.Recurrence)
+	}
+}
 
-	return reminder, nil
+// applyEnd sets the end of a new recurring reminder.
+// A reminder whose first trigger already falls past its end date is rejected.
+func applyEnd(reminder *entities.Reminder, selection *entities.UserSelection) error {
+	reminder.Recurrence.EndDate = selection.EndDate
+	reminder.Recurrence.MaxOccurrences = selection.MaxOccurrences
+	if reminder.NextTrigger != nil && reminder.Recurrence.EndsBefore(*reminder.NextTrigger) {
+		return errors.NewDomainError("END_BEFORE_FIRST_TRIGGER", "End date is before the first reminder", nil)
+	}
+	return nil
+}
+
+func newOnceReminder(user *entities.User, selection *entities.UserSelection, dateTime time.Time) (*entities.Reminder, error) {
+	recurrence := entities.OnceAt(dateTime, user.GetLocation())
+	nextTrigger := *recurrence.StartDate
+	return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, &nextTrigger), nil
+}
+
+func newDailyReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
+	recurrence := entities.DailyAt(timeOfDay, user.GetLocation())
+	next := scheduler.NextDailyTrigger(time.Now(), timeOfDay, user.GetLocation())
+	return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, &next), nil
+}
+
+func newWeeklyReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
+	if len(selection.WeekOptions) == 0 {
+		return nil, errors.NewDomainError("NO_WEEKDAYS_SELECTED", "At least one weekday must be selected", nil)
+	}
+
+	recurrence := entities.CustomWeekly(selection.WeekOptions, timeOfDay, user.GetLocation())
+	next := scheduler.NextWeeklyTrigger(time.Now(), selection.WeekOptions, timeOfDay, user.GetLocation())
+	return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, &next), nil
 }
 
-func (r *reminderUseCase) createMonthlyReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
-	if len(selection.MonthOptions) == 0 {
+func newMonthlyReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
+	if len(selection.MonthOptions) == 0 && len(selection.NthWeekdays) == 0 {
 		return nil, errors.NewDomainError("NO_DAYS_SELECTED", "At least one day of month must be selected", nil)
 	}
+	for _, n := range selection.NthWeekdays {
+		if !n.IsValid() {
+			return nil, errors.NewDomainError("INVALID_NTH_WEEKDAY", "Weekday of month must be the 1st to 4th or last", nil)
+		}
+	}
+	for _, day := range selection.MonthOptions {
+		if !entities.IsValidDayOfMonth(day) {
+			return nil, errors.NewDomainError("INVALID_DAY_OF_MONTH", "Days of month must be 1 to 31, or -1 to -31 counting from the month end", nil)
+		}
+	}
+
+	recurrence := entities.MonthlyOnDay(selection.MonthOptions, timeOfDay, user.GetLocation())
+	next := scheduler.NextMonthlyTrigger(time.Now(), selection.MonthOptions, timeOfDay, user.GetLocation())
+	return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, &next), nil
+}
 
-	reminder, err := r.reminderRepo.CreateMonthlyReminder(selection.MonthOptions, timeOfDay, user, selection.ReminderMessage)
+func newIntervalReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
+	unit, err := entities.ToIntervalUnit(string(selection.IntervalUnit))
 	if err != nil {
-		return nil, err
+		return nil, errors.NewDomainError("INVALID_INTERVAL_UNIT", "Interval unit must be minutes, hours, days or weeks", err)
 	}
+	if selection.Interval <= 0 || selection.Interval > unit.MaxInterval() {
+		return nil, errors.NewDomainError("INVALID_INTERVAL", fmt.Sprintf("Interval must be between 1 and %d %s", unit.MaxInterval(), unit), nil)
+	}
+	if selection.ActiveWindow != nil {
+		if !unit.IsSubDaily() {
+			return nil, errors.NewDomainError("INVALID_ACTIVE_WINDOW", "Active window applies only to minute and hour intervals", nil)
+		}
+		if err := selection.ActiveWindow.Validate(); err != nil {
+			return nil, errors.NewDomainError("INVALID_ACTIVE_WINDOW", err.Error(), err)
+		}
+	}
+	recurrence := entities.IntervalEvery(selection.Interval, unit, selection.ActiveWindow, timeOfDay, user.GetLocation())
+	next := scheduler.FirstIntervalTrigger(time.Now(), timeOfDay, recurrence)
+	return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, &next), nil
+}
 
-	return reminder, nil
+func newYearlyReminder(user *entities.User, selection *entities.UserSelection, date time.Time) (*entities.Reminder, error) {
+	if selection.SelectedDate.IsZero() {
+		return nil, errors.NewDomainError("NO_DATE_SELECTED", "A month and day must be selected for a yearly reminder", nil)
+	}
+
+	recurrence := entities.YearlyOn(date, user.GetLocation())
+	next := scheduler.NextYearlyForRecurrence(time.Now(), date, recurrence)
+	return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, next), nil
 }
 
-func (r *reminderUseCase) createIntervalReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
-	if selection.IntervalDays <= 0 {
-		return nil, errors.NewDomainError("INVALID_INTERVAL", "Interval must be a positive number of days", nil)
+func newSpaceBasedRepetitionReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
+	if !selection.AdaptiveRecall && len(selection.Ladder) > 0 {
+		if err := entities.ValidateLadder(selection.Ladder); err != nil {
+			return nil, errors.NewDomainError("INVALID_LADDER", err.Error(), err)
+		}
 	}
-	reminder, err := r.reminderRepo.CreateIntervalReminder(selection.IntervalDays, timeOfDay, user, selection.ReminderMessage)
-	if err != nil {
-		return nil, err
+	recurrence := entities.SpacedBasedRepetitionInterval(timeOfDay, user.GetLocation())
+	if selection.AdaptiveRecall {
+		// The first review comes at the next time of day, later ones follow the grades
+		entities.WithRecall(entities.NewRecallState())(recurrence)
+		next := scheduler.NextDailyTrigger(time.Now(), timeOfDay, recurrence.GetLocation())
+		return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, &next), nil
 	}
-	return reminder, nil
+	if len(selection.Ladder) > 0 {
+		entities.WithSpacedRepetitionLadder(selection.Ladder)(recurrence)
+	}
+	next := scheduler.NextForSpacedBasedRepetition(time.Now(), timeOfDay, recurrence)
+	return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, next), nil
 }
 
-func (r *reminderUseCase) createSpaceBasedRepetitionReminder(user *entities.User, selection *entities.UserSelection, timeOfDay time.Time) (*entities.Reminder, error) {
-	reminder, err := r.reminderRepo.CreateSpaceBasedRepetitionReminder(timeOfDay, user, selection.ReminderMessage)
-	if err != nil {
-		return nil, err
+func newRRuleReminder(user *entities.User, selection *entities.UserSelection, startDate time.Time) (*entities.Reminder, error) {
+	if _, err := scheduler.ParseRRule(selection.RRule); err != nil {
+		return nil, errors.NewDomainError("INVALID_RRULE", "Recurrence rule is invalid", err)
 	}
-	return reminder, nil
+	recurrence := entities.RRuleFrom(selection.RRule, startDate, user.GetLocation())
+	next := scheduler.NextRRuleTrigger(time.Now(), recurrence)
+	if next == nil {
+		return nil, errors.NewDomainError("RRULE_ENDED", "Recurrence rule has no upcoming occurrences", nil)
+	}
+	return entities.NewReminder(0, user.ID, selection.ReminderMessage, recurrence, next), nil
 }
 
 func (r *reminderUseCase) GetUserReminders(userID int64) ([]entities.Reminder, error) {
@@ -205,7 +389,13 @@ func (r *reminderUseCase) DeleteReminder(reminderID, userID int64) error {
 		return errors.ErrUnauthorized
 	}
 
-	return r.reminderRepo.DeleteReminder(reminderID, userID)
+	if err := r.reminderRepo.DeleteReminder(reminderID, userID); err != nil {
+		return err
+	}
+	if r.timer != nil {
+		r.timer.Unschedule(reminderID)
+	}
+	return r.deliveryRepo.DeleteDeliveriesByReminder(reminderID)
 }
 
 func (r *reminderUseCase) GetReminder(userID, reminderID int64) (*entities.Reminder, error) {
@@ -233,6 +423,36 @@ func (r *reminderUseCase) GetReminder(userID, reminderID int64) (*entities.Remin
 	return reminder, nil
 }
 
+// rescheduleFromNow recomputes the next trigger of a reminder whose schedule changed.
+// The reminder is active again when the schedule has an upcoming trigger, unless it is paused,
+// and deactivated otherwise.
+func rescheduleFromNow(reminder *entities.Reminder) error {
+	rec := reminder.Recurrence
+	if rec.StartDate == nil {
+		return errors.NewDomainError("INVALID_RECURRENCE", "Recurrence must have a start date and time of day", nil)
+	}
+	if rec.Type == entities.Weekly && len(rec.Weekdays) == 0 {
+		return errors.NewDomainError("NO_WEEKDAYS_SELECTED", "At least one weekday must be selected", nil)
+	}
+	if rec.Type == entities.Monthly && len(rec.DayOfMonth) == 0 && len(rec.NthWeekdays) == 0 {
+		return errors.NewDomainError("NO_DAYS_SELECTED", "At least one day of month must be selected", nil)
+	}
+
+	now := time.Now()
+	var next *time.Time
+	if rec.Type == entities.Once {
+		if rec.StartDate.After(now) {
+			start := *rec.StartDate
+			next = &start
+		}
+	} else {
+		next = scheduler.NextForRecurrence(now, *rec.StartDate, rec)
+	}
+	reminder.NextTrigger = next
+	reminder.IsActive = next != nil && !reminder.Paused
+	return nil
+}
+
 func (r *reminderUseCase) UpdateReminder(userID, reminderID int64, updatedFields *entities.Reminder) (*entities.Reminder, error) {
 	if updatedFields == nil {
 		return nil, errors.NewDomainError("INVALID_REMINDER", "Reminder cannot be nil", nil)
@@ -266,6 +486,21 @@ func (r *reminderUseCase) UpdateReminder(userID, reminderID int64, updatedFields
 	if updatedFields.Recurrence != nil {
 		existingReminder.Recurrence = updatedFields.Recurrence
 	}
+	if updatedFields.Nagging != nil {
+		existingReminder.EnableNagging(updatedFields.Nagging.IntervalMinutes, updatedFields.Nagging.MaxNags)
+	}
+	if updatedFields.CatchUp != entities.CatchUpDefault {
+		if !updatedFields.CatchUp.IsValid() {
+			return nil, errors.NewDomainError("INVALID_CATCH_UP_POLICY", "Unknown catch-up policy", nil)
+		}
+		existingReminder.CatchUp = updatedFields.CatchUp
+	}
+	// A changed schedule starts over from now unless the next trigger is given
+	if updatedFields.Recurrence != nil && updatedFields.NextTrigger == nil {
+		if err := rescheduleFromNow(existingReminder); err != nil {
+			return nil, err
+		}
+	}
 
 	// Update the reminder
 	err = r.reminderRepo.UpdateReminder(existingReminder)
@@ -273,6 +508,7 @@ func (r *reminderUseCase) UpdateReminder(userID, reminderID int64, updatedFields
 		return nil, err
 	}
 
+	r.schedule(existingReminder)
 	return existingReminder, nil
 }
 
@@ -283,3 +519,313 @@ func (r *reminderUseCase) GetActiveReminders() ([]entities.Reminder, error) {
 	}
 	return reminders, nil
 }
+
+// SnoozeReminder schedules a one-off re-delivery without touching the recurrence
+func (r *reminderUseCase) SnoozeReminder(userID, reminderID int64, until time.Time) (*entities.Reminder, error) {
+	reminder, err := r.GetReminder(userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if !until.After(time.Now()) {
+		return nil, errors.NewDomainError("INVALID_SNOOZE_TIME", "Snooze time must be in the future", nil)
+	}
+
+	// The snoozed re-delivery starts a fresh nag cycle
+	reminder.StopNagging()
+	reminder.Snooze(until)
+	if err := r.reminderRepo.UpdateReminder(reminder); err != nil {
+		return nil, err
+	}
+	r.schedule(reminder)
+	return reminder, nil
+}
+
+// MarkReminderDone acknowledges a delivered reminder and cancels any pending snooze or nag
+func (r *reminderUseCase) MarkReminderDone(userID, reminderID int64) (*entities.Reminder, error) {
+	reminder, err := r.GetReminder(userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder.Acknowledge()
+	if err := r.reminderRepo.UpdateReminder(reminder); err != nil {
+		return nil, err
+	}
+	r.schedule(reminder)
+	if err := r.deliveryRepo.AcknowledgeDeliveries(reminderID, time.Now()); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// GradeRecall applies the answer to an adaptive review and schedules the next one from now.
+// The reminder is deactivated once the item is learned or the recurrence has ended.
+func (r *reminderUseCase) GradeRecall(userID, reminderID int64, grade entities.RecallGrade) (*entities.Reminder, error) {
+	reminder, err := r.GetReminder(userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if reminder.Recurrence == nil || !reminder.Recurrence.IsAdaptive() {
+		return nil, errors.NewDomainError("NOT_ADAPTIVE", "Reminder does not take recall grades", nil)
+	}
+	if err := reminder.Recurrence.Recall.Apply(grade); err != nil {
+		return nil, errors.NewDomainError("INVALID_RECALL_GRADE", err.Error(), err)
+	}
+
+	reminder.Acknowledge()
+	reminder.NextTrigger = scheduler.NextForRecurrence(time.Now(), *reminder.Recurrence.StartDate, reminder.Recurrence)
+	if reminder.NextTrigger == nil {
+		reminder.IsActive = false
+	}
+	if err := r.reminderRepo.UpdateReminder(reminder); err != nil {
+		return nil, err
+	}
+	r.schedule(reminder)
+	if err := r.deliveryRepo.AcknowledgeDeliveries(reminderID, time.Now()); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// SetNagging enables "repeat until acknowledged" mode with the given cadence, or disables it when nagging is nil
+func (r *reminderUseCase) SetNagging(userID, reminderID int64, nagging *entities.Nagging) (*entities.Reminder, error) {
+	reminder, err := r.GetReminder(userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if nagging == nil {
+		reminder.DisableNagging()
+	} else {
+		reminder.EnableNagging(nagging.IntervalMinutes, nagging.MaxNags)
+	}
+	if err := r.reminderRepo.UpdateReminder(reminder); err != nil {
+		return nil, err
+	}
+	r.schedule(reminder)
+	return reminder, nil
+}
+
+// PauseReminder deactivates a reminder until it is resumed, without deleting it
+func (r *reminderUseCase) PauseReminder(userID, reminderID int64) (*entities.Reminder, error) {
+	reminder, err := r.GetReminder(userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder.Pause()
+	if err := r.reminderRepo.UpdateReminder(reminder); err != nil {
+		return nil, err
+	}
+	r.schedule(reminder)
+	return reminder, nil
+}
+
+// ResumeReminder reactivates a paused reminder from its next occurrence after now,
+// so occurrences missed while it was paused are not delivered
+func (r *reminderUseCase) ResumeReminder(userID, reminderID int64) (*entities.Reminder, error) {
+	reminder, err := r.GetReminder(userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if !reminder.Paused {
+		return nil, errors.NewDomainError("NOT_PAUSED", "Reminder is not paused", nil)
+	}
+
+	reminder.Paused = false
+	if err := rescheduleFromNow(reminder); err != nil {
+		return nil, err
+	}
+	if !reminder.IsActive {
+		return nil, errors.NewDomainError("REMINDER_ENDED", "Reminder has no upcoming occurrences", nil)
+	}
+	if err := r.reminderRepo.UpdateReminder(reminder); err != nil {
+		return nil, err
+	}
+	r.schedule(reminder)
+	return reminder, nil
+}
+
+// PauseAllReminders pauses every reminder of a user until the given time (vacation mode).
+// Occurrences falling into the pause are skipped, not delivered when it ends.
+func (r *reminderUseCase) PauseAllReminders(userID int64, until time.Time) (*entities.User, error) {
+	if userID <= 0 {
+		return nil, errors.NewDomainError("INVALID_USER_ID", "User ID must be positive", nil)
+	}
+	if !until.After(time.Now()) {
+		return nil, errors.NewDomainError("INVALID_PAUSE_DATE", "Pause must end in the future", nil)
+	}
+	user, err := r.userRepo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if err := r.userRepo.SetPausedUntil(userID, &until); err != nil {
+		return nil, err
+	}
+	user.PauseUntil(until)
+	return user, nil
+}
+
+// ResumeAllReminders ends a pause of all reminders early. Reminders whose occurrences fell
+// into the pause continue from their next occurrence after now.
+func (r *reminderUseCase) ResumeAllReminders(userID int64) (*entities.User, error) {
+	if userID <= 0 {
+		return nil, errors.NewDomainError("INVALID_USER_ID", "User ID must be positive", nil)
+	}
+	user, err := r.userRepo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	if err := r.userRepo.SetPausedUntil(userID, nil); err != nil {
+		return nil, err
+	}
+	user.Resume()
+
+	if err := r.rescheduleOverdue(userID, true); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// RescheduleMissedReminders moves the recurring reminders of a user who could not be reached
+// to their next occurrence after now, so what was missed meanwhile is not caught up.
+// One-time reminders keep their trigger and still fire once.
+func (r *reminderUseCase) RescheduleMissedReminders(userID int64) error {
+	if userID <= 0 {
+		return errors.NewDomainError("INVALID_USER_ID", "User ID must be positive", nil)
+	}
+	return r.rescheduleOverdue(userID, false)
+}
+
+// rescheduleOverdue moves the user's active reminders whose trigger has passed to their next
+// occurrence after now. One-time reminders are included, and so deactivated, only with includeOnce.
+func (r *reminderUseCase) rescheduleOverdue(userID int64, includeOnce bool) error {
+	reminders, err := r.reminderRepo.GetRemindersByUser(userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i := range reminders {
+		reminder := &reminders[i]
+		if !reminder.IsActive || reminder.NextTrigger == nil || reminder.NextTrigger.After(now) {
+			continue
+		}
+		if !includeOnce && reminder.Recurrence.Type == entities.Once {
+			continue
+		}
+		if err := rescheduleFromNow(reminder); err != nil {
+			return err
+		}
+		if err := r.reminderRepo.UpdateReminder(reminder); err != nil {
+			return err
+		}
+		r.schedule(reminder)
+	}
+	return nil
+}
+
+// SetDigest switches a user to one daily digest at HH:MM in their location, or back to
+// individual reminders when digestTime is empty. Occurrences due before the first digest
+// still ping on their own, so none is lost when the digest is turned on or moved.
+func (r *reminderUseCase) SetDigest(userID int64, digestTime string) (*entities.User, error) {
+	if userID <= 0 {
+		return nil, errors.NewDomainError("INVALID_USER_ID", "User ID must be positive", nil)
+	}
+	user, err := r.userRepo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.ErrUserNotFound
+	}
+
+	var digest *entities.Digest
+	if digestTime != "" {
+		next, err := scheduler.NextDigest(time.Now(), digestTime, user.GetLocation())
+		if err != nil {
+			return nil, errors.NewDomainError("INVALID_DIGEST_TIME", "Digest time must be in HH:MM format", err)
+		}
+		digest = &entities.Digest{Time: digestTime, NextAt: next, Since: next}
+	}
+
+	if err := r.userRepo.SetDigest(userID, digest); err != nil {
+		return nil, err
+	}
+	user.SetDigest(digest)
+	if r.timer != nil {
+		r.timer.ScheduleDigest(user)
+	}
+	return user, nil
+}
+
+// SetCritical makes a reminder ping on its own even when its user gets a daily digest
+func (r *reminderUseCase) SetCritical(userID, reminderID int64, critical bool) (*entities.Reminder, error) {
+	reminder, err := r.GetReminder(userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder.Critical = critical
+	if err := r.reminderRepo.UpdateReminder(reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// Most occurrences of a single reminder listed in an agenda, e.g. of one firing every minute
+const maxAgendaOccurrences = 50
+
+// GetAgenda expands the active reminders of a user into their occurrences from (inclusive) to
+// (exclusive), in chronological order. Occurrences falling into a pause of all reminders are left out.
+func (r *reminderUseCase) GetAgenda(userID int64, from, to time.Time) ([]entities.AgendaItem, error) {
+	if userID <= 0 {
+		return nil, errors.NewDomainError("INVALID_USER_ID", "User ID must be positive", nil)
+	}
+	user, err := r.userRepo.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.ErrUserNotFound
+	}
+	if user.PausedUntil != nil && user.PausedUntil.After(from) {
+		from = *user.PausedUntil
+	}
+
+	reminders, err := r.reminderRepo.GetRemindersByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var agenda []entities.AgendaItem
+	for _, reminder := range reminders {
+		for _, at := range scheduler.UpcomingOccurrences(reminder, from, to, maxAgendaOccurrences) {
+			agenda = append(agenda, entities.AgendaItem{ReminderID: reminder.ID, Message: reminder.Message, At: at})
+		}
+	}
+	slices.SortStableFunc(agenda, func(a, b entities.AgendaItem) int {
+		return a.At.Compare(b.At)
+	})
+	return agenda, nil
+}
+
+// GetReminderHistory returns the delivery history of a reminder, newest first
+func (r *reminderUseCase) GetReminderHistory(userID, reminderID int64, limit int) ([]entities.Delivery, error) {
+	if _, err := r.GetReminder(userID, reminderID); err != nil {
+		return nil, err
+	}
+	return r.deliveryRepo.GetDeliveriesByReminder(reminderID, limit)
+}
+
+// GetDeadLetterDeliveries returns deliveries that will not be retried anymore
+func (r *reminderUseCase) GetDeadLetterDeliveries() ([]entities.Delivery, error) {
+	return r.deliveryRepo.GetDeliveriesByStatus(entities.DeliveryStatusDeadLetter)
+}
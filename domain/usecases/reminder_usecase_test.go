@@ -1,18 +1,20 @@
 package usecases
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/ivanenkomaksym/remindme_bot/domain/errors"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
 	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
 )
 
 func newReminderUC() ReminderUseCase {
 	remRepo := inmemory.NewInMemoryReminderRepository()
 	userRepo := inmemory.NewInMemoryUserRepository()
-	return NewReminderUseCase(remRepo, userRepo)
+	return NewReminderUseCase(remRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
 }
 
 func TestCreateReminder_ValidOnce(t *testing.T) {
@@ -23,7 +25,7 @@ func TestCreateReminder_ValidOnce(t *testing.T) {
 
 	// Build a new UC that shares the same user repo as above
 	remRepo := inmemory.NewInMemoryReminderRepository()
-	uc = NewReminderUseCase(remRepo, userRepo)
+	uc = NewReminderUseCase(remRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
 
 	now := time.Now()
 	loc := time.Local
@@ -59,7 +61,7 @@ func TestCreateReminder_ValidDaily(t *testing.T) {
 
 	// Build a new UC that shares the same user repo as above
 	remRepo := inmemory.NewInMemoryReminderRepository()
-	uc = NewReminderUseCase(remRepo, userRepo)
+	uc = NewReminderUseCase(remRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
 
 	sel := entities.NewUserSelection()
 	sel.RecurrenceType = entities.Daily
@@ -129,3 +131,759 @@ func TestCreateReminder_UserMustExist(t *testing.T) {
 		t.Fatalf("expected ErrUserNotFound, got %v", err)
 	}
 }
+
+func TestSnoozeReminder_KeepsRecurrence(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	user, _ := userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	remRepo := inmemory.NewInMemoryReminderRepository()
+	uc := NewReminderUseCase(remRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	tod, _ := time.Parse("15:04", "09:00")
+	rem, _ := remRepo.CreateDailyReminder(tod, user, "Stretch")
+	originalNext := *rem.NextTrigger
+
+	until := time.Now().Add(10 * time.Minute)
+	snoozed, err := uc.SnoozeReminder(1, rem.ID, until)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snoozed.SnoozedUntil == nil || !snoozed.SnoozedUntil.Equal(until) {
+		t.Fatalf("expected SnoozedUntil %v, got %v", until, snoozed.SnoozedUntil)
+	}
+	if !snoozed.NextTrigger.Equal(originalNext) || !snoozed.Recurrence.IsDaily() {
+		t.Fatalf("snooze must not change the schedule")
+	}
+
+	if _, err := uc.SnoozeReminder(1, rem.ID, time.Now().Add(-time.Minute)); err == nil {
+		t.Fatalf("expected error for snooze time in the past")
+	}
+	if _, err := uc.SnoozeReminder(2, rem.ID, until); err != errors.ErrUnauthorized {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+
+	done, err := uc.MarkReminderDone(1, rem.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done.SnoozedUntil != nil {
+		t.Fatalf("expected done to cancel pending snooze")
+	}
+}
+
+func TestSetNagging_EnableAndDisable(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	user, _ := userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	remRepo := inmemory.NewInMemoryReminderRepository()
+	uc := NewReminderUseCase(remRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	tod, _ := time.Parse("15:04", "09:00")
+	rem, _ := remRepo.CreateDailyReminder(tod, user, "Medication")
+
+	updated, err := uc.SetNagging(1, rem.ID, &entities.Nagging{IntervalMinutes: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !updated.IsNagging() || updated.Nagging.IntervalMinutes != 5 || updated.Nagging.MaxNags != entities.DefaultMaxNags {
+		t.Fatalf("unexpected nagging settings: %+v", updated.Nagging)
+	}
+
+	stored, _ := remRepo.GetReminder(rem.ID)
+	stored.StartNagging(time.Now())
+	remRepo.UpdateReminder(stored)
+
+	done, err := uc.MarkReminderDone(1, rem.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done.Nagging.NextNag != nil {
+		t.Fatalf("expected done to stop pending nags")
+	}
+
+	disabled, err := uc.SetNagging(1, rem.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if disabled.IsNagging() {
+		t.Fatalf("expected nagging to be disabled")
+	}
+}
+
+// recordingScheduler remembers the last due time scheduled per reminder and digest
+type recordingScheduler struct {
+	scheduled map[int64]*time.Time
+	digests   map[int64]time.Time
+}
+
+func (s *recordingScheduler) Schedule(reminder *entities.Reminder) {
+	s.scheduled[reminder.ID] = reminder.NextDueAt()
+}
+
+func (s *recordingScheduler) Unschedule(reminderID int64) {
+	delete(s.scheduled, reminderID)
+}
+
+func (s *recordingScheduler) ScheduleDigest(user *entities.User) {
+	if user.Digest == nil {
+		delete(s.digests, user.ID)
+		return
+	}
+	s.digests[user.ID] = user.Digest.NextAt
+}
+
+func TestReminderChanges_RearmScheduler(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	timer := &recordingScheduler{scheduled: make(map[int64]*time.Time), digests: make(map[int64]time.Time)}
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), timer)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "Take a break"
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if at := timer.scheduled[rem.ID]; at == nil || !at.Equal(*rem.NextTrigger) {
+		t.Fatalf("expected creation to arm the timer at %v, got %v", rem.NextTrigger, at)
+	}
+
+	soon := time.Now().Add(5 * time.Minute).Truncate(time.Second)
+	if _, err := uc.UpdateReminder(1, rem.ID, &entities.Reminder{NextTrigger: &soon}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if at := timer.scheduled[rem.ID]; at == nil || !at.Equal(soon) {
+		t.Fatalf("expected update to re-arm the timer at %v, got %v", soon, at)
+	}
+
+	if err := uc.DeleteReminder(rem.ID, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := timer.scheduled[rem.ID]; ok {
+		t.Fatalf("expected deletion to disarm the timer")
+	}
+}
+
+func TestCreateReminder_RRule(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.RRule
+	sel.RRule = "FREQ=MONTHLY;BYDAY=-1FR"
+	sel.SelectedTime = "18:00"
+	sel.ReminderMessage = "Submit timesheet"
+
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rem.NextTrigger == nil || rem.NextTrigger.Weekday() != time.Friday || rem.NextTrigger.AddDate(0, 0, 7).Month() == rem.NextTrigger.Month() {
+		t.Fatalf("expected the last Friday of a month, got %v", rem.NextTrigger)
+	}
+
+	sel.RRule = "FREQ=MONTHLY;BYDAY=FRIDAY"
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for invalid rule")
+	}
+
+	sel.RRule = "FREQ=DAILY;UNTIL=20200101"
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for a rule that already ended")
+	}
+}
+
+func TestCreateReminder_End(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "Stretch"
+	sel.SetEnd(nil, 10)
+
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rem.Recurrence.MaxOccurrences != 10 || !rem.IsActive {
+		t.Fatalf("expected an active reminder limited to 10 occurrences, got %+v", rem.Recurrence)
+	}
+
+	sel.SetEnd(nil, -1)
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for a negative number of occurrences")
+	}
+
+	past := time.Now().Add(-time.Hour)
+	sel.SetEnd(&past, 0)
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for an end date in the past")
+	}
+
+	// Weekly on a day that does not come before the end
+	soon := time.Now().Add(time.Minute)
+	sel.RecurrenceType = entities.Weekly
+	sel.WeekOptions = []time.Weekday{soon.AddDate(0, 0, 3).Weekday()}
+	sel.SetEnd(&soon, 0)
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for an end before the first trigger")
+	}
+	reminders, _ := uc.GetUserReminders(1)
+	if len(reminders) != 1 {
+		t.Fatalf("expected the rejected reminder to be discarded, got %d reminders", len(reminders))
+	}
+}
+
+// writeCountingRepository counts the writes to reminders that were already stored
+type writeCountingRepository struct {
+	repositories.ReminderRepository
+	writes int
+}
+
+func (r *writeCountingRepository) UpdateReminder(reminder *entities.Reminder) error {
+	r.writes++
+	return r.ReminderRepository.UpdateReminder(reminder)
+}
+
+func (r *writeCountingRepository) DeleteReminder(reminderID int64, userID int64) error {
+	r.writes++
+	return r.ReminderRepository.DeleteReminder(reminderID, userID)
+}
+
+func TestCreateReminder_StoresCompleteReminder(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	repo := &writeCountingRepository{ReminderRepository: inmemory.NewInMemoryReminderRepository()}
+	uc := NewReminderUseCase(repo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "Stretch"
+	sel.SetEnd(nil, 10)
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored, _ := repo.GetReminder(rem.ID); stored == nil || stored.Recurrence.MaxOccurrences != 10 {
+		t.Fatalf("expected the end to be stored with the reminder, got %+v", stored)
+	}
+
+	sel.RecurrenceType = entities.Monthly
+	sel.MonthOptions = []int{31}
+	sel.ClampToMonthEnd = true
+	sel.SetSelectedTimes([]string{"08:00", "20:00"})
+	rem, err = uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored, _ := repo.GetReminder(rem.ID); stored == nil || !stored.Recurrence.ClampToMonthEnd || len(stored.Recurrence.TimesOfDay) != 2 {
+		t.Fatalf("expected the schedule options to be stored with the reminder, got %+v", stored)
+	}
+	sel.ClampToMonthEnd = false
+	sel.SetSelectedTimes([]string{"09:00"})
+
+	sel.RecurrenceType = entities.Daily
+	sel.BusinessDays = &entities.BusinessDays{Policy: entities.HolidayPolicySkip, Country: "UA"}
+	rem, err = uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored, _ := repo.GetReminder(rem.ID); stored == nil || stored.Recurrence.BusinessDays == nil || !stored.NextTrigger.Equal(*rem.NextTrigger) {
+		t.Fatalf("expected the holiday policy and its first trigger to be stored with the reminder, got %+v", stored)
+	}
+	sel.BusinessDays = nil
+
+	// Rejected before it is ever stored
+	soon := time.Now().Add(time.Minute)
+	sel.RecurrenceType = entities.Weekly
+	sel.WeekOptions = []time.Weekday{soon.AddDate(0, 0, 3).Weekday()}
+	sel.SetEnd(&soon, 0)
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for an end before the first trigger")
+	}
+
+	if repo.writes != 0 {
+		t.Fatalf("expected every reminder to be stored complete in one insert, got %d later writes", repo.writes)
+	}
+}
+
+func TestCreateReminder_MultipleTimesOfDay(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+	sel.SetSelectedTimes([]string{"20:00", "08:00", "14:00"})
+	sel.ReminderMessage = "Take a pill"
+
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rem.Recurrence.GetTimesOfDay(); len(got) != 3 || got[0] != "08:00" {
+		t.Fatalf("expected three times starting at 08:00, got %v", got)
+	}
+	loc := rem.Recurrence.GetLocation()
+	if rem.NextTrigger == nil || !rem.NextTrigger.After(time.Now()) || rem.NextTrigger.After(time.Now().Add(8*time.Hour)) {
+		t.Fatalf("expected the earliest upcoming of the three times, got %v", rem.NextTrigger)
+	}
+	if hm := rem.NextTrigger.In(loc).Format("15:04"); hm != "08:00" && hm != "14:00" && hm != "20:00" {
+		t.Fatalf("expected one of the selected times, got %s", hm)
+	}
+
+	sel.SetSelectedTimes([]string{"08:00", "25:00"})
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for an invalid time")
+	}
+}
+
+func TestCreateReminder_MonthEnd(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Monthly
+	sel.MonthOptions = []int{31}
+	sel.ClampToMonthEnd = true
+	sel.SelectedTime = "10:00"
+	sel.ReminderMessage = "Pay rent"
+
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rem.Recurrence.ClampToMonthEnd || rem.NextTrigger == nil {
+		t.Fatalf("expected a clamped monthly reminder, got %+v", rem.Recurrence)
+	}
+	// Clamped, the first trigger is never more than a month away
+	if rem.NextTrigger.After(time.Now().AddDate(0, 1, 1)) {
+		t.Fatalf("expected the first trigger within a month, got %v", rem.NextTrigger)
+	}
+
+	sel.MonthOptions = []int{0}
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for day 0")
+	}
+	sel.MonthOptions = []int{-32}
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for day -32")
+	}
+}
+
+func TestCreateReminder_Yearly(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Yearly
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "Mom's birthday"
+
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error without a date")
+	}
+
+	birthday := time.Now().AddDate(0, 0, 10)
+	sel.SetSelectedDate(birthday)
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loc := rem.Recurrence.GetLocation()
+	if rem.NextTrigger == nil {
+		t.Fatalf("expected a next trigger")
+	}
+	next := rem.NextTrigger.In(loc)
+	if next.Month() != birthday.In(loc).Month() || next.Day() != birthday.In(loc).Day() || next.Format("15:04") != "09:00" {
+		t.Fatalf("expected the birthday at 09:00, got %v", next)
+	}
+}
+
+func TestCreateReminder_NthWeekday(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Monthly
+	sel.SelectedTime = "18:00"
+	sel.ReminderMessage = "book club"
+
+	sel.SetNthWeekday(entities.NthWeekday{Ordinal: 5, Weekday: time.Thursday})
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for a fifth weekday")
+	}
+
+	sel.SetNthWeekday(entities.NthWeekday{Ordinal: entities.OrdinalLast, Weekday: time.Thursday})
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rem.Recurrence.NthWeekdays) != 1 || rem.NextTrigger == nil {
+		t.Fatalf("expected the weekday of the month to be stored with a next trigger")
+	}
+	next := rem.NextTrigger.In(rem.Recurrence.GetLocation())
+	if next.Weekday() != time.Thursday || next.AddDate(0, 0, 7).Month() == next.Month() || next.Format("15:04") != "18:00" {
+		t.Fatalf("expected the last Thursday at 18:00, got %v", next)
+	}
+}
+
+func TestCreateReminder_BusinessDays(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	userRepo.UpdateLocation(1, "Europe/Kyiv")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "stand-up"
+
+	sel.BusinessDays = &entities.BusinessDays{Policy: entities.HolidayPolicySkip, Country: "XX"}
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for an unknown holiday calendar")
+	}
+
+	sel.BusinessDays = &entities.BusinessDays{Policy: entities.HolidayPolicySkip}
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rem.Recurrence.BusinessDays == nil || rem.Recurrence.BusinessDays.Country != "UA" {
+		t.Fatalf("expected the calendar to follow the user's timezone, got %+v", rem.Recurrence.BusinessDays)
+	}
+	if rem.NextTrigger == nil || entities.IsWeekend(rem.NextTrigger.In(rem.Recurrence.GetLocation())) {
+		t.Fatalf("expected the first trigger on a business day, got %v", rem.NextTrigger)
+	}
+}
+
+func TestCreateReminder_SpacedRepetition(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.SpacedBasedRepetition
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "vocabulary"
+
+	sel.SetSpacedRepetition(false, []int{1, 0})
+	if _, err := uc.CreateReminder(1, sel); err == nil {
+		t.Fatalf("expected error for a zero day step")
+	}
+
+	sel.SetSpacedRepetition(false, []int{1, 3, 7})
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rem.Recurrence.RemainingLadder(); len(got) != 3 || got[2] != 7 || rem.NextTrigger == nil {
+		t.Fatalf("expected the custom ladder to be left after the first review, got %v", got)
+	}
+
+	if _, err := uc.GradeRecall(1, rem.ID, entities.RecallGood); err == nil {
+		t.Fatalf("expected error grading a fixed ladder")
+	}
+}
+
+func TestGradeRecall(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	reminderRepo := inmemory.NewInMemoryReminderRepository()
+	uc := NewReminderUseCase(reminderRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.SpacedBasedRepetition
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "vocabulary"
+	sel.SetSpacedRepetition(true, nil)
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rem.Recurrence.IsAdaptive() || rem.NextTrigger == nil || rem.NextTrigger.After(time.Now().Add(24*time.Hour)) {
+		t.Fatalf("expected the first adaptive review within a day, got %v", rem.NextTrigger)
+	}
+
+	uc.GradeRecall(1, rem.ID, entities.RecallGood)
+	rem, err = uc.GradeRecall(1, rem.ID, entities.RecallGood)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loc := rem.Recurrence.GetLocation()
+	wantDay := time.Now().In(loc).AddDate(0, 0, 6)
+	next := rem.NextTrigger.In(loc)
+	if next.YearDay() != wantDay.YearDay() || next.Format("15:04") != "09:00" {
+		t.Fatalf("expected the review in six days at 09:00, got %v", next)
+	}
+
+	rem.Recurrence.Recall.IntervalDays = rem.Recurrence.Recall.MaxIntervalDays
+	reminderRepo.UpdateReminder(rem)
+	rem, err = uc.GradeRecall(1, rem.ID, entities.RecallEasy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rem.IsActive || rem.NextTrigger != nil {
+		t.Fatalf("expected a learned item to be deactivated, got %+v", rem)
+	}
+}
+
+func TestUpdateReminder_RecomputesNextTrigger(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	uc := NewReminderUseCase(inmemory.NewInMemoryReminderRepository(), userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Weekly
+	sel.WeekOptions = []time.Weekday{time.Monday}
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "Stand-up"
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edit := entities.NewUserSelection()
+	edit.StartEdit(rem)
+	edit.SetSelectedTimes([]string{"10:30"})
+	edit.WeekOptions = []time.Weekday{time.Wednesday}
+	edit.ReminderMessage = "Planning"
+	recurrence, err := editedRecurrence(rem.Recurrence, edit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := uc.UpdateReminder(1, rem.ID, &entities.Reminder{Message: edit.ReminderMessage, Recurrence: recurrence})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loc := updated.Recurrence.GetLocation()
+	if updated.Message != "Planning" || updated.NextTrigger == nil || !updated.IsActive {
+		t.Fatalf("expected an active reminder with the new message, got %+v", updated)
+	}
+	if next := updated.NextTrigger.In(loc); next.Weekday() != time.Wednesday || next.Format("15:04") != "10:30" {
+		t.Fatalf("expected the next trigger on Wednesday at 10:30, got %v", next)
+	}
+
+	edit.WeekOptions = nil
+	recurrence, _ = editedRecurrence(rem.Recurrence, edit)
+	if _, err := uc.UpdateReminder(1, rem.ID, &entities.Reminder{Recurrence: recurrence}); err == nil {
+		t.Fatalf("expected error for a weekly reminder without weekdays")
+	}
+	edit.SetSelectedTimes(nil)
+	if _, err := editedRecurrence(rem.Recurrence, edit); err == nil {
+		t.Fatalf("expected error without a time of day")
+	}
+}
+
+func TestPauseAndResumeReminder(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	reminderRepo := inmemory.NewInMemoryReminderRepository()
+	uc := NewReminderUseCase(reminderRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "Water the plants"
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := uc.ResumeReminder(1, rem.ID); err == nil {
+		t.Fatalf("expected error resuming a reminder that is not paused")
+	}
+
+	paused, err := uc.PauseReminder(1, rem.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !paused.Paused || paused.IsActive {
+		t.Fatalf("expected an inactive paused reminder, got %+v", paused)
+	}
+
+	// The schedule went stale while paused
+	stale, _ := reminderRepo.GetReminder(rem.ID)
+	past := time.Now().Add(-72 * time.Hour)
+	stale.NextTrigger = &past
+	reminderRepo.UpdateReminder(stale)
+
+	resumed, err := uc.ResumeReminder(1, rem.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resumed.Paused || !resumed.IsActive || resumed.NextTrigger == nil || !resumed.NextTrigger.After(time.Now()) {
+		t.Fatalf("expected an active reminder scheduled from now, got %+v", resumed)
+	}
+
+	if _, err := uc.PauseReminder(2, rem.ID); err == nil {
+		t.Fatalf("expected error pausing another user's reminder")
+	}
+}
+
+func TestPauseAndResumeAllReminders(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	reminderRepo := inmemory.NewInMemoryReminderRepository()
+	uc := NewReminderUseCase(reminderRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	if _, err := uc.PauseAllReminders(1, time.Now().Add(-time.Hour)); err == nil {
+		t.Fatalf("expected error pausing until a past time")
+	}
+
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+	sel.SelectedTime = "09:00"
+	sel.ReminderMessage = "Stretch"
+	rem, err := uc.CreateReminder(1, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	until := time.Now().Add(7 * 24 * time.Hour)
+	user, err := uc.PauseAllReminders(1, until)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !user.IsPaused(time.Now()) {
+		t.Fatalf("expected the user to be paused, got %+v", user)
+	}
+	if stored, _ := userRepo.GetUser(1); stored.PausedUntil == nil || !stored.PausedUntil.Equal(until) {
+		t.Fatalf("expected the pause to be stored, got %+v", stored)
+	}
+
+	// Occurrences fell into the pause before it was ended early
+	stale, _ := reminderRepo.GetReminder(rem.ID)
+	past := time.Now().Add(-48 * time.Hour)
+	stale.NextTrigger = &past
+	reminderRepo.UpdateReminder(stale)
+
+	user, err = uc.ResumeAllReminders(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.PausedUntil != nil {
+		t.Fatalf("expected the pause to end, got %+v", user)
+	}
+	resumed, _ := reminderRepo.GetReminder(rem.ID)
+	if !resumed.IsActive || resumed.NextTrigger == nil || !resumed.NextTrigger.After(time.Now()) {
+		t.Fatalf("expected the reminder scheduled from now, got %+v", resumed)
+	}
+}
+
+func TestGetAgenda(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	reminderRepo := inmemory.NewInMemoryReminderRepository()
+	uc := NewReminderUseCase(reminderRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), nil)
+
+	user := &entities.User{ID: 1, Location: time.UTC}
+	base := time.Date(2030, 3, 4, 0, 0, 0, 0, time.UTC) // Monday
+	schedule := func(rem *entities.Reminder, next time.Time, change func(*entities.Reminder)) {
+		rem.NextTrigger = &next
+		if change != nil {
+			change(rem)
+		}
+		reminderRepo.UpdateReminder(rem)
+	}
+
+	daily, _ := reminderRepo.CreateDailyReminder(base.Add(9*time.Hour), user, "daily")
+	schedule(daily, base.Add(9*time.Hour), nil)
+	limited, _ := reminderRepo.CreateDailyReminder(base.Add(18*time.Hour), user, "limited")
+	schedule(limited, base.Add(18*time.Hour), func(r *entities.Reminder) {
+		r.Recurrence.MaxOccurrences = 3
+		r.Recurrence.OccurrenceCount = 1
+	})
+	weekly, _ := reminderRepo.CreateWeeklyReminder([]time.Weekday{time.Wednesday}, base.Add(12*time.Hour), user, "weekly")
+	schedule(weekly, base.AddDate(0, 0, 2).Add(12*time.Hour), nil)
+	reminderRepo.CreateOnceReminder(base.AddDate(0, 0, 1).Add(10*time.Hour), user, "once")
+	inactive, _ := reminderRepo.CreateDailyReminder(base.Add(8*time.Hour), user, "inactive")
+	schedule(inactive, base.Add(8*time.Hour), func(r *entities.Reminder) { r.Deactivate() })
+
+	messages := func(agenda []entities.AgendaItem) string {
+		var m []string
+		for _, item := range agenda {
+			m = append(m, item.At.Format("02 15:04")+" "+item.Message)
+		}
+		return strings.Join(m, ", ")
+	}
+
+	tests := []struct {
+		name string
+		from time.Time
+		want string
+	}{
+		{"whole window", base,
+			"04 09:00 daily, 04 18:00 limited, 05 09:00 daily, 05 10:00 once, 05 18:00 limited, 06 09:00 daily, 06 12:00 weekly"},
+		{"due occurrences before the window count towards the limit", base.Add(19 * time.Hour),
+			"05 09:00 daily, 05 10:00 once, 05 18:00 limited, 06 09:00 daily, 06 12:00 weekly"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agenda, err := uc.GetAgenda(1, tt.from, base.AddDate(0, 0, 3))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := messages(agenda); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	// Occurrences falling into a pause of all reminders are skipped
+	pausedUntil := base.AddDate(0, 0, 2)
+	userRepo.SetPausedUntil(1, &pausedUntil)
+	agenda, err := uc.GetAgenda(1, base, base.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := messages(agenda), "06 09:00 daily, 06 12:00 weekly"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestSetDigest(t *testing.T) {
+	userRepo := inmemory.NewInMemoryUserRepository()
+	userRepo.GetOrCreateUser(1, "u", "f", "l", "en")
+	reminderRepo := inmemory.NewInMemoryReminderRepository()
+	timer := &recordingScheduler{scheduled: make(map[int64]*time.Time), digests: make(map[int64]time.Time)}
+	uc := NewReminderUseCase(reminderRepo, userRepo, inmemory.NewInMemoryDeliveryRepository(), timer)
+
+	if _, err := uc.SetDigest(1, "8 am"); err == nil {
+		t.Fatalf("expected error for an invalid digest time")
+	}
+
+	user, err := uc.SetDigest(1, "08:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	digest := user.Digest
+	if digest == nil || digest.Time != "08:00" || !digest.NextAt.After(time.Now()) || digest.NextAt.Sub(time.Now()) > 24*time.Hour {
+		t.Fatalf("expected the next digest at 08:00 within a day, got %+v", digest)
+	}
+	if !digest.Since.Equal(digest.NextAt) {
+		t.Fatalf("expected occurrences before the first digest to notify on their own, got %+v", digest)
+	}
+	if stored, _ := userRepo.GetUser(1); stored.Digest == nil || !stored.Digest.NextAt.Equal(digest.NextAt) {
+		t.Fatalf("expected the digest to be stored, got %+v", stored)
+	}
+	if at, ok := timer.digests[1]; !ok || !at.Equal(digest.NextAt) {
+		t.Fatalf("expected setting the digest to arm the timer at %v, got %v", digest.NextAt, at)
+	}
+
+	if user, err = uc.SetDigest(1, ""); err != nil || user.Digest != nil {
+		t.Fatalf("expected the digest to be turned off, got %+v, %v", user, err)
+	}
+	if _, ok := timer.digests[1]; ok {
+		t.Fatalf("expected turning the digest off to disarm the timer")
+	}
+}
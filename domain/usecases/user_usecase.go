@@ -19,6 +19,7 @@ type UserUseCase interface {
 	GetUserSelection(userID int64) (*entities.UserSelection, error)
 	UpdateUserSelection(userID int64, selection *entities.UserSelection) error
 	ClearUserSelection(userID int64) error
+	MarkUserReachable(userID int64) error
 	DeleteUser(userID int64) error
 }
 
@@ -151,6 +152,15 @@ func (u *userUseCase) ClearUserSelection(userID int64) error {
 	return u.selectionRepo.ClearUserSelection(userID)
 }
 
+// MarkUserReachable resumes delivery to a user previously marked as unreachable
+func (u *userUseCase) MarkUserReachable(userID int64) error {
+	if userID <= 0 {
+		return errors.NewDomainError(errors.ErrUserDataInvalid.Code, "User ID must be positive", nil)
+	}
+
+	return u.userRepo.MarkReachable(userID)
+}
+
 func (u *userUseCase) DeleteUser(userID int64) error {
 	if userID <= 0 {
 		return errors.NewDomainError(errors.ErrUserDataInvalid.Code, "User ID must be positive", nil)
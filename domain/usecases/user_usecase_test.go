@@ -145,3 +145,23 @@ func TestUserUseCase_ClearUserSelection(t *testing.T) {
 	err = useCase.ClearUserSelection(0)
 	assert.Error(t, err)
 }
+
+func TestUserUseCase_MarkUserReachable(t *testing.T) {
+	mockRepo := mocks.NewMockUserRepository()
+	selRepo := inmemory.NewInMemoryUserSelectionRepository()
+	useCase := NewUserUseCase(mockRepo, selRepo)
+
+	user := entities.NewUser(1, "testuser", "Test", "User", "en")
+	user.MarkUnreachable("Forbidden: bot was blocked by the user")
+	mockRepo.Users[1] = user
+
+	err := useCase.MarkUserReachable(1)
+	assert.NoError(t, err)
+
+	result, _ := useCase.GetUser(1)
+	assert.False(t, result.Unreachable)
+	assert.Nil(t, result.UnreachableSince)
+	assert.Empty(t, result.UnreachableReason)
+
+	assert.Error(t, useCase.MarkUserReachable(0))
+}
@@ -0,0 +1,235 @@
+package holidays
+
+import (
+	"embed"
+	"encoding/json"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// How a holiday that falls on a weekend is made up for
+const (
+	ObservedNone           = ""                // Weekend holidays are not made up for
+	ObservedNextWeekday    = "next_weekday"    // The next weekday that is not a holiday itself is a day off
+	ObservedNearestWeekday = "nearest_weekday" // Saturday holidays are observed on Friday, Sunday ones on Monday
+)
+
+// Rule describes when a holiday falls: on a fixed date, on a weekday of a month or relative to Easter
+type Rule struct {
+	Name         string               `json:"name"`
+	Month        time.Month           `json:"month,omitempty"`
+	Day          int                  `json:"day,omitempty"`
+	Nth          *entities.NthWeekday `json:"nth,omitempty"`           // e.g. the last Monday of Month
+	EasterOffset *int                 `json:"easter_offset,omitempty"` // Days after Easter Sunday
+	From         int                  `json:"from,omitempty"`          // First year the holiday is observed (optional)
+}
+
+// Holiday is a public holiday on a calendar day
+type Holiday struct {
+	Date     time.Time // Midnight UTC of the calendar day
+	Name     string
+	Observed bool // A weekday off making up for a holiday on a weekend
+}
+
+// Calendar holds the public holidays of a country
+type Calendar struct {
+	Country   string   `json:"country"`
+	Name      string   `json:"name"`
+	Easter    string   `json:"easter"`   // "western" or "orthodox"
+	Observed  string   `json:"observed"` // One of the Observed* constants
+	Timezones []string `json:"timezones"`
+	Rules     []Rule   `json:"holidays"`
+
+	mu    sync.Mutex
+	years map[int]map[string]Holiday
+}
+
+//go:embed data/*.json
+var calendarFiles embed.FS
+
+var (
+	loadOnce  sync.Once
+	calendars map[string]*Calendar
+)
+
+func load() {
+	calendars = map[string]*Calendar{}
+	files, err := calendarFiles.ReadDir("data")
+	if err != nil {
+		log.Printf("Failed to read holiday calendars: %v", err)
+		return
+	}
+	for _, file := range files {
+		data, err := calendarFiles.ReadFile(path.Join("data", file.Name()))
+		if err != nil {
+			log.Printf("Failed to read holiday calendar %s: %v", file.Name(), err)
+			continue
+		}
+		calendar := &Calendar{}
+		if err := json.Unmarshal(data, calendar); err != nil {
+			log.Printf("Failed to parse holiday calendar %s: %v", file.Name(), err)
+			continue
+		}
+		calendars[strings.ToUpper(calendar.Country)] = calendar
+	}
+}
+
+// Get returns the holiday calendar of a country by its ISO 3166 code, e.g. "UA"
+func Get(country string) (*Calendar, bool) {
+	loadOnce.Do(load)
+	calendar, ok := calendars[strings.ToUpper(country)]
+	return calendar, ok
+}
+
+// Countries lists the codes of the built-in calendars
+func Countries() []string {
+	loadOnce.Do(load)
+	countries := make([]string, 0, len(calendars))
+	for country := range calendars {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// CountryForLocation guesses the holiday calendar from a timezone, or returns "" when none matches
+func CountryForLocation(loc *time.Location) string {
+	if loc == nil {
+		return ""
+	}
+	loadOnce.Do(load)
+	for country, calendar := range calendars {
+		for _, timezone := range calendar.Timezones {
+			if timezone == loc.String() {
+				return country
+			}
+		}
+	}
+	return ""
+}
+
+// IsBusinessDay reports whether the calendar day of day, in its location, is neither a weekend,
+// a holiday of the chosen country nor one of the excluded dates
+func IsBusinessDay(day time.Time, businessDays *entities.BusinessDays) bool {
+	if entities.IsWeekend(day) {
+		return false
+	}
+	if businessDays == nil {
+		return true
+	}
+	if businessDays.Excludes(day) {
+		return false
+	}
+	if calendar, ok := Get(businessDays.Country); ok && calendar.IsHoliday(day) {
+		return false
+	}
+	return true
+}
+
+// IsHoliday reports whether the calendar day of day, in its location, is a holiday or an observed day off
+func (c *Calendar) IsHoliday(day time.Time) bool {
+	_, ok := c.year(day.Year())[day.Format("2006-01-02")]
+	return ok
+}
+
+// Holidays lists the holidays and observed days off of a year in date order
+func (c *Calendar) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for _, holiday := range c.year(year) {
+		holidays = append(holidays, holiday)
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// year returns the holidays of a year keyed by YYYY-MM-DD, computing them once
+func (c *Calendar) year(year int) map[string]Holiday {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if holidays, ok := c.years[year]; ok {
+		return holidays
+	}
+	if c.years == nil {
+		c.years = map[int]map[string]Holiday{}
+	}
+
+	// Observed days can cross the new year, e.g. a Saturday January 1 observed on December 31
+	var dates []Holiday
+	for y := year - 1; y <= year+1; y++ {
+		dates = append(dates, c.dates(y)...)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Date.Before(dates[j].Date) })
+
+	all := map[string]Holiday{}
+	for _, holiday := range dates {
+		all[holiday.Date.Format("2006-01-02")] = holiday
+	}
+	for _, holiday := range dates {
+		if !entities.IsWeekend(holiday.Date) {
+			continue
+		}
+		if observed, ok := c.observe(holiday.Date, all); ok {
+			all[observed.Format("2006-01-02")] = Holiday{Date: observed, Name: holiday.Name, Observed: true}
+		}
+	}
+
+	holidays := map[string]Holiday{}
+	for key, holiday := range all {
+		if holiday.Date.Year() == year {
+			holidays[key] = holiday
+		}
+	}
+	c.years[year] = holidays
+	return holidays
+}
+
+// observe returns the weekday making up for a holiday on a weekend, if the calendar has one
+func (c *Calendar) observe(date time.Time, taken map[string]Holiday) (time.Time, bool) {
+	switch c.Observed {
+	case ObservedNearestWeekday:
+		if date.Weekday() == time.Saturday {
+			return date.AddDate(0, 0, -1), true
+		}
+		return date.AddDate(0, 0, 1), true
+	case ObservedNextWeekday:
+		for day := date.AddDate(0, 0, 1); ; day = day.AddDate(0, 0, 1) {
+			if _, ok := taken[day.Format("2006-01-02")]; !entities.IsWeekend(day) && !ok {
+				return day, true
+			}
+		}
+	default:
+		return time.Time{}, false
+	}
+}
+
+// dates resolves the rules to the actual holidays of a year
+func (c *Calendar) dates(year int) []Holiday {
+	easter := WesternEaster(year)
+	if c.Easter == "orthodox" {
+		easter = OrthodoxEaster(year)
+	}
+
+	var holidays []Holiday
+	for _, rule := range c.Rules {
+		if rule.From > year {
+			continue
+		}
+		var date time.Time
+		switch {
+		case rule.EasterOffset != nil:
+			date = easter.AddDate(0, 0, *rule.EasterOffset)
+		case rule.Nth != nil:
+			date = time.Date(year, rule.Month, rule.Nth.DayIn(year, rule.Month), 0, 0, 0, 0, time.UTC)
+		default:
+			date = time.Date(year, rule.Month, rule.Day, 0, 0, 0, 0, time.UTC)
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: rule.Name})
+	}
+	return holidays
+}
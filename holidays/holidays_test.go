@@ -0,0 +1,126 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"western 2024", WesternEaster(2024), date(2024, time.March, 31)},
+		{"western 2025", WesternEaster(2025), date(2025, time.April, 20)},
+		{"western 2026", WesternEaster(2026), date(2026, time.April, 5)},
+		{"orthodox 2024", OrthodoxEaster(2024), date(2024, time.May, 5)},
+		{"orthodox 2025", OrthodoxEaster(2025), date(2025, time.April, 20)},
+		{"orthodox 2026", OrthodoxEaster(2026), date(2026, time.April, 12)},
+	}
+	for _, tt := range tests {
+		if !tt.got.Equal(tt.want) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestCountries(t *testing.T) {
+	countries := Countries()
+	for _, country := range []string{"DE", "GB", "PL", "UA", "US"} {
+		if _, ok := Get(country); !ok {
+			t.Fatalf("expected a calendar for %s, got %v", country, countries)
+		}
+	}
+	if _, ok := Get("ua"); !ok {
+		t.Fatalf("expected country codes to be case insensitive")
+	}
+	if _, ok := Get("XX"); ok {
+		t.Fatalf("expected no calendar for an unknown country")
+	}
+}
+
+func TestCalendar_IsHoliday(t *testing.T) {
+	tests := []struct {
+		name    string
+		country string
+		day     time.Time
+		want    bool
+	}{
+		{"US Thanksgiving", "US", date(2025, time.November, 27), true},
+		{"US Memorial Day", "US", date(2025, time.May, 26), true},
+		{"US Saturday Independence Day observed on Friday", "US", date(2026, time.July, 3), true},
+		{"US Saturday New Year observed in the previous year", "US", date(2021, time.December, 31), true},
+		{"US Juneteenth before it existed", "US", date(2020, time.June, 19), false},
+		{"GB Good Friday", "GB", date(2025, time.April, 18), true},
+		{"GB weekend Christmas observed on Monday", "GB", date(2021, time.December, 27), true},
+		{"GB weekend Boxing Day observed on Tuesday", "GB", date(2021, time.December, 28), true},
+		{"UA Sunday Trinity observed on Monday", "UA", date(2025, time.June, 9), true},
+		{"UA Independence Day", "UA", date(2025, time.August, 24), true},
+		{"PL Corpus Christi", "PL", date(2025, time.June, 19), true},
+		{"PL Christmas Eve from 2025", "PL", date(2025, time.December, 24), true},
+		{"PL Christmas Eve before 2025", "PL", date(2024, time.December, 24), false},
+		{"DE Whit Monday", "DE", date(2025, time.June, 9), true},
+		{"DE ordinary day", "DE", date(2025, time.June, 10), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar, _ := Get(tt.country)
+			if got := calendar.IsHoliday(tt.day); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCalendar_Holidays(t *testing.T) {
+	calendar, _ := Get("US")
+	holidays := calendar.Holidays(2025)
+	if len(holidays) != 11 {
+		t.Fatalf("expected 11 US holidays in 2025, got %d", len(holidays))
+	}
+	if holidays[0].Name != "New Year's Day" || holidays[len(holidays)-1].Name != "Christmas Day" {
+		t.Fatalf("expected holidays in date order, got %v", holidays)
+	}
+}
+
+func TestCountryForLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	if got := CountryForLocation(loc); got != "UA" {
+		t.Fatalf("expected UA, got %q", got)
+	}
+	if got := CountryForLocation(time.UTC); got != "" {
+		t.Fatalf("expected no country for UTC, got %q", got)
+	}
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	businessDays := &entities.BusinessDays{Policy: entities.HolidayPolicySkip, Country: "UA", ExcludedDates: []string{"2025-12-24"}}
+	tests := []struct {
+		name string
+		day  time.Time
+		want bool
+	}{
+		{"weekday", date(2025, time.December, 23), true},
+		{"excluded date", date(2025, time.December, 24), false},
+		{"holiday", date(2025, time.December, 25), false},
+		{"weekend", date(2025, time.December, 27), false},
+	}
+	for _, tt := range tests {
+		if got := IsBusinessDay(tt.day, businessDays); got != tt.want {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+	if IsBusinessDay(date(2025, time.December, 27), nil) {
+		t.Fatalf("expected weekends off without business days")
+	}
+}
@@ -0,0 +1,109 @@
+package keyboards
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/scheduler"
+)
+
+// Agenda periods, named after the commands showing them
+const (
+	AgendaToday    = "today"
+	AgendaTomorrow = "tomorrow"
+	AgendaWeek     = "week"
+)
+
+const CallbackPrefixAgenda = "agenda:"
+
+// Most occurrences listed in one agenda message
+const maxAgendaItems = 60
+
+func IsAgendaCallback(callbackData string) bool {
+	return strings.HasPrefix(callbackData, CallbackPrefixAgenda)
+}
+
+// ParseAgendaPeriod returns the period picked under an agenda
+func ParseAgendaPeriod(callbackData string) (string, bool) {
+	period := strings.TrimPrefix(callbackData, CallbackPrefixAgenda)
+	switch period {
+	case AgendaToday, AgendaTomorrow, AgendaWeek:
+		return period, IsAgendaCallback(callbackData)
+	}
+	return "", false
+}
+
+// AgendaWindow returns when an agenda period starts (inclusive) and ends (exclusive) in the
+// user's location: the rest of today, all of tomorrow or from now until the end of the sixth day after today
+func AgendaWindow(period string, now time.Time, loc *time.Location) (time.Time, time.Time, bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	today := now.In(loc)
+	startOfDay := func(days int) time.Time {
+		return scheduler.LocalTime(today.Year(), today.Month(), today.Day()+days, 0, 0, loc)
+	}
+	switch period {
+	case AgendaToday:
+		return now, startOfDay(1), true
+	case AgendaTomorrow:
+		return startOfDay(1), startOfDay(2), true
+	case AgendaWeek:
+		return now, startOfDay(7), true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// GetAgendaMarkup switches between the agenda periods
+func GetAgendaMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnAgendaToday, CallbackPrefixAgenda+AgendaToday),
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnAgendaTomorrow, CallbackPrefixAgenda+AgendaTomorrow),
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnAgendaWeek, CallbackPrefixAgenda+AgendaWeek),
+		),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, CallbackBackToMainMenu)),
+	)
+	return &menu
+}
+
+// FormatAgenda renders the occurrences of a period in chronological order in the user's location,
+// grouped by day for the week
+func FormatAgenda(items []entities.AgendaItem, period string, loc *time.Location, lang string) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	s := T(lang)
+
+	var b strings.Builder
+	b.WriteString(s.AgendaTitles[period] + "\n")
+	if len(items) == 0 {
+		b.WriteString("\n" + s.MsgAgendaEmpty)
+		return b.String()
+	}
+
+	writeAgendaItems(&b, items, period == AgendaWeek, loc, lang)
+	return b.String()
+}
+
+// writeAgendaItems writes one line per occurrence, under a header for each day when byDay is set
+func writeAgendaItems(b *strings.Builder, items []entities.AgendaItem, byDay bool, loc *time.Location, lang string) {
+	s := T(lang)
+	var day time.Time
+	for i, item := range items {
+		if i == maxAgendaItems {
+			b.WriteString(fmt.Sprintf(s.MsgAgendaMore, len(items)-i))
+			break
+		}
+		at := item.At.In(loc)
+		if byDay && (i == 0 || at.YearDay() != day.YearDay() || at.Year() != day.Year()) {
+			day = at
+			b.WriteString(fmt.Sprintf("\n%s, %s\n", s.WeekdayNamesShort[at.Weekday()], FormatYearlyDate(at.Month(), at.Day(), lang)))
+		}
+		b.WriteString(fmt.Sprintf("⏰ %s — %s\n", at.Format("15:04"), truncateMessage(item.Message, maxListMessageRunes)))
+	}
+}
@@ -0,0 +1,67 @@
+package keyboards
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestAgendaWindow(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	now := time.Date(2026, 10, 23, 22, 30, 0, 0, time.UTC) // Saturday 01:30 in Kyiv, the day before DST ends
+
+	tests := []struct {
+		period   string
+		from, to time.Time
+	}{
+		{AgendaToday, now, time.Date(2026, 10, 25, 0, 0, 0, 0, kyiv)},
+		{AgendaTomorrow, time.Date(2026, 10, 25, 0, 0, 0, 0, kyiv), time.Date(2026, 10, 26, 0, 0, 0, 0, kyiv)},
+		{AgendaWeek, now, time.Date(2026, 10, 31, 0, 0, 0, 0, kyiv)},
+	}
+	for _, tt := range tests {
+		from, to, ok := AgendaWindow(tt.period, now, kyiv)
+		if !ok || !from.Equal(tt.from) || !to.Equal(tt.to) {
+			t.Fatalf("%s: expected %v - %v, got %v - %v", tt.period, tt.from, tt.to, from, to)
+		}
+	}
+	if _, _, ok := AgendaWindow("month", now, kyiv); ok {
+		t.Fatalf("expected an unknown period to be rejected")
+	}
+
+	if period, ok := ParseAgendaPeriod(CallbackPrefixAgenda + AgendaWeek); !ok || period != AgendaWeek {
+		t.Fatalf("expected the week, got %q", period)
+	}
+	if got := GetKeyboardType(CallbackPrefixAgenda + AgendaTomorrow); got != Agenda {
+		t.Fatalf("GetKeyboardType() = %v, want %v", got, Agenda)
+	}
+}
+
+func TestFormatAgenda(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	items := []entities.AgendaItem{
+		{ReminderID: 1, Message: "Stand-up", At: time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)},
+		{ReminderID: 2, Message: "Gym", At: time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC)},
+		{ReminderID: 1, Message: "Stand-up", At: time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC)},
+	}
+
+	text := FormatAgenda(items, AgendaWeek, kyiv, LangEN)
+	for _, want := range []string{"Mon, October 19\n⏰ 09:00 — Stand-up\n⏰ 19:00 — Gym", "Tue, October 20\n⏰ 09:00 — Stand-up"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in the agenda, got %q", want, text)
+		}
+	}
+	if text := FormatAgenda(items[:1], AgendaToday, kyiv, LangEN); strings.Contains(text, "October") {
+		t.Fatalf("expected no day headers for a single day, got %q", text)
+	}
+	if text := FormatAgenda(nil, AgendaTomorrow, kyiv, LangUK); !strings.Contains(text, T(LangUK).MsgAgendaEmpty) {
+		t.Fatalf("expected the empty agenda notice, got %q", text)
+	}
+}
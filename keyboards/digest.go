@@ -0,0 +1,88 @@
+package keyboards
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// The callback data of the daily digest settings, opened from the account menu
+const (
+	CallbackDigestMenu       = "digest_menu"
+	CallbackPrefixDigestTime = "digest_time:"
+	CallbackDigestOff        = "digest_off"
+)
+
+// DigestTimes are the times of day offered for the daily digest
+var DigestTimes = []string{"07:00", "08:00", "09:00", "20:00"}
+
+func IsDigestCallback(callbackData string) bool {
+	return strings.HasPrefix(callbackData, "digest_")
+}
+
+// ParseDigestTime returns the time of day picked for the digest
+func ParseDigestTime(callbackData string) (string, bool) {
+	if !strings.HasPrefix(callbackData, CallbackPrefixDigestTime) {
+		return "", false
+	}
+	digestTime := callbackData[len(CallbackPrefixDigestTime):]
+	if _, err := time.Parse("15:04", digestTime); err != nil {
+		return "", false
+	}
+	return digestTime, true
+}
+
+// GetDigestMarkup offers the times of day for the digest, marking the current one
+func GetDigestMarkup(user *entities.User) *tgbotapi.InlineKeyboardMarkup {
+	s := T(user.Language)
+	var times []tgbotapi.InlineKeyboardButton
+	for _, digestTime := range DigestTimes {
+		label := digestTime
+		if user.Digest != nil && user.Digest.Time == digestTime {
+			label = "✅ " + label
+		}
+		times = append(times, tgbotapi.NewInlineKeyboardButtonData(label, CallbackPrefixDigestTime+digestTime))
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{times}
+	if user.Digest != nil {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnDigestOff, CallbackDigestOff)))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, CallbackAccount)))
+
+	menu := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return &menu
+}
+
+// FormatDigestSettings explains the digest and whether it is on
+func FormatDigestSettings(user *entities.User) string {
+	s := T(user.Language)
+	if user.Digest == nil {
+		return s.MsgDigestSettings + s.MsgDigestOff
+	}
+	return s.MsgDigestSettings + fmt.Sprintf(s.MsgDigestOn, user.Digest.Time)
+}
+
+// FormatDigest renders the occurrences summarised in a daily digest in the user's location,
+// grouped by day when they run past midnight
+func FormatDigest(items []entities.AgendaItem, loc *time.Location, lang string) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	s := T(lang)
+
+	var b strings.Builder
+	b.WriteString(s.DigestTitle + "\n")
+	if len(items) == 0 {
+		b.WriteString("\n" + s.MsgAgendaEmpty)
+		return b.String()
+	}
+
+	first, last := items[0].At.In(loc), items[len(items)-1].At.In(loc)
+	byDay := first.YearDay() != last.YearDay() || first.Year() != last.Year()
+	writeAgendaItems(&b, items, byDay, loc, lang)
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package keyboards
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestDigestSettings(t *testing.T) {
+	if digestTime, ok := ParseDigestTime(CallbackPrefixDigestTime + "08:00"); !ok || digestTime != "08:00" {
+		t.Fatalf("expected 08:00, got %q", digestTime)
+	}
+	if _, ok := ParseDigestTime(CallbackPrefixDigestTime + "25:00"); ok {
+		t.Fatalf("expected an invalid time to be rejected")
+	}
+	if got := GetKeyboardType(CallbackDigestOff); got != Digest {
+		t.Fatalf("GetKeyboardType() = %v, want %v", got, Digest)
+	}
+
+	user := &entities.User{Language: LangEN}
+	markup := GetDigestMarkup(user)
+	if len(markup.InlineKeyboard) != 2 {
+		t.Fatalf("expected the times and back rows while off, got %d rows", len(markup.InlineKeyboard))
+	}
+
+	user.Digest = &entities.Digest{Time: "09:00"}
+	markup = GetDigestMarkup(user)
+	if len(markup.InlineKeyboard) != 3 {
+		t.Fatalf("expected a turn off row while on, got %d rows", len(markup.InlineKeyboard))
+	}
+	if label := markup.InlineKeyboard[0][2].Text; label != "✅ 09:00" {
+		t.Fatalf("expected the current time to be marked, got %q", label)
+	}
+	if text := FormatDigestSettings(user); !strings.Contains(text, "09:00") {
+		t.Fatalf("expected the digest time in the settings, got %q", text)
+	}
+}
+
+func TestFormatDigest(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	items := []entities.AgendaItem{
+		{ReminderID: 1, Message: "Stand-up", At: time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)},
+		{ReminderID: 2, Message: "Gym", At: time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC)},
+	}
+	text := FormatDigest(items, kyiv, LangEN)
+	if want := "☀️ Your daily digest\n⏰ 09:00 — Stand-up\n⏰ 19:00 — Gym\n"; text != want {
+		t.Fatalf("expected %q, got %q", want, text)
+	}
+
+	// Past midnight the occurrences are grouped by day
+	items = append(items, entities.AgendaItem{ReminderID: 3, Message: "Pills", At: time.Date(2026, 10, 19, 22, 30, 0, 0, time.UTC)})
+	text = FormatDigest(items, kyiv, LangEN)
+	if !strings.Contains(text, "Tue, October 20\n⏰ 01:30 — Pills") {
+		t.Fatalf("expected a header for the next day, got %q", text)
+	}
+}
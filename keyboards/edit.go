@@ -0,0 +1,89 @@
+package keyboards
+
+import (
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// The callback data of the reminder editor, opened from the reminders list
+const (
+	CallbackEditTime   = "edit_time"
+	CallbackEditText   = "edit_text"
+	CallbackEditDays   = "edit_days"
+	CallbackEditSave   = "edit_save"
+	CallbackEditCancel = "edit_cancel"
+)
+
+func IsEditCallback(callbackData string) bool {
+	return strings.HasPrefix(callbackData, "edit_")
+}
+
+// GetEditReminderMarkup offers the parts of a reminder that can be changed.
+// Days are offered for weekly and monthly reminders only.
+func GetEditReminderMarkup(userSelection *entities.UserSelection, lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	row := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(s.BtnEditTime, CallbackEditTime),
+		tgbotapi.NewInlineKeyboardButtonData(s.BtnEditText, CallbackEditText),
+	)
+	if hasEditableDays(userSelection) {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(s.BtnEditDays, CallbackEditDays))
+	}
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnSave, CallbackEditSave),
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnCancel, CallbackEditCancel),
+		),
+	)
+	return &menu
+}
+
+// FormatEditReminder shows the reminder being edited with the changes made so far
+func FormatEditReminder(userSelection *entities.UserSelection, lang string) string {
+	s := T(lang)
+	text := s.MsgEditReminder + "\n\n"
+	text += "📅 " + s.Frequency + ": " + RecurrenceTypeLabel(lang, userSelection.RecurrenceType) + "\n"
+	if days, ok := formatSelectedDays(userSelection, lang); ok {
+		text += "📆 " + s.Days + ": " + days + "\n"
+	}
+	if userSelection.RecurrenceType == entities.Once {
+		text += "📅 " + s.Date + ": " + userSelection.SelectedDate.Format("2006-01-02") + "\n"
+	}
+	text += "⏰ " + s.Time + ": " + strings.Join(userSelection.GetSelectedTimes(), ", ") + "\n"
+	text += "💬 " + s.Message + ": " + userSelection.ReminderMessage
+	return text
+}
+
+// EditReminderResult returns to the reminder editor
+func EditReminderResult(userSelection *entities.UserSelection, lang string) *SelectionResult {
+	return &SelectionResult{Text: FormatEditReminder(userSelection, lang), Markup: GetEditReminderMarkup(userSelection, lang)}
+}
+
+// HandleEditSelection opens the keyboard for the chosen part of the reminder.
+// The picked value returns to the editor; saving and cancelling are up to the caller.
+func HandleEditSelection(callbackData string, user *entities.User, userSelection *entities.UserSelection) *SelectionResult {
+	s := T(user.Language)
+	switch callbackData {
+	case CallbackEditTime:
+		// Start over so the picked times replace the current ones
+		userSelection.SetSelectedTimes(nil)
+		return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(user.Language)}
+	case CallbackEditText:
+		return &SelectionResult{Text: s.MsgSelectMessage, Markup: GetMessageSelectionMarkup(user.Language)}
+	case CallbackEditDays:
+		switch userSelection.RecurrenceType {
+		case entities.Weekly:
+			return &SelectionResult{Text: s.MsgSelectWeekdays, Markup: GetWeekRangeMarkup(userSelection.WeekOptions, user.Language)}
+		case entities.Monthly:
+			return &SelectionResult{Text: s.MsgSelectDate, Markup: GetMonthRangeMarkup(userSelection.MonthOptions, userSelection.ClampToMonthEnd, user.Language)}
+		}
+	}
+	return EditReminderResult(userSelection, user.Language)
+}
+
+func hasEditableDays(userSelection *entities.UserSelection) bool {
+	return userSelection.RecurrenceType == entities.Weekly || userSelection.RecurrenceType == entities.Monthly
+}
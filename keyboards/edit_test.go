@@ -0,0 +1,57 @@
+package keyboards
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestHandleEditSelection(t *testing.T) {
+	user := &entities.User{Language: LangEN, Location: time.UTC}
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	reminder := entities.NewReminder(7, 1, "Stand-up", entities.CustomWeekly([]time.Weekday{time.Monday}, start, time.UTC), nil)
+
+	sel := entities.NewUserSelection()
+	sel.StartEdit(reminder)
+	if !sel.IsEditing() || sel.ReminderMessage != "Stand-up" || sel.SelectedTime != "09:00" || len(sel.WeekOptions) != 1 {
+		t.Fatalf("expected the reminder to be loaded, got %+v", sel)
+	}
+	if got := GetKeyboardType(CallbackEditSave); got != Edit {
+		t.Fatalf("GetKeyboardType(save) = %v, want %v", got, Edit)
+	}
+	if got := GetKeyboardType(CallbackReminderEditPrefix + "7"); got != Reminders {
+		t.Fatalf("GetKeyboardType(edit) = %v, want %v", got, Reminders)
+	}
+
+	result := EditReminderResult(sel, LangEN)
+	if !strings.Contains(result.Text, "Stand-up") || len(result.Markup.InlineKeyboard[0]) != 3 {
+		t.Fatalf("expected the editor with time, text and days, got %q", result.Text)
+	}
+
+	// Picking a time returns to the editor instead of asking for the message
+	result = HandleEditSelection(CallbackEditTime, user, sel)
+	if result.Text != T(LangEN).MsgSelectTime || sel.SelectedTime != "" {
+		t.Fatalf("expected the time picker with the times cleared, got %q", result.Text)
+	}
+	HandleTimeSelection(CallbackPrefixSpecificTime+"10:30", user, sel)
+	result = HandleTimeSelection(CallbackTimeDone, user, sel)
+	if !strings.Contains(result.Text, T(LangEN).MsgEditReminder) || sel.SelectedTime != "10:30" {
+		t.Fatalf("expected to return to the editor at 10:30, got %q", result.Text)
+	}
+
+	result = HandleEditSelection(CallbackEditDays, user, sel)
+	if result.Text != T(LangEN).MsgSelectWeekdays {
+		t.Fatalf("expected the weekday picker, got %q", result.Text)
+	}
+
+	// Reminders without days only offer the time and the text
+	sel.SetRecurrenceType(entities.Daily)
+	if sel.IsEditing() {
+		t.Fatalf("expected choosing a recurrence type to start a new reminder")
+	}
+	if row := GetEditReminderMarkup(sel, LangEN).InlineKeyboard[0]; len(row) != 2 {
+		t.Fatalf("expected no days button for a daily reminder, got %d buttons", len(row))
+	}
+}
@@ -0,0 +1,119 @@
+package keyboards
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+const (
+	// Represents a recurring reminder without an end.
+	CallbackEndNever = "end_never"
+	// Represents stopping after a number of occurrences, e.g. "end_count:10".
+	CallbackPrefixEndCount = "end_count:"
+	// Represents stopping after a period, e.g. "end_in:1m".
+	CallbackPrefixEndIn = "end_in:"
+	// Represents switching how weekends and holidays are handled.
+	CallbackEndHolidays = "end_holidays"
+)
+
+// endCountPresets are the occurrence limits offered in the end step
+var endCountPresets = []int{5, 10, 30}
+
+func IsEndCallback(callbackData string) bool {
+	return strings.HasPrefix(callbackData, "end_")
+}
+
+// NeedsEndSelection reports whether the reminder being set up can be given an end.
+// One-time reminders fire once and spaced repetition stops after its own ladder.
+func NeedsEndSelection(userSelection *entities.UserSelection) bool {
+	return userSelection.RecurrenceType != entities.Once && userSelection.RecurrenceType != entities.SpacedBasedRepetition
+}
+
+// GetEndMarkup offers never, a number of occurrences and a period to stop after,
+// along with a toggle for what happens on weekends and holidays.
+func GetEndMarkup(policy entities.HolidayPolicy, lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	var countRow []tgbotapi.InlineKeyboardButton
+	for _, count := range endCountPresets {
+		countRow = append(countRow, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(s.BtnEndAfterN, count), CallbackPrefixEndCount+strconv.Itoa(count)))
+	}
+
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnEndNever, CallbackEndNever)),
+		countRow,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnEndInWeek, CallbackPrefixEndIn+"1w"),
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnEndInMonth, CallbackPrefixEndIn+"1m"),
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnEndIn3Months, CallbackPrefixEndIn+"3m"),
+		),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnHolidayPolicy[policy], CallbackEndHolidays)),
+	)
+	return &menu
+}
+
+// HandleEndSelection stores the chosen end and reports whether the setup is complete.
+func HandleEndSelection(callbackData string, user *entities.User, userSelection *entities.UserSelection) (*SelectionResult, bool) {
+	switch {
+	case callbackData == CallbackEndHolidays:
+		userSelection.CycleHolidayPolicy()
+
+	case callbackData == CallbackEndNever:
+		userSelection.SetEnd(nil, 0)
+		return nil, true
+
+	case strings.HasPrefix(callbackData, CallbackPrefixEndCount):
+		count, err := strconv.Atoi(callbackData[len(CallbackPrefixEndCount):])
+		if err == nil && count > 0 {
+			userSelection.SetEnd(nil, count)
+			return nil, true
+		}
+
+	case strings.HasPrefix(callbackData, CallbackPrefixEndIn):
+		if endDate, ok := endDateIn(callbackData[len(CallbackPrefixEndIn):], time.Now(), user.GetLocation()); ok {
+			userSelection.SetEnd(&endDate, 0)
+			return nil, true
+		}
+	}
+
+	return &SelectionResult{Text: T(user.Language).MsgSelectEnd, Markup: GetEndMarkup(userSelection.GetHolidayPolicy(), user.Language)}, false
+}
+
+// endDateIn returns the last moment of the day a period after now, in the user's location
+func endDateIn(period string, now time.Time, loc *time.Location) (time.Time, bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	day := now.In(loc)
+	switch period {
+	case "1w":
+		day = day.AddDate(0, 0, 7)
+	case "1m":
+		day = day.AddDate(0, 1, 0)
+	case "3m":
+		day = day.AddDate(0, 3, 0)
+	default:
+		return time.Time{}, false
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, loc).UTC(), true
+}
+
+// FormatEnd renders the end of a recurrence, or an empty string when it never ends.
+func FormatEnd(endDate *time.Time, maxOccurrences int, loc *time.Location, lang string) string {
+	s := T(lang)
+	var parts []string
+	if maxOccurrences > 0 {
+		parts = append(parts, fmt.Sprintf(s.MsgEndsAfterN, maxOccurrences))
+	}
+	if endDate != nil {
+		if loc == nil {
+			loc = time.UTC
+		}
+		parts = append(parts, fmt.Sprintf(s.MsgEndsOn, endDate.In(loc).Format("2006-01-02")))
+	}
+	return strings.Join(parts, ", ")
+}
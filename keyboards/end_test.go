@@ -0,0 +1,72 @@
+package keyboards
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestHandleEndSelection(t *testing.T) {
+	user := &entities.User{Language: LangEN, Location: time.UTC}
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+
+	if _, completed := HandleEndSelection(CallbackPrefixEndCount+"10", user, sel); !completed || sel.MaxOccurrences != 10 || sel.EndDate != nil {
+		t.Fatalf("expected 10 occurrences, got %+v", sel)
+	}
+
+	if _, completed := HandleEndSelection(CallbackPrefixEndIn+"1w", user, sel); !completed || sel.EndDate == nil || sel.MaxOccurrences != 0 {
+		t.Fatalf("expected an end date, got %+v", sel)
+	}
+	want := time.Now().UTC().AddDate(0, 0, 7)
+	if end := sel.EndDate; end.Day() != want.Day() || end.Hour() != 23 || end.Minute() != 59 {
+		t.Fatalf("expected the end of the day in a week, got %v", end)
+	}
+
+	if _, completed := HandleEndSelection(CallbackEndNever, user, sel); !completed || sel.EndDate != nil || sel.MaxOccurrences != 0 {
+		t.Fatalf("expected no end, got %+v", sel)
+	}
+
+	result, completed := HandleEndSelection(CallbackPrefixEndIn+"1y", user, sel)
+	if completed || result == nil || result.Markup == nil {
+		t.Fatalf("expected the end picker again for an unknown period")
+	}
+}
+
+func TestNeedsEndSelection(t *testing.T) {
+	sel := entities.NewUserSelection()
+	for recurrenceType, want := range map[entities.RecurrenceType]bool{
+		entities.Once:                  false,
+		entities.SpacedBasedRepetition: false,
+		entities.Daily:                 true,
+		entities.Weekly:                true,
+		entities.Interval:              true,
+	} {
+		sel.RecurrenceType = recurrenceType
+		if got := NeedsEndSelection(sel); got != want {
+			t.Fatalf("%v: expected %v, got %v", recurrenceType, want, got)
+		}
+	}
+}
+
+func TestHandleEndSelection_HolidayPolicy(t *testing.T) {
+	user := &entities.User{Language: LangEN, Location: time.UTC}
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Daily
+
+	result, completed := HandleEndSelection(CallbackEndHolidays, user, sel)
+	if completed || sel.GetHolidayPolicy() != entities.HolidayPolicySkip {
+		t.Fatalf("expected the end picker again with skipping, got %v", sel.GetHolidayPolicy())
+	}
+	rows := result.Markup.InlineKeyboard
+	if got := rows[len(rows)-1][0].Text; got != T(LangEN).BtnHolidayPolicy[entities.HolidayPolicySkip] {
+		t.Fatalf("expected the toggle to show the new policy, got %q", got)
+	}
+
+	HandleEndSelection(CallbackEndHolidays, user, sel)
+	HandleEndSelection(CallbackEndHolidays, user, sel)
+	if sel.GetHolidayPolicy() != entities.HolidayPolicyNone {
+		t.Fatalf("expected the policy to wrap around, got %v", sel.GetHolidayPolicy())
+	}
+}
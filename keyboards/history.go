@@ -0,0 +1,63 @@
+package keyboards
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// HistoryLimit is the number of most recent deliveries shown in the bot
+const HistoryLimit = 10
+
+var deliveryKindIcons = map[entities.DeliveryKind]string{
+	entities.DeliveryKindScheduled: "🔔",
+	entities.DeliveryKindSnooze:    "⏰",
+	entities.DeliveryKindNag:       "🔁",
+}
+
+// GetReminderHistoryMarkup returns the keyboard shown under a reminder's delivery history
+func GetReminderHistoryMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, CallbackRemindersList),
+		),
+	)
+	return &markup
+}
+
+// FormatReminderHistoryText renders the delivery history of a reminder in the user's location
+func FormatReminderHistoryText(reminder *entities.Reminder, deliveries []entities.Delivery, location *time.Location, lang string) string {
+	if location == nil {
+		location = time.UTC
+	}
+	s := T(lang)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(s.HistoryTitle, reminder.Message))
+	if len(deliveries) == 0 {
+		b.WriteString(s.HistoryEmpty)
+		return b.String()
+	}
+
+	for _, d := range deliveries {
+		b.WriteString(fmt.Sprintf("%s %s", deliveryKindIcons[d.Kind], d.SentAt.In(location).Format("2006-01-02 15:04")))
+		// Mention the original time when the message went out noticeably late
+		if d.SentAt.Sub(d.ScheduledAt) >= time.Minute {
+			b.WriteString(" (" + fmt.Sprintf(s.HistoryScheduled, d.ScheduledAt.In(location).Format("2006-01-02 15:04")) + ")")
+		}
+		switch {
+		case d.Status == entities.DeliveryStatusRetrying:
+			b.WriteString(" — " + fmt.Sprintf(s.HistoryRetrying, d.Error))
+		case !d.IsSuccessful():
+			b.WriteString(" — " + fmt.Sprintf(s.HistoryFailed, d.Error))
+		case d.Acknowledged:
+			b.WriteString(" — " + s.MsgReminderDone)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package keyboards
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestParseHistoryReminderID(t *testing.T) {
+	if !IsRemindersCallback(CallbackReminderHistPrefix + "7") {
+		t.Fatalf("history callback should be recognized as reminders callback")
+	}
+	if id, ok := ParseHistoryReminderID(CallbackReminderHistPrefix + "7"); !ok || id != 7 {
+		t.Fatalf("unexpected parse result: %d, %v", id, ok)
+	}
+	if _, ok := ParseHistoryReminderID(CallbackReminderDeletePrefix + "7"); ok {
+		t.Fatalf("delete callback must not parse as history")
+	}
+}
+
+func TestFormatReminderHistoryText(t *testing.T) {
+	rem := &entities.Reminder{ID: 1, UserID: 2, Message: "Pills"}
+	if text := FormatReminderHistoryText(rem, nil, time.UTC, LangEN); !strings.Contains(text, T(LangEN).HistoryEmpty) {
+		t.Fatalf("expected empty history message, got %q", text)
+	}
+
+	at := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	late := entities.NewDelivery(rem, entities.DeliveryKindScheduled, at, at.Add(30*time.Minute))
+	late.MarkSent(5)
+	late.Acknowledge(at.Add(time.Hour))
+	failed := entities.NewDelivery(rem, entities.DeliveryKindNag, at, at)
+	failed.MarkDeadLetter(errors.New("chat not found"))
+
+	text := FormatReminderHistoryText(rem, []entities.Delivery{*late, *failed}, time.UTC, LangEN)
+	for _, want := range []string{"Pills", "2025-03-01 08:30", "scheduled for 2025-03-01 08:00", T(LangEN).MsgReminderDone, "chat not found"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in history text:\n%s", want, text)
+		}
+	}
+}
@@ -53,8 +53,13 @@ type Strings struct {
 	MsgSelectDate string
 	// Interval-related i18n
 	MsgIntervalPrompt          string // e.g., "Every N days"
-	MsgEveryNDays              string // e.g., "Every %d days"
 	MsgEveryNDaysSpaced        string // e.g., "Every %s days"
+	MsgEveryN                  string // e.g., "Every %d %s"
+	MsgSelectIntervalUnit      string
+	MsgEnterInterval           string
+	MsgSelectActiveWindow      string
+	BtnAllDay                  string
+	IntervalUnitNames          map[entities.IntervalUnit]string
 	MsgParsingFailed           string
 	MsgTimezoneAutoDetect      string
 	MsgTimezoneAutoDetectDescr string
@@ -114,6 +119,116 @@ type Strings struct {
 	PremiumUpgradeComingSoon string
 	// Language selection
 	LanguageSelectPrompt string
+	// Notification actions
+	BtnSnooze10Min    string
+	BtnSnooze1Hour    string
+	BtnSnoozeTomorrow string
+	BtnDone           string
+	MsgSnoozedUntil   string
+	MsgReminderDone   string
+	MsgLateDelivery   string
+	// Repeat until acknowledged
+	BtnNagOn  string
+	BtnNagOff string
+	// Delivery history
+	BtnHistory       string
+	HistoryTitle     string
+	HistoryEmpty     string
+	HistoryFailed    string
+	HistoryRetrying  string
+	HistoryScheduled string
+	// Recurrence end
+	MsgSelectEnd    string
+	BtnEndNever     string
+	BtnEndAfterN    string
+	BtnEndInWeek    string
+	BtnEndInMonth   string
+	BtnEndIn3Months string
+	MsgEndsAfterN   string
+	MsgEndsOn       string
+	MsgEndRejected  string
+	// Times of day
+	BtnAddTime       string
+	MsgSelectedTimes string
+	// Month end
+	BtnLastDay           string
+	BtnSecondToLastDay   string
+	BtnClampToMonthEnd   string
+	MonthDayLast         string
+	MonthDayBeforeLast   string
+	MsgClampedToMonthEnd string
+	// Yearly
+	MonthNames map[time.Month]string
+	YearlyDate string
+	// Weekday of the month
+	BtnByWeekday        string
+	BtnOrdinals         map[int]string
+	Ordinals            map[int]string
+	NthWeekday          string
+	MsgSelectNthOrdinal string
+	MsgSelectNthWeekday string
+	// Weekends and holidays
+	BtnHolidayPolicy map[entities.HolidayPolicy]string
+	HolidayPolicies  map[entities.HolidayPolicy]string
+	// Spaced repetition
+	MsgSelectSpacedMode string
+	BtnSpacedAdaptive   string
+	BtnSpacedLadder     string
+	BtnSpacedCustom     string
+	MsgEnterLadder      string
+	MsgInvalidLadder    string
+	MsgAdaptiveReviews  string
+	RecallGrades        map[entities.RecallGrade]string
+	MsgNextReview       string
+	MsgRecallLearned    string
+	// Editing reminders
+	MsgEditReminder    string
+	BtnEditTime        string
+	BtnEditText        string
+	BtnEditDays        string
+	BtnSave            string
+	BtnCancel          string
+	MsgReminderUpdated string
+	MsgEditRejected    string
+	// Pausing reminders
+	BtnPause            string
+	BtnResume           string
+	BtnPauseAll         string
+	BtnResumeAll        string
+	BtnPauseDays        string
+	BtnPauseUntilDate   string
+	MsgPauseAll         string
+	MsgEnterPauseDate   string
+	MsgInvalidPauseDate string
+	MsgAllPaused        string
+	// Reminders list
+	BtnFilter              string
+	FilterAll              string
+	FilterActive           string
+	FilterInactive         string
+	MsgRemindersFilter     string
+	MsgNoMatchingReminders string
+	MsgRemindersPage       string
+	MsgSelectFilter        string
+	// Agenda
+	CmdTodayDesc      string
+	CmdTomorrowDesc   string
+	CmdWeekDesc       string
+	AgendaTitles      map[string]string
+	BtnAgendaToday    string
+	BtnAgendaTomorrow string
+	BtnAgendaWeek     string
+	MsgAgendaEmpty    string
+	MsgAgendaMore     string
+	// Daily digest
+	AccDigest         string
+	MsgDigestSettings string
+	MsgDigestOn       string
+	MsgDigestOff      string
+	BtnDigestOff      string
+	DigestTitle       string
+	BtnCriticalOn     string
+	BtnCriticalOff    string
 }
 
 var stringsByLang = map[string]Strings{
@@ -126,6 +241,8 @@ var stringsByLang = map[string]Strings{
 			entities.Monthly:               "🗓️ Monthly",
 			entities.Interval:              "⏱️ Interval",
 			entities.SpacedBasedRepetition: "🧠 Spaced Repetition",
+			entities.RRule:                 "🔁 Custom rule",
+			entities.Yearly:                "🎂 Yearly",
 		},
 		BtnBack:                  "🔙 Back",
 		BtnCustomTime:            "Custom",
@@ -136,7 +253,7 @@ var stringsByLang = map[string]Strings{
 		MsgEnterCustomTime:       "Please type your custom time in HH:MM format (e.g., 14:30):",
 		MsgEnterCustomMessage:    "Please type your custom reminder message:",
 		MsgInvalidTimeFormat:     "Invalid time format.",
-		MsgInvalidIntervalFormat: "Invalid interval format. Expected 1-7",
+		MsgInvalidIntervalFormat: "Invalid interval format. Expected 1-%d",
 		BtnMyReminders:           "📋 My reminders",
 		NoReminders:              "You have no reminders yet.",
 		YourReminders:            "Your reminders:\n\n",
@@ -174,13 +291,23 @@ var stringsByLang = map[string]Strings{
 			time.Saturday:  "Sat",
 			time.Sunday:    "Sun",
 		},
+		IntervalUnitNames: map[entities.IntervalUnit]string{
+			entities.IntervalUnitMinutes: "minutes",
+			entities.IntervalUnitHours:   "hours",
+			entities.IntervalUnitDays:    "days",
+			entities.IntervalUnitWeeks:   "weeks",
+		},
 		MsgSelectWeekdays:          "Select weekdays:",
 		MsgSelectTimeWeekly:        "Select time for weekly reminders:",
 		BtnSelect:                  "Select",
 		MsgSelectDate:              "Select a date:",
 		MsgIntervalPrompt:          "Every N days",
-		MsgEveryNDays:              "Every %d days",
 		MsgEveryNDaysSpaced:        "Every %s days",
+		MsgEveryN:                  "Every %d %s",
+		MsgSelectIntervalUnit:      "Remind me every…",
+		MsgEnterInterval:           "How many %s between reminders? Enter a number from 1 to %d:",
+		MsgSelectActiveWindow:      "Remind only during these hours?",
+		BtnAllDay:                  "🌐 All day",
 		MsgParsingFailed:           "I didn't understand that. Please use the menu buttons.",
 		MsgTimezoneAutoDetect:      "🌍 Set Timezone Automatically",
 		MsgTimezoneAutoDetectDescr: "Click the button to detect your timezone.",
@@ -237,6 +364,134 @@ var stringsByLang = map[string]Strings{
 		AccViewPremium:       "💎 Premium Usage",
 		TzManualSelect:       "📍 Select Manually",
 		TzSelectPrompt:       "Select your timezone:",
+		// Notification actions
+		BtnSnooze10Min:    "⏰ 10 min",
+		BtnSnooze1Hour:    "⏰ 1 hour",
+		BtnSnoozeTomorrow: "🌅 Tomorrow",
+		BtnDone:           "✅ Done",
+		MsgSnoozedUntil:   "⏰ Snoozed until %s",
+		MsgReminderDone:   "✅ Done",
+		MsgLateDelivery:   "🕒 Originally scheduled for %s",
+		BtnNagOn:          "🔁 Repeat: on",
+		BtnNagOff:         "🔕 Repeat: off",
+		BtnHistory:        "📜 History",
+		HistoryTitle:      "📜 History of \"%s\":\n\n",
+		HistoryEmpty:      "This reminder has not been sent yet.",
+		HistoryFailed:     "❌ Failed: %s",
+		HistoryRetrying:   "⏳ Retrying: %s",
+		HistoryScheduled:  "scheduled for %s",
+		// Recurrence end
+		MsgSelectEnd:    "When should the reminder stop?",
+		BtnEndNever:     "♾️ Never",
+		BtnEndAfterN:    "After %d times",
+		BtnEndInWeek:    "In 1 week",
+		BtnEndInMonth:   "In 1 month",
+		BtnEndIn3Months: "In 3 months",
+		MsgEndsAfterN:   "Ends after %d times",
+		MsgEndsOn:       "Ends on %s",
+		MsgEndRejected:  "⚠️ The reminder would end before it first fires. Please pick another end.",
+		// Times of day
+		BtnAddTime:       "➕ Add time",
+		MsgSelectedTimes: "Selected times: %s\nAdd another time or tap a selected one again to remove it.",
+		// Month end
+		BtnLastDay:           "Last",
+		BtnSecondToLastDay:   "2nd to last",
+		BtnClampToMonthEnd:   "Short months: use the last day",
+		MonthDayLast:         "last",
+		MonthDayBeforeLast:   "%d before last",
+		MsgClampedToMonthEnd: "last day in shorter months",
+		// Yearly
+		MonthNames: map[time.Month]string{
+			time.January: "January", time.February: "February", time.March: "March", time.April: "April",
+			time.May: "May", time.June: "June", time.July: "July", time.August: "August",
+			time.September: "September", time.October: "October", time.November: "November", time.December: "December",
+		},
+		YearlyDate: "%[2]s %[1]d",
+		// Weekday of the month
+		BtnByWeekday:        "📅 By weekday, e.g. first Monday",
+		BtnOrdinals:         map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", -1: "Last"},
+		Ordinals:            map[int]string{1: "first", 2: "second", 3: "third", 4: "fourth", -1: "last"},
+		NthWeekday:          "%[1]s %[2]s",
+		MsgSelectNthOrdinal: "Which one in the month?",
+		MsgSelectNthWeekday: "Select the weekday:",
+		// Weekends and holidays
+		BtnHolidayPolicy: map[entities.HolidayPolicy]string{
+			entities.HolidayPolicyNone:  "📅 Weekends & holidays: remind",
+			entities.HolidayPolicySkip:  "📅 Weekends & holidays: skip",
+			entities.HolidayPolicyShift: "📅 Weekends & holidays: next business day",
+		},
+		HolidayPolicies: map[entities.HolidayPolicy]string{
+			entities.HolidayPolicySkip:  "Skips weekends and holidays",
+			entities.HolidayPolicyShift: "Moves to the next business day on weekends and holidays",
+		},
+		// Spaced repetition
+		MsgSelectSpacedMode: "How should the reviews be spaced?",
+		BtnSpacedAdaptive:   "🧠 Adapt to how well I remember",
+		BtnSpacedLadder:     "🪜 Every %s days",
+		BtnSpacedCustom:     "✏️ My own intervals",
+		MsgEnterLadder:      "Enter the days between reviews, e.g. 1, 3, 7, 14, 30 (up to 20 steps of 1 to 365 days):",
+		MsgInvalidLadder:    "⚠️ Could not read the intervals. Enter 1 to 20 numbers of days from 1 to 365, separated by commas, e.g. 1, 3, 7, 14",
+		MsgAdaptiveReviews:  "Adapts to how well you remember",
+		RecallGrades: map[entities.RecallGrade]string{
+			entities.RecallAgain: "🔁 Again",
+			entities.RecallHard:  "😓 Hard",
+			entities.RecallGood:  "🙂 Good",
+			entities.RecallEasy:  "😎 Easy",
+		},
+		MsgNextReview:    "🧠 Next review on %s",
+		MsgRecallLearned: "🎓 Learned! No more reviews for this one.",
+		// Editing reminders
+		MsgEditReminder:    "✏️ Editing reminder",
+		BtnEditTime:        "⏰ Time",
+		BtnEditText:        "💬 Text",
+		BtnEditDays:        "📆 Days",
+		BtnSave:            "💾 Save",
+		BtnCancel:          "✖️ Cancel",
+		MsgReminderUpdated: "✅ Reminder updated",
+		MsgEditRejected:    "⚠️ Could not save the changes, please check the reminder and try again",
+		// Pausing reminders
+		BtnPause:            "⏸ Pause",
+		BtnResume:           "▶️ Resume",
+		BtnPauseAll:         "🏖 Pause all",
+		BtnResumeAll:        "▶️ Resume all",
+		BtnPauseDays:        "%d days",
+		BtnPauseUntilDate:   "📅 Until a date",
+		MsgPauseAll:         "🏖 Pause all reminders for how long? Reminders that fall into the pause are skipped.",
+		MsgEnterPauseDate:   "Enter the date to resume reminders on (YYYY-MM-DD):",
+		MsgInvalidPauseDate: "❌ Invalid date. Please enter a future date as YYYY-MM-DD",
+		MsgAllPaused:        "🏖 All reminders are paused until %s\n\n",
+		// Reminders list
+		BtnFilter:              "🔍 Show: %s",
+		FilterAll:              "All",
+		FilterActive:           "✅ Active",
+		FilterInactive:         "❌ Inactive",
+		MsgRemindersFilter:     "🔍 %s: %d of %d\n\n",
+		MsgNoMatchingReminders: "No reminders match the filter.",
+		MsgRemindersPage:       "\nPage %d of %d",
+		MsgSelectFilter:        "🔍 Which reminders to show?",
+		// Agenda
+		CmdTodayDesc:    "Show what is coming up today",
+		CmdTomorrowDesc: "Show what is coming up tomorrow",
+		CmdWeekDesc:     "Show what is coming up in the next 7 days",
+		AgendaTitles: map[string]string{
+			AgendaToday:    "📅 Today",
+			AgendaTomorrow: "📅 Tomorrow",
+			AgendaWeek:     "📅 Next 7 days",
+		},
+		BtnAgendaToday:    "Today",
+		BtnAgendaTomorrow: "Tomorrow",
+		BtnAgendaWeek:     "Week",
+		MsgAgendaEmpty:    "Nothing scheduled.",
+		MsgAgendaMore:     "…and %d more\n",
+		// Daily digest
+		AccDigest:         "📰 Daily Digest",
+		MsgDigestSettings: "📰 Daily digest\n\nGet one message a day listing your upcoming reminders instead of a notification for each. Reminders marked 🚨 and spaced repetition reviews still notify on their own.\n\n",
+		MsgDigestOn:       "✅ Sent every day at %s",
+		MsgDigestOff:      "Off, every reminder notifies on its own",
+		BtnDigestOff:      "🔕 Turn off",
+		DigestTitle:       "☀️ Your daily digest",
+		BtnCriticalOn:     "🚨 Own",
+		BtnCriticalOff:    "📰 Digest",
 	},
 	LangUK: {
 		Welcome: "Ласкаво просимо до бота-нагадувача!",
@@ -247,6 +502,8 @@ var stringsByLang = map[string]Strings{
 			entities.Monthly:               "🗓️ Щомісяця",
 			entities.Interval:              "⏱️ Інтервал",
 			entities.SpacedBasedRepetition: "🧠 Інтервал з повторенням",
+			entities.RRule:                 "🔁 Власне правило",
+			entities.Yearly:                "🎂 Щороку",
 		},
 		BtnBack:                  "🔙 Назад",
 		BtnCustomTime:            "Свій час",
@@ -257,7 +514,7 @@ var stringsByLang = map[string]Strings{
 		MsgEnterCustomTime:       "Введіть час у форматі HH:MM (напр., 14:30):",
 		MsgEnterCustomMessage:    "Введіть власний текст нагадування:",
 		MsgInvalidTimeFormat:     "Неправильний формат часу.",
-		MsgInvalidIntervalFormat: "Неправильний формат інтервалу. Очікується 1-7",
+		MsgInvalidIntervalFormat: "Неправильний формат інтервалу. Очікується 1-%d",
 		BtnMyReminders:           "📋 Мої нагадування",
 		NoReminders:              "У вас ще немає нагадувань.",
 		YourReminders:            "Ваші нагадування:\n\n",
@@ -295,13 +552,23 @@ var stringsByLang = map[string]Strings{
 			time.Saturday:  "Сб",
 			time.Sunday:    "Нд",
 		},
+		IntervalUnitNames: map[entities.IntervalUnit]string{
+			entities.IntervalUnitMinutes: "хвилин",
+			entities.IntervalUnitHours:   "годин",
+			entities.IntervalUnitDays:    "днів",
+			entities.IntervalUnitWeeks:   "тижнів",
+		},
 		MsgSelectWeekdays:          "Оберіть дні тижня:",
 		MsgSelectTimeWeekly:        "Оберіть час для щотижневих нагадувань:",
 		BtnSelect:                  "Обрати",
 		MsgSelectDate:              "Оберіть дату:",
 		MsgIntervalPrompt:          "Кожні N днів",
-		MsgEveryNDays:              "Кожні %d днів",
 		MsgEveryNDaysSpaced:        "Кожні %s днів",
+		MsgEveryN:                  "Кожні %d %s",
+		MsgSelectIntervalUnit:      "Нагадувати кожні…",
+		MsgEnterInterval:           "Скільки %s між нагадуваннями? Введіть число від 1 до %d:",
+		MsgSelectActiveWindow:      "Нагадувати лише в ці години?",
+		BtnAllDay:                  "🌐 Цілодобово",
 		MsgParsingFailed:           "Я не зрозумів. Будь ласка, скористайтеся кнопками меню.",
 		MsgTimezoneAutoDetect:      "🌍 Автоматично встановити часовий пояс",
 		MsgTimezoneAutoDetectDescr: "Натисніть кнопку, щоб визначити свій часовий пояс.",
@@ -358,6 +625,134 @@ var stringsByLang = map[string]Strings{
 		AccViewPremium:       "💎 Преміум статус",
 		TzManualSelect:       "📍 Обрати вручну",
 		TzSelectPrompt:       "Оберіть свій часовий пояс:",
+		// Notification actions
+		BtnSnooze10Min:    "⏰ 10 хв",
+		BtnSnooze1Hour:    "⏰ 1 год",
+		BtnSnoozeTomorrow: "🌅 Завтра",
+		BtnDone:           "✅ Виконано",
+		MsgSnoozedUntil:   "⏰ Відкладено до %s",
+		MsgReminderDone:   "✅ Виконано",
+		MsgLateDelivery:   "🕒 Було заплановано на %s",
+		BtnNagOn:          "🔁 Повтор: так",
+		BtnNagOff:         "🔕 Повтор: ні",
+		BtnHistory:        "📜 Історія",
+		HistoryTitle:      "📜 Історія \"%s\":\n\n",
+		HistoryEmpty:      "Це нагадування ще не надсилалося.",
+		HistoryFailed:     "❌ Помилка: %s",
+		HistoryRetrying:   "⏳ Повторна спроба: %s",
+		HistoryScheduled:  "заплановано на %s",
+		// Recurrence end
+		MsgSelectEnd:    "Коли припинити нагадування?",
+		BtnEndNever:     "♾️ Ніколи",
+		BtnEndAfterN:    "Після %d разів",
+		BtnEndInWeek:    "Через тиждень",
+		BtnEndInMonth:   "Через місяць",
+		BtnEndIn3Months: "Через 3 місяці",
+		MsgEndsAfterN:   "Закінчиться після %d разів",
+		MsgEndsOn:       "Закінчиться %s",
+		MsgEndRejected:  "⚠️ Нагадування закінчилося б ще до першого спрацювання. Оберіть інше завершення.",
+		// Times of day
+		BtnAddTime:       "➕ Додати час",
+		MsgSelectedTimes: "Обраний час: %s\nДодайте ще або натисніть обраний час ще раз, щоб його прибрати.",
+		// Month end
+		BtnLastDay:           "Останній",
+		BtnSecondToLastDay:   "Передостанній",
+		BtnClampToMonthEnd:   "Короткі місяці: останній день",
+		MonthDayLast:         "останній",
+		MonthDayBeforeLast:   "за %d до останнього",
+		MsgClampedToMonthEnd: "останній день у коротших місяцях",
+		// Yearly
+		MonthNames: map[time.Month]string{
+			time.January: "січня", time.February: "лютого", time.March: "березня", time.April: "квітня",
+			time.May: "травня", time.June: "червня", time.July: "липня", time.August: "серпня",
+			time.September: "вересня", time.October: "жовтня", time.November: "листопада", time.December: "грудня",
+		},
+		YearlyDate: "%[1]d %[2]s",
+		// Weekday of the month
+		BtnByWeekday:        "📅 За днем тижня, напр. перший понеділок",
+		BtnOrdinals:         map[int]string{1: "1-й", 2: "2-й", 3: "3-й", 4: "4-й", -1: "Останній"},
+		Ordinals:            map[int]string{1: "1-го", 2: "2-го", 3: "3-го", 4: "4-го", -1: "останнього"},
+		NthWeekday:          "%[2]s %[1]s тижня",
+		MsgSelectNthOrdinal: "Який за рахунком у місяці?",
+		MsgSelectNthWeekday: "Оберіть день тижня:",
+		// Weekends and holidays
+		BtnHolidayPolicy: map[entities.HolidayPolicy]string{
+			entities.HolidayPolicyNone:  "📅 Вихідні та свята: нагадувати",
+			entities.HolidayPolicySkip:  "📅 Вихідні та свята: пропускати",
+			entities.HolidayPolicyShift: "📅 Вихідні та свята: на наступний робочий день",
+		},
+		HolidayPolicies: map[entities.HolidayPolicy]string{
+			entities.HolidayPolicySkip:  "Пропускає вихідні та свята",
+			entities.HolidayPolicyShift: "У вихідні та свята переноситься на наступний робочий день",
+		},
+		// Spaced repetition
+		MsgSelectSpacedMode: "Як розподілити повторення?",
+		BtnSpacedAdaptive:   "🧠 Залежно від того, як я пам'ятаю",
+		BtnSpacedLadder:     "🪜 Кожні %s днів",
+		BtnSpacedCustom:     "✏️ Власні інтервали",
+		MsgEnterLadder:      "Введіть кількість днів між повтореннями, напр. 1, 3, 7, 14, 30 (до 20 кроків від 1 до 365 днів):",
+		MsgInvalidLadder:    "⚠️ Не вдалося прочитати інтервали. Введіть від 1 до 20 чисел днів від 1 до 365 через кому, напр. 1, 3, 7, 14",
+		MsgAdaptiveReviews:  "Підлаштовується під те, як ви пам'ятаєте",
+		RecallGrades: map[entities.RecallGrade]string{
+			entities.RecallAgain: "🔁 Знову",
+			entities.RecallHard:  "😓 Важко",
+			entities.RecallGood:  "🙂 Добре",
+			entities.RecallEasy:  "😎 Легко",
+		},
+		MsgNextReview:    "🧠 Наступне повторення %s",
+		MsgRecallLearned: "🎓 Вивчено! Більше повторень не буде.",
+		// Editing reminders
+		MsgEditReminder:    "✏️ Редагування нагадування",
+		BtnEditTime:        "⏰ Час",
+		BtnEditText:        "💬 Текст",
+		BtnEditDays:        "📆 Дні",
+		BtnSave:            "💾 Зберегти",
+		BtnCancel:          "✖️ Скасувати",
+		MsgReminderUpdated: "✅ Нагадування оновлено",
+		MsgEditRejected:    "⚠️ Не вдалося зберегти зміни, перевірте нагадування та спробуйте ще раз",
+		// Pausing reminders
+		BtnPause:            "⏸ Пауза",
+		BtnResume:           "▶️ Відновити",
+		BtnPauseAll:         "🏖 Призупинити всі",
+		BtnResumeAll:        "▶️ Відновити всі",
+		BtnPauseDays:        "%d дн.",
+		BtnPauseUntilDate:   "📅 До дати",
+		MsgPauseAll:         "🏖 На скільки призупинити всі нагадування? Нагадування під час паузи буде пропущено.",
+		MsgEnterPauseDate:   "Введіть дату, з якої відновити нагадування (РРРР-ММ-ДД):",
+		MsgInvalidPauseDate: "❌ Невірна дата. Введіть майбутню дату у форматі РРРР-ММ-ДД",
+		MsgAllPaused:        "🏖 Усі нагадування призупинено до %s\n\n",
+		// Reminders list
+		BtnFilter:              "🔍 Показати: %s",
+		FilterAll:              "Усі",
+		FilterActive:           "✅ Активні",
+		FilterInactive:         "❌ Неактивні",
+		MsgRemindersFilter:     "🔍 %s: %d з %d\n\n",
+		MsgNoMatchingReminders: "Немає нагадувань, що відповідають фільтру.",
+		MsgRemindersPage:       "\nСторінка %d з %d",
+		MsgSelectFilter:        "🔍 Які нагадування показати?",
+		// Agenda
+		CmdTodayDesc:    "Показати, що заплановано на сьогодні",
+		CmdTomorrowDesc: "Показати, що заплановано на завтра",
+		CmdWeekDesc:     "Показати, що заплановано на найближчі 7 днів",
+		AgendaTitles: map[string]string{
+			AgendaToday:    "📅 Сьогодні",
+			AgendaTomorrow: "📅 Завтра",
+			AgendaWeek:     "📅 Найближчі 7 днів",
+		},
+		BtnAgendaToday:    "Сьогодні",
+		BtnAgendaTomorrow: "Завтра",
+		BtnAgendaWeek:     "Тиждень",
+		MsgAgendaEmpty:    "Нічого не заплановано.",
+		MsgAgendaMore:     "…і ще %d\n",
+		// Daily digest
+		AccDigest:         "📰 Щоденний дайджест",
+		MsgDigestSettings: "📰 Щоденний дайджест\n\nОтримуйте одне повідомлення на день зі списком найближчих нагадувань замість сповіщення для кожного. Нагадування з позначкою 🚨 та повторення за інтервальним методом надходять окремо.\n\n",
+		MsgDigestOn:       "✅ Надсилається щодня о %s",
+		MsgDigestOff:      "Вимкнено, кожне нагадування надходить окремо",
+		BtnDigestOff:      "🔕 Вимкнути",
+		DigestTitle:       "☀️ Ваш щоденний дайджест",
+		BtnCriticalOn:     "🚨 Окремо",
+		BtnCriticalOff:    "📰 Дайджест",
 	},
 }
 
@@ -1,7 +1,9 @@
 package keyboards
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
@@ -9,30 +11,126 @@ import (
 
 const (
 	CallbackIntervalStart = "interval_start"
+	// Represents the selection of an interval unit, e.g. "interval_unit:hours".
+	CallbackPrefixIntervalUnit = "interval_unit:"
+	// Represents the selection of an active window, e.g. "interval_window:08:00-22:00".
+	CallbackPrefixActiveWindow = "interval_window:"
+	// Represents reminding around the clock.
+	CallbackActiveWindowAllDay = CallbackPrefixActiveWindow + "all"
 )
 
+// activeWindowPresets are the daily windows offered for minute and hour intervals
+var activeWindowPresets = []entities.ActiveWindow{
+	{From: "08:00", To: "22:00"},
+	{From: "09:00", To: "21:00"},
+	{From: "07:00", To: "23:00"},
+}
+
 func IsIntervalCallback(callbackData string) bool {
-	return callbackData == CallbackIntervalStart
+	return strings.HasPrefix(callbackData, "interval_")
 }
 
-// GetIntervalPrompt returns a text asking user to enter N for interval days. No keyboard.
+// GetIntervalPrompt returns the interval unit picker and resets a previously chosen interval.
 func GetIntervalPrompt(userSelection *entities.UserSelection, lang string) *tgbotapi.InlineKeyboardMarkup {
-	userSelection.StartCustomInterval()
-	// no keyboard for interval; user should type a number
-	return nil
+	userSelection.SetCustomInterval(0)
+	userSelection.SetActiveWindow(nil)
+	return GetIntervalUnitMarkup(lang)
+}
+
+// GetIntervalUnitMarkup lists minutes, hours, days and weeks.
+func GetIntervalUnitMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	var row []tgbotapi.InlineKeyboardButton
+	for _, unit := range entities.IntervalUnits {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("… "+s.IntervalUnitNames[unit], CallbackPrefixIntervalUnit+string(unit)))
+	}
+
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, SetupMenu)),
+	)
+	return &menu
+}
+
+// GetActiveWindowMarkup offers the active window presets and an all-day option.
+func GetActiveWindowMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, window := range activeWindowPresets {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(FormatActiveWindow(&window), CallbackPrefixActiveWindow+window.From+"-"+window.To)))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(s.BtnAllDay, CallbackActiveWindowAllDay),
+		tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, SetupMenu),
+	))
+
+	menu := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return &menu
+}
+
+// HandleIntervalSelection handles the unit and active window steps of an interval reminder.
+func HandleIntervalSelection(callbackData string, user *entities.User, userSelection *entities.UserSelection) *SelectionResult {
+	s := T(user.Language)
+	switch {
+	case strings.HasPrefix(callbackData, CallbackPrefixIntervalUnit):
+		unit, err := entities.ToIntervalUnit(callbackData[len(CallbackPrefixIntervalUnit):])
+		if err != nil {
+			return &SelectionResult{Text: s.MsgSelectIntervalUnit, Markup: GetIntervalUnitMarkup(user.Language)}
+		}
+		userSelection.SetIntervalUnit(unit)
+		return &SelectionResult{Text: fmt.Sprintf(s.MsgEnterInterval, s.IntervalUnitNames[unit], unit.MaxInterval()), Markup: nil}
+
+	case callbackData == CallbackActiveWindowAllDay:
+		userSelection.SetActiveWindow(nil)
+		return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(user.Language)}
+
+	case strings.HasPrefix(callbackData, CallbackPrefixActiveWindow):
+		from, to, _ := strings.Cut(callbackData[len(CallbackPrefixActiveWindow):], "-")
+		window, err := entities.NewActiveWindow(from, to)
+		if err != nil {
+			return &SelectionResult{Text: s.MsgSelectActiveWindow, Markup: GetActiveWindowMarkup(user.Language)}
+		}
+		// The window start is the first reminder of each day, so no time needs to be picked
+		userSelection.SetActiveWindow(window)
+		userSelection.SetSelectedTime(window.From)
+		return &SelectionResult{Text: s.MsgSelectMessage, Markup: GetMessageSelectionMarkup(user.Language)}
+	}
+
+	return &SelectionResult{Text: s.MsgSelectIntervalUnit, Markup: GetIntervalUnitMarkup(user.Language)}
 }
 
 func HandleCustomIntervalInput(text string, userEntity *entities.User, selection *entities.UserSelection) *SelectionResult {
 	s := T(userEntity.Language)
-	i, err := strconv.Atoi(text)
-	if err != nil || i < 0 || i > 7 {
-		return &SelectionResult{Text: s.MsgInvalidIntervalFormat, Markup: nil}
+	unit := selection.IntervalUnit.Normalize()
+	i, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || i < 1 || i > unit.MaxInterval() {
+		return &SelectionResult{Text: fmt.Sprintf(s.MsgInvalidIntervalFormat, unit.MaxInterval()), Markup: nil}
 	}
 
 	selection.SetCustomInterval(i)
 
+	if unit.IsSubDaily() {
+		return &SelectionResult{Text: s.MsgSelectActiveWindow, Markup: GetActiveWindowMarkup(userEntity.Language)}
+	}
+
 	outputText := s.MsgSelectTime
 	markup := GetHourRangeMarkup(userEntity.Language)
 
 	return &SelectionResult{Text: outputText, Markup: markup}
 }
+
+// FormatActiveWindow renders an active window as "08:00–22:00".
+func FormatActiveWindow(window *entities.ActiveWindow) string {
+	return window.From + "–" + window.To
+}
+
+// FormatInterval renders an interval recurrence as e.g. "Every 2 hours, 08:00–22:00".
+func FormatInterval(interval int, unit entities.IntervalUnit, window *entities.ActiveWindow, lang string) string {
+	s := T(lang)
+	text := fmt.Sprintf(s.MsgEveryN, interval, s.IntervalUnitNames[unit.Normalize()])
+	if window != nil && unit.IsSubDaily() {
+		text += ", " + FormatActiveWindow(window)
+	}
+	return text
+}
@@ -0,0 +1,54 @@
+package keyboards
+
+import (
+	"testing"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestIntervalSetup_HoursWithActiveWindow(t *testing.T) {
+	user := &entities.User{Language: LangEN}
+	selection := entities.NewUserSelection()
+	selection.SetRecurrenceType(entities.Interval)
+
+	res := HandleIntervalSelection(CallbackPrefixIntervalUnit+string(entities.IntervalUnitHours), user, selection)
+	if res == nil || !selection.CustomInterval || selection.IntervalUnit != entities.IntervalUnitHours {
+		t.Fatalf("choosing hours should ask for the number of hours")
+	}
+
+	res = HandleCustomIntervalInput("25", user, selection)
+	if res.Markup != nil || selection.Interval != 0 {
+		t.Fatalf("25 hours should be rejected")
+	}
+
+	res = HandleCustomIntervalInput("2", user, selection)
+	if selection.Interval != 2 || res.Markup == nil || res.Text != T(LangEN).MsgSelectActiveWindow {
+		t.Fatalf("hour intervals should offer an active window, got %q", res.Text)
+	}
+
+	res = HandleIntervalSelection(CallbackPrefixActiveWindow+"08:00-22:00", user, selection)
+	if selection.ActiveWindow == nil || selection.ActiveWindow.From != "08:00" || selection.ActiveWindow.To != "22:00" {
+		t.Fatalf("expected active window 08:00-22:00, got %+v", selection.ActiveWindow)
+	}
+	if selection.SelectedTime != "08:00" || res.Text != T(LangEN).MsgSelectMessage {
+		t.Fatalf("the window start should be used as the time of day")
+	}
+
+	if got, want := FormatInterval(selection.Interval, selection.IntervalUnit, selection.ActiveWindow, LangEN), "Every 2 hours, 08:00–22:00"; got != want {
+		t.Fatalf("FormatInterval() = %q, want %q", got, want)
+	}
+}
+
+func TestIntervalSetup_DaysAskForTime(t *testing.T) {
+	user := &entities.User{Language: LangEN}
+	selection := entities.NewUserSelection()
+
+	HandleIntervalSelection(CallbackPrefixIntervalUnit+string(entities.IntervalUnitDays), user, selection)
+	res := HandleCustomIntervalInput("3", user, selection)
+	if selection.Interval != 3 || res.Text != T(LangEN).MsgSelectTime {
+		t.Fatalf("day intervals should go straight to time selection, got %q", res.Text)
+	}
+	if GetKeyboardType(CallbackActiveWindowAllDay) != Interval {
+		t.Fatalf("active window callbacks should route to the interval keyboard")
+	}
+}
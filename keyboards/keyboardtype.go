@@ -14,6 +14,13 @@ const (
 	Month
 	Message
 	Reminders
+	Interval
+	End
+	NthWeekday
+	SpacedRepetition
+	Edit
+	Agenda
+	Digest
 )
 
 func (kt KeyboardType) String() string {
@@ -36,6 +43,20 @@ func (kt KeyboardType) String() string {
 		return "message"
 	case Reminders:
 		return "reminders"
+	case Interval:
+		return "interval"
+	case End:
+		return "end"
+	case NthWeekday:
+		return "nth_weekday"
+	case SpacedRepetition:
+		return "spaced_repetition"
+	case Edit:
+		return "edit"
+	case Agenda:
+		return "agenda"
+	case Digest:
+		return "digest"
 	default:
 		return "unknown"
 	}
@@ -69,6 +90,27 @@ func GetKeyboardType(callbackData string) KeyboardType {
 	if IsRemindersCallback(callbackData) {
 		return Reminders
 	}
+	if IsIntervalCallback(callbackData) {
+		return Interval
+	}
+	if IsEndCallback(callbackData) {
+		return End
+	}
+	if IsNthWeekdayCallback(callbackData) {
+		return NthWeekday
+	}
+	if IsSpacedRepetitionCallback(callbackData) {
+		return SpacedRepetition
+	}
+	if IsEditCallback(callbackData) {
+		return Edit
+	}
+	if IsAgendaCallback(callbackData) {
+		return Agenda
+	}
+	if IsDigestCallback(callbackData) {
+		return Digest
+	}
 	_, err := entities.ToRecurrenceType(callbackData)
 	if err == nil {
 		return Reccurence
@@ -1,7 +1,6 @@
 package keyboards
 
 import (
-	"fmt"
 	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -69,6 +68,7 @@ func HandleCustomText(text string,
 	user *entities.User,
 	userSelection *entities.UserSelection) (*SelectionResult, bool) {
 	userSelection.ReminderMessage = text
+	userSelection.CustomText = false
 	return nil, true
 }
 
@@ -78,59 +78,45 @@ func FormatReminderConfirmation(user *entities.User, userSelection *entities.Use
 	confirmation := "✅ " + s.ReminderSet + "!\n\n"
 	confirmation += "📅 " + s.Frequency + ": " + RecurrenceTypeLabel(user.Language, userSelection.RecurrenceType) + "\n"
 
-	if userSelection.RecurrenceType == entities.Weekly {
-		confirmation += "📆 " + s.Days + ": "
-		days := []string{}
-		for _, weekday := range userSelection.WeekOptions {
-			days = append(days, s.WeekdayNames[weekday])
-		}
-		if len(days) > 0 {
-			confirmation += strings.Join(days, ", ")
-		} else {
-			confirmation += s.NoneSelected
-		}
-		confirmation += "\n"
-	}
-
-	if userSelection.RecurrenceType == entities.Monthly {
-		confirmation += "📆 " + s.Days + ": "
-		var days []string
-		for _, day := range userSelection.MonthOptions {
-			days = append(days, fmt.Sprintf("%d", day))
-		}
-		if len(days) > 0 {
-			confirmation += strings.Join(days, ", ")
-		} else {
-			confirmation += s.NoneSelected
-		}
-		confirmation += "\n"
+	if days, ok := formatSelectedDays(userSelection, user.Language); ok {
+		confirmation += "📆 " + s.Days + ": " + days + "\n"
 	}
 
 	if userSelection.RecurrenceType == entities.Once {
 		confirmation += "📅 " + s.Date + ": " + userSelection.SelectedDate.Format("2006-01-02") + "\n"
 	}
 
+	if userSelection.RecurrenceType == entities.Yearly {
+		confirmation += "📅 " + s.Date + ": " + FormatYearlyDate(userSelection.SelectedDate.Month(), userSelection.SelectedDate.Day(), user.Language) + "\n"
+	}
+
 	if userSelection.RecurrenceType == entities.Interval {
-		if userSelection.IntervalDays > 0 {
-			confirmation += "📆 " + fmt.Sprintf(s.MsgEveryNDays, userSelection.IntervalDays) + "\n"
+		if userSelection.Interval > 0 {
+			confirmation += "📆 " + FormatInterval(userSelection.Interval, userSelection.IntervalUnit, userSelection.ActiveWindow, user.Language) + "\n"
 		} else {
 			confirmation += "📆 " + s.MsgIntervalPrompt + "\n"
 		}
 	}
 
+	if userSelection.RecurrenceType == entities.RRule {
+		confirmation += "🔁 " + userSelection.RRule + "\n"
+	}
+
 	if userSelection.RecurrenceType == entities.SpacedBasedRepetition {
-		var days string
-		// TODO: make this more flexible in the future and reuse recurrence parsing logic
-		for i, c := range []int{1, 2, 3, 5, 7, 7, 7} {
-			if i > 0 {
-				days += ", "
-			}
-			days += fmt.Sprintf("%d", c)
+		ladder := userSelection.Ladder
+		if len(ladder) == 0 {
+			ladder = entities.DefaultSpacedRepetitionLadder
 		}
-		confirmation += "📆 " + fmt.Sprintf(s.MsgEveryNDaysSpaced, days) + "\n"
+		confirmation += "📆 " + FormatSpacedRepetition(userSelection.AdaptiveRecall, ladder, user.Language) + "\n"
 	}
 
-	confirmation += "⏰ " + s.Time + ": " + userSelection.SelectedTime + "\n"
+	confirmation += "⏰ " + s.Time + ": " + strings.Join(userSelection.GetSelectedTimes(), ", ") + "\n"
+	if end := FormatEnd(userSelection.EndDate, userSelection.MaxOccurrences, user.GetLocation(), user.Language); end != "" {
+		confirmation += "🏁 " + end + "\n"
+	}
+	if userSelection.BusinessDays.IsActive() {
+		confirmation += "💼 " + s.HolidayPolicies[userSelection.BusinessDays.Policy] + "\n"
+	}
 	confirmation += "💬 " + s.Message + ": " + userSelection.ReminderMessage + "\n\n"
 	confirmation += s.ReminderScheduled
 
@@ -142,3 +128,29 @@ func FormatReminderConfirmation(user *entities.User, userSelection *entities.Use
 
 	return &SelectionResult{Text: confirmation, Markup: &myRemindersMenu}
 }
+
+// formatSelectedDays lists the weekdays of a weekly or the days of a monthly selection;
+// ok is false for other recurrence types
+func formatSelectedDays(userSelection *entities.UserSelection, lang string) (string, bool) {
+	s := T(lang)
+	switch userSelection.RecurrenceType {
+	case entities.Weekly:
+		days := []string{}
+		for _, weekday := range userSelection.WeekOptions {
+			days = append(days, s.WeekdayNames[weekday])
+		}
+		if len(days) == 0 {
+			return s.NoneSelected, true
+		}
+		return strings.Join(days, ", "), true
+	case entities.Monthly:
+		if len(userSelection.NthWeekdays) > 0 {
+			return FormatNthWeekdays(userSelection.NthWeekdays, lang), true
+		}
+		if len(userSelection.MonthOptions) > 0 {
+			return FormatMonthDays(userSelection.MonthOptions, userSelection.ClampToMonthEnd, lang), true
+		}
+		return s.NoneSelected, true
+	}
+	return "", false
+}
@@ -2,18 +2,22 @@ package keyboards
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 )
 
 // The callback data prefixes help to parse the user's selection for month days.
 const (
 	CallbackMonthSelect = "month_select"
 	CallbackMonthDay    = "month_day:"
+	CallbackMonthClamp  = "month_clamp"
 )
 
 func IsMonthSelectionCallback(callbackData string) bool {
-	if callbackData == CallbackMonthSelect {
+	if callbackData == CallbackMonthSelect || callbackData == CallbackMonthClamp {
 		return true
 	}
 	if stringsHasPrefix(callbackData, CallbackMonthDay) {
@@ -22,8 +26,10 @@ func IsMonthSelectionCallback(callbackData string) bool {
 	return false
 }
 
-// GetMonthRangeMarkup renders a 4x7 grid for days 1..28 with multi-select support.
-func GetMonthRangeMarkup(selectedDays []int, lang string) *tgbotapi.InlineKeyboardMarkup {
+// GetMonthRangeMarkup renders a 4x7 grid for days 1..28 with multi-select support,
+// followed by the days not every month has, the last two days, the month end clamp toggle
+// and the switch to a weekday of the month.
+func GetMonthRangeMarkup(selectedDays []int, clamp bool, lang string) *tgbotapi.InlineKeyboardMarkup {
 	var inlineKeyboard [][]tgbotapi.InlineKeyboardButton
 	s := T(lang)
 
@@ -47,6 +53,23 @@ func GetMonthRangeMarkup(selectedDays []int, lang string) *tgbotapi.InlineKeyboa
 		inlineKeyboard = append(inlineKeyboard, row)
 	}
 
+	var monthEndRow []tgbotapi.InlineKeyboardButton
+	for _, day := range []int{29, 30, 31, -1, -2} {
+		label := FormatMonthDay(day, lang)
+		if day == -1 {
+			label = s.BtnLastDay
+		} else if day == -2 {
+			label = s.BtnSecondToLastDay
+		}
+		callback := fmt.Sprintf("%s%d", CallbackMonthDay, day)
+		monthEndRow = append(monthEndRow, tgbotapi.NewInlineKeyboardButtonData(buttonText(label, slices.Contains(selectedDays, day)), callback))
+	}
+	inlineKeyboard = append(inlineKeyboard, monthEndRow)
+	inlineKeyboard = append(inlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(buttonText(s.BtnClampToMonthEnd, clamp), CallbackMonthClamp)))
+	inlineKeyboard = append(inlineKeyboard, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(s.BtnByWeekday, CallbackNthWeekdayStart)))
+
 	inlineKeyboard = append(inlineKeyboard, tgbotapi.NewInlineKeyboardRow(
 		tgbotapi.NewInlineKeyboardButtonData(s.BtnSelect, CallbackMonthSelect)))
 	inlineKeyboard = append(inlineKeyboard, tgbotapi.NewInlineKeyboardRow(
@@ -55,12 +78,16 @@ func GetMonthRangeMarkup(selectedDays []int, lang string) *tgbotapi.InlineKeyboa
 	return &tgbotapi.InlineKeyboardMarkup{InlineKeyboard: inlineKeyboard}
 }
 
-// HandleMonthSelection toggles a selected day and returns the updated view or proceeds on select.
-func HandleMonthSelection(callbackData string, selectedDays *[]int, lang string) *SelectionResult {
+// HandleMonthSelection toggles a selected day or the month end clamp and returns the updated view
+// or proceeds on select.
+func HandleMonthSelection(callbackData string, selectedDays *[]int, clamp *bool, lang string) *SelectionResult {
+	if callbackData == CallbackMonthClamp {
+		*clamp = !*clamp
+	}
 	if stringsHasPrefix(callbackData, CallbackMonthDay) {
 		var day int
 		_, _ = fmt.Sscanf(callbackData[len(CallbackMonthDay):], "%d", &day)
-		if day >= 1 && day <= 28 {
+		if entities.IsValidDayOfMonth(day) {
 			// Check if the day is already selected
 			found := false
 			for i, selectedDay := range *selectedDays {
@@ -81,5 +108,31 @@ func HandleMonthSelection(callbackData string, selectedDays *[]int, lang string)
 	if callbackData == CallbackMonthSelect {
 		return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(lang)}
 	}
-	return &SelectionResult{Text: s.MsgSelectDate, Markup: GetMonthRangeMarkup(*selectedDays, lang)}
+	return &SelectionResult{Text: s.MsgSelectDate, Markup: GetMonthRangeMarkup(*selectedDays, *clamp, lang)}
+}
+
+// FormatMonthDay renders a day-of-month setting, e.g. "15", "last" or "1 before last".
+func FormatMonthDay(day int, lang string) string {
+	s := T(lang)
+	switch {
+	case day == -1:
+		return s.MonthDayLast
+	case day < -1:
+		return fmt.Sprintf(s.MonthDayBeforeLast, -day-1)
+	default:
+		return fmt.Sprintf("%d", day)
+	}
+}
+
+// FormatMonthDays renders the days of a monthly reminder, noting when short months use their last day.
+func FormatMonthDays(days []int, clamp bool, lang string) string {
+	var labels []string
+	for _, day := range days {
+		labels = append(labels, FormatMonthDay(day, lang))
+	}
+	text := strings.Join(labels, ", ")
+	if clamp {
+		text += " (" + T(lang).MsgClampedToMonthEnd + ")"
+	}
+	return text
 }
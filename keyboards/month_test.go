@@ -16,10 +16,10 @@ func TestIsMonthSelectionCallback(t *testing.T) {
 
 func TestGetMonthRangeMarkup(t *testing.T) {
 	var opts []int
-	m := GetMonthRangeMarkup(opts, LangEN)
-	// 4 day rows + select + back
-	if len(m.InlineKeyboard) != 6 {
-		t.Fatalf("expected 6 rows, got %d", len(m.InlineKeyboard))
+	m := GetMonthRangeMarkup(opts, false, LangEN)
+	// 4 day rows + month end + clamp + by weekday + select + back
+	if len(m.InlineKeyboard) != 9 {
+		t.Fatalf("expected 9 rows, got %d", len(m.InlineKeyboard))
 	}
 	for i := 0; i < 4; i++ {
 		if len(m.InlineKeyboard[i]) != 7 {
@@ -30,19 +30,44 @@ func TestGetMonthRangeMarkup(t *testing.T) {
 
 func TestHandleMonthSelection_ToggleAndSelect(t *testing.T) {
 	opts := []int{}
+	clamp := false
 
 	// Toggle day 1
-	res := HandleMonthSelection(CallbackMonthDay+"1", &opts, LangEN)
+	res := HandleMonthSelection(CallbackMonthDay+"1", &opts, &clamp, LangEN)
 	if res == nil || opts[0] != 1 {
 		t.Fatalf("day 1 should be toggled on")
 	}
-	if res.Markup == nil || len(res.Markup.InlineKeyboard) != 6 {
-		t.Fatalf("expected 6 rows after toggle")
+	if res.Markup == nil || len(res.Markup.InlineKeyboard) != 9 {
+		t.Fatalf("expected 9 rows after toggle")
 	}
 
 	// Confirm selection
-	res = HandleMonthSelection(CallbackMonthSelect, &opts, LangEN)
+	res = HandleMonthSelection(CallbackMonthSelect, &opts, &clamp, LangEN)
 	if res == nil || res.Markup == nil {
 		t.Fatalf("expected markup on select")
 	}
 }
+
+func TestHandleMonthSelection_MonthEnd(t *testing.T) {
+	opts := []int{}
+	clamp := false
+
+	HandleMonthSelection(CallbackMonthDay+"31", &opts, &clamp, LangEN)
+	HandleMonthSelection(CallbackMonthDay+"-1", &opts, &clamp, LangEN)
+	res := HandleMonthSelection(CallbackMonthClamp, &opts, &clamp, LangEN)
+	if len(opts) != 2 || opts[0] != 31 || opts[1] != -1 || !clamp {
+		t.Fatalf("expected days 31 and last with clamping, got %v (clamp %v)", opts, clamp)
+	}
+	if got := res.Markup.InlineKeyboard[4][3].Text; got != buttonText(T(LangEN).BtnLastDay, true) {
+		t.Fatalf("expected the last day button to be selected, got %q", got)
+	}
+
+	HandleMonthSelection(CallbackMonthDay+"0", &opts, &clamp, LangEN)
+	if len(opts) != 2 {
+		t.Fatalf("day 0 should be ignored, got %v", opts)
+	}
+
+	if got := FormatMonthDays([]int{15, -1, -2}, false, LangEN); got != "15, last, 1 before last" {
+		t.Fatalf("unexpected days label %q", got)
+	}
+}
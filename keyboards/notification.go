@@ -0,0 +1,170 @@
+package keyboards
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// Callback data for actions attached to delivered reminder notifications.
+// Format: "<prefix><reminderID>" for done and "<prefix><reminderID>:<option>" for snooze and grades.
+const (
+	CallbackNotificationPrefix = "ntf_"
+	CallbackNotifySnoozePrefix = "ntf_snooze:"
+	CallbackNotifyDonePrefix   = "ntf_done:"
+	CallbackNotifyGradePrefix  = "ntf_grade:"
+
+	SnoozeOption10Min    = "10m"
+	SnoozeOption1Hour    = "1h"
+	SnoozeOptionTomorrow = "tomorrow"
+)
+
+// NotificationAction describes a parsed notification callback
+type NotificationAction struct {
+	ReminderID int64
+	Done       bool
+	Snooze     string
+	Grade      entities.RecallGrade
+}
+
+func IsNotificationCallback(callbackData string) bool {
+	return strings.HasPrefix(callbackData, CallbackNotificationPrefix)
+}
+
+// GetNotificationMarkup returns the inline keyboard attached to every delivered reminder
+func GetNotificationMarkup(reminderID int64, lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	snooze := func(label, option string) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%d:%s", CallbackNotifySnoozePrefix, reminderID, option))
+	}
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			snooze(s.BtnSnooze10Min, SnoozeOption10Min),
+			snooze(s.BtnSnooze1Hour, SnoozeOption1Hour),
+			snooze(s.BtnSnoozeTomorrow, SnoozeOptionTomorrow),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnDone, fmt.Sprintf("%s%d", CallbackNotifyDonePrefix, reminderID)),
+		),
+	)
+	return &markup
+}
+
+// GetRecallNotificationMarkup replaces done with recall grades on adaptive spaced repetition reviews
+func GetRecallNotificationMarkup(reminderID int64, lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	markup := GetNotificationMarkup(reminderID, lang)
+
+	var grades []tgbotapi.InlineKeyboardButton
+	for _, grade := range entities.RecallGrades {
+		grades = append(grades, tgbotapi.NewInlineKeyboardButtonData(s.RecallGrades[grade], fmt.Sprintf("%s%d:%s", CallbackNotifyGradePrefix, reminderID, grade)))
+	}
+	markup.InlineKeyboard[len(markup.InlineKeyboard)-1] = grades
+	return markup
+}
+
+// ParseNotificationCallback extracts the reminder ID and the requested action
+func ParseNotificationCallback(callbackData string) (*NotificationAction, bool) {
+	if after, ok := strings.CutPrefix(callbackData, CallbackNotifyDonePrefix); ok {
+		id, err := strconv.ParseInt(after, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		return &NotificationAction{ReminderID: id, Done: true}, true
+	}
+
+	if after, ok := strings.CutPrefix(callbackData, CallbackNotifyGradePrefix); ok {
+		idStr, option, _ := strings.Cut(after, ":")
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		grade, err := entities.ToRecallGrade(option)
+		if err != nil {
+			return nil, false
+		}
+		return &NotificationAction{ReminderID: id, Grade: grade}, true
+	}
+
+	if after, ok := strings.CutPrefix(callbackData, CallbackNotifySnoozePrefix); ok {
+		idStr, option, found := strings.Cut(after, ":")
+		if !found {
+			return nil, false
+		}
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		return &NotificationAction{ReminderID: id, Snooze: option}, true
+	}
+
+	return nil, false
+}
+
+// SnoozeUntil resolves a snooze option to an absolute time.
+// "tomorrow" keeps the current wall-clock time in the user's location.
+func SnoozeUntil(option string, now time.Time, location *time.Location) (time.Time, bool) {
+	if location == nil {
+		location = time.UTC
+	}
+	switch option {
+	case SnoozeOption10Min:
+		return now.Add(10 * time.Minute), true
+	case SnoozeOption1Hour:
+		return now.Add(time.Hour), true
+	case SnoozeOptionTomorrow:
+		return now.In(location).AddDate(0, 0, 1), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// FormatNotificationText renders the text of a delivered reminder
+func FormatNotificationText(message string) string {
+	return fmt.Sprintf("🔔 %s", message)
+}
+
+// FormatLateNotificationText renders a reminder delivered after its scheduled time,
+// noting when it was originally due
+func FormatLateNotificationText(message string, scheduledAt time.Time, location *time.Location, lang string) string {
+	if location == nil {
+		location = time.UTC
+	}
+	s := T(lang)
+	return FormatNotificationText(message) + "\n\n" + fmt.Sprintf(s.MsgLateDelivery, scheduledAt.In(location).Format("2006-01-02 15:04"))
+}
+
+// FormatSnoozedNotification replaces the notification keyboard with a snooze confirmation
+func FormatSnoozedNotification(originalText string, until time.Time, location *time.Location, lang string) *SelectionResult {
+	if location == nil {
+		location = time.UTC
+	}
+	s := T(lang)
+	text := originalText + "\n\n" + fmt.Sprintf(s.MsgSnoozedUntil, until.In(location).Format("2006-01-02 15:04"))
+	return &SelectionResult{Text: text, Markup: nil}
+}
+
+// FormatDoneNotification replaces the notification keyboard with a done confirmation
+func FormatDoneNotification(originalText string, lang string) *SelectionResult {
+	s := T(lang)
+	return &SelectionResult{Text: originalText + "\n\n" + s.MsgReminderDone, Markup: nil}
+}
+
+// FormatGradedNotification replaces the notification keyboard with the next review,
+// or with a note that the item is learned when there is none
+func FormatGradedNotification(originalText string, next *time.Time, location *time.Location, lang string) *SelectionResult {
+	if location == nil {
+		location = time.UTC
+	}
+	s := T(lang)
+	if next == nil {
+		return &SelectionResult{Text: originalText + "\n\n" + s.MsgRecallLearned, Markup: nil}
+	}
+	text := originalText + "\n\n" + fmt.Sprintf(s.MsgNextReview, next.In(location).Format("2006-01-02 15:04"))
+	return &SelectionResult{Text: text, Markup: nil}
+}
@@ -0,0 +1,86 @@
+package keyboards
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsNotificationCallback(t *testing.T) {
+	if !IsNotificationCallback(CallbackNotifyDonePrefix + "1") {
+		t.Fatalf("done callback should be recognized")
+	}
+	if !IsNotificationCallback(CallbackNotifySnoozePrefix + "1:" + SnoozeOption10Min) {
+		t.Fatalf("snooze callback should be recognized")
+	}
+	if IsNotificationCallback("x") {
+		t.Fatalf("unexpected recognition")
+	}
+}
+
+func TestParseNotificationCallback(t *testing.T) {
+	action, ok := ParseNotificationCallback(CallbackNotifyDonePrefix + "42")
+	if !ok || action.ReminderID != 42 || !action.Done {
+		t.Fatalf("unexpected done parse result: %+v, %v", action, ok)
+	}
+
+	action, ok = ParseNotificationCallback(CallbackNotifySnoozePrefix + "7:" + SnoozeOption1Hour)
+	if !ok || action.ReminderID != 7 || action.Done || action.Snooze != SnoozeOption1Hour {
+		t.Fatalf("unexpected snooze parse result: %+v, %v", action, ok)
+	}
+
+	for _, data := range []string{CallbackNotifyDonePrefix + "abc", CallbackNotifySnoozePrefix + "7", CallbackNotifySnoozePrefix + "x:1h", "x"} {
+		if _, ok := ParseNotificationCallback(data); ok {
+			t.Fatalf("expected %q to be rejected", data)
+		}
+	}
+}
+
+func TestGetNotificationMarkup(t *testing.T) {
+	markup := GetNotificationMarkup(5, LangEN)
+	if len(markup.InlineKeyboard) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(markup.InlineKeyboard))
+	}
+	for _, row := range markup.InlineKeyboard {
+		for _, btn := range row {
+			action, ok := ParseNotificationCallback(*btn.CallbackData)
+			if !ok || action.ReminderID != 5 {
+				t.Fatalf("button %q has unparsable callback %q", btn.Text, *btn.CallbackData)
+			}
+		}
+	}
+}
+
+func TestSnoozeUntil(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Kyiv")
+	now := time.Date(2025, 3, 10, 21, 30, 0, 0, time.UTC)
+
+	if got, ok := SnoozeUntil(SnoozeOption10Min, now, loc); !ok || !got.Equal(now.Add(10*time.Minute)) {
+		t.Fatalf("10m snooze = %v, %v", got, ok)
+	}
+	if got, ok := SnoozeUntil(SnoozeOption1Hour, now, loc); !ok || !got.Equal(now.Add(time.Hour)) {
+		t.Fatalf("1h snooze = %v, %v", got, ok)
+	}
+	got, ok := SnoozeUntil(SnoozeOptionTomorrow, now, loc)
+	if !ok {
+		t.Fatalf("tomorrow snooze should be valid")
+	}
+	local := got.In(loc)
+	if local.Day() != 11 || local.Hour() != now.In(loc).Hour() || local.Minute() != 30 {
+		t.Fatalf("expected same wall-clock time tomorrow, got %v", local)
+	}
+	if _, ok := SnoozeUntil("bogus", now, loc); ok {
+		t.Fatalf("unknown option should be rejected")
+	}
+}
+
+func TestFormatSnoozedNotification(t *testing.T) {
+	until := time.Date(2025, 3, 10, 9, 5, 0, 0, time.UTC)
+	res := FormatSnoozedNotification("🔔 ping", until, time.UTC, LangEN)
+	if res.Markup != nil {
+		t.Fatalf("expected keyboard to be removed")
+	}
+	if !strings.HasPrefix(res.Text, "🔔 ping") || !strings.Contains(res.Text, "2025-03-10 09:05") {
+		t.Fatalf("unexpected text: %q", res.Text)
+	}
+}
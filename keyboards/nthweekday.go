@@ -0,0 +1,97 @@
+package keyboards
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+const (
+	// Represents choosing a weekday of the month instead of days of the month.
+	CallbackNthWeekdayStart = "nth_start"
+	// Represents the selection of an ordinal, e.g. "nth_ordinal:-1" for the last one.
+	CallbackPrefixNthOrdinal = "nth_ordinal:"
+	// Represents the selection of an ordinal weekday, e.g. "nth_weekday:1:1" for the first Monday.
+	CallbackPrefixNthWeekday = "nth_weekday:"
+)
+
+// nthOrdinals are the ordinals offered in the picker, in display order
+var nthOrdinals = []int{1, 2, 3, 4, entities.OrdinalLast}
+
+// nthWeekdays lists the weekdays from Monday to Sunday
+var nthWeekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+func IsNthWeekdayCallback(callbackData string) bool {
+	return strings.HasPrefix(callbackData, "nth_")
+}
+
+// GetNthOrdinalMarkup offers the first to fourth and last weekday of the month.
+func GetNthOrdinalMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	var row []tgbotapi.InlineKeyboardButton
+	for _, ordinal := range nthOrdinals {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(s.BtnOrdinals[ordinal], CallbackPrefixNthOrdinal+strconv.Itoa(ordinal)))
+	}
+
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, entities.Monthly.String())),
+	)
+	return &menu
+}
+
+// GetNthWeekdayMarkup lists the weekdays for the chosen ordinal.
+func GetNthWeekdayMarkup(ordinal int, lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	var row []tgbotapi.InlineKeyboardButton
+	for _, weekday := range nthWeekdays {
+		callback := fmt.Sprintf("%s%d:%d", CallbackPrefixNthWeekday, ordinal, weekday)
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(s.WeekdayNamesShort[weekday], callback))
+	}
+
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, CallbackNthWeekdayStart)),
+	)
+	return &menu
+}
+
+// HandleNthWeekdaySelection walks through picking an ordinal and a weekday, then asks for the time.
+func HandleNthWeekdaySelection(callbackData string, user *entities.User, userSelection *entities.UserSelection) *SelectionResult {
+	s := T(user.Language)
+	switch {
+	case strings.HasPrefix(callbackData, CallbackPrefixNthOrdinal):
+		ordinal, err := strconv.Atoi(callbackData[len(CallbackPrefixNthOrdinal):])
+		if err == nil && (entities.NthWeekday{Ordinal: ordinal}).IsValid() {
+			return &SelectionResult{Text: s.MsgSelectNthWeekday, Markup: GetNthWeekdayMarkup(ordinal, user.Language)}
+		}
+
+	case strings.HasPrefix(callbackData, CallbackPrefixNthWeekday):
+		var ordinal, weekday int
+		_, err := fmt.Sscanf(callbackData[len(CallbackPrefixNthWeekday):], "%d:%d", &ordinal, &weekday)
+		nthWeekday := entities.NthWeekday{Ordinal: ordinal, Weekday: time.Weekday(weekday)}
+		if err == nil && nthWeekday.IsValid() {
+			userSelection.SetNthWeekday(nthWeekday)
+			if userSelection.IsEditing() {
+				return EditReminderResult(userSelection, user.Language)
+			}
+			return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(user.Language)}
+		}
+	}
+
+	return &SelectionResult{Text: s.MsgSelectNthOrdinal, Markup: GetNthOrdinalMarkup(user.Language)}
+}
+
+// FormatNthWeekdays renders weekdays of the month, e.g. "first Monday" or "last Thursday".
+func FormatNthWeekdays(nthWeekdays []entities.NthWeekday, lang string) string {
+	s := T(lang)
+	var labels []string
+	for _, n := range nthWeekdays {
+		labels = append(labels, fmt.Sprintf(s.NthWeekday, s.Ordinals[n.Ordinal], s.WeekdayNames[n.Weekday]))
+	}
+	return strings.Join(labels, ", ")
+}
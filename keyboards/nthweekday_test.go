@@ -0,0 +1,55 @@
+package keyboards
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestHandleNthWeekdaySelection(t *testing.T) {
+	user := &entities.User{Language: LangEN, Location: time.UTC}
+	sel := entities.NewUserSelection()
+	sel.RecurrenceType = entities.Monthly
+	sel.MonthOptions = []int{1, 15}
+
+	if got := GetKeyboardType(CallbackNthWeekdayStart); got != NthWeekday {
+		t.Fatalf("GetKeyboardType(nth start) = %v, want %v", got, NthWeekday)
+	}
+
+	result := HandleNthWeekdaySelection(CallbackNthWeekdayStart, user, sel)
+	if result.Text != T(LangEN).MsgSelectNthOrdinal || len(result.Markup.InlineKeyboard[0]) != 5 {
+		t.Fatalf("expected the ordinal picker, got %q", result.Text)
+	}
+
+	result = HandleNthWeekdaySelection(CallbackPrefixNthOrdinal+"-1", user, sel)
+	if result.Text != T(LangEN).MsgSelectNthWeekday || len(result.Markup.InlineKeyboard[0]) != 7 {
+		t.Fatalf("expected the weekday picker, got %q", result.Text)
+	}
+
+	result = HandleNthWeekdaySelection(CallbackPrefixNthWeekday+"-1:4", user, sel)
+	if result.Text != T(LangEN).MsgSelectTime {
+		t.Fatalf("expected the time picker, got %q", result.Text)
+	}
+	if len(sel.NthWeekdays) != 1 || sel.NthWeekdays[0].Ordinal != entities.OrdinalLast || sel.NthWeekdays[0].Weekday != time.Thursday {
+		t.Fatalf("expected the last Thursday, got %v", sel.NthWeekdays)
+	}
+	if len(sel.MonthOptions) != 0 {
+		t.Fatalf("expected days of month to be cleared, got %v", sel.MonthOptions)
+	}
+
+	result = HandleNthWeekdaySelection(CallbackPrefixNthOrdinal+"5", user, sel)
+	if result.Text != T(LangEN).MsgSelectNthOrdinal {
+		t.Fatalf("expected the ordinal picker again for a fifth weekday")
+	}
+}
+
+func TestFormatNthWeekdays(t *testing.T) {
+	nth := []entities.NthWeekday{{Ordinal: 1, Weekday: time.Monday}, {Ordinal: entities.OrdinalLast, Weekday: time.Thursday}}
+	if got := FormatNthWeekdays(nth, LangEN); got != "first Monday, last Thursday" {
+		t.Fatalf("unexpected English label %q", got)
+	}
+	if got := FormatNthWeekdays(nth[:1], LangUK); got != "Понеділок 1-го тижня" {
+		t.Fatalf("unexpected Ukrainian label %q", got)
+	}
+}
@@ -0,0 +1,88 @@
+package keyboards
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/scheduler"
+)
+
+// The callback data of pausing all reminders of a user (vacation mode), opened from the reminders list
+const (
+	CallbackPauseAll           = "rem_pauseall"
+	CallbackPrefixPauseAllDays = "rem_pauseall:"
+	CallbackPauseAllDate       = "rem_pauseall_date"
+	CallbackResumeAll          = "rem_resumeall"
+)
+
+// Preset pause lengths in days
+var pauseAllPresets = []int{3, 7, 14, 30}
+
+func IsPauseAllCallback(callbackData string) bool {
+	return strings.HasPrefix(callbackData, CallbackPauseAll) || callbackData == CallbackResumeAll
+}
+
+// GetPauseAllMarkup offers how long to pause all reminders for
+func GetPauseAllMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	var presets []tgbotapi.InlineKeyboardButton
+	for _, days := range pauseAllPresets {
+		presets = append(presets, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(s.BtnPauseDays, days), fmt.Sprintf("%s%d", CallbackPrefixPauseAllDays, days)))
+	}
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		presets,
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnPauseUntilDate, CallbackPauseAllDate)),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, CallbackRemindersList)),
+	)
+	return &menu
+}
+
+// ParsePauseAllDays returns the number of days of a preset pause
+func ParsePauseAllDays(callbackData string) (int, bool) {
+	if !strings.HasPrefix(callbackData, CallbackPrefixPauseAllDays) {
+		return 0, false
+	}
+	days, err := strconv.Atoi(callbackData[len(CallbackPrefixPauseAllDays):])
+	if err != nil || days <= 0 {
+		return 0, false
+	}
+	return days, true
+}
+
+// PauseUntilDays returns the start of the day the given number of days after now, in the user's
+// location, so reminders resume on that day
+func PauseUntilDays(days int, now time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	day := now.In(loc)
+	return scheduler.LocalTime(day.Year(), day.Month(), day.Day()+days, 0, 0, loc)
+}
+
+// ParsePauseDate reads the date to resume reminders on, e.g. "2026-08-31", as the start of that
+// day in the user's location. The date must be in the future.
+func ParsePauseDate(text string, now time.Time, loc *time.Location) (time.Time, bool) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	date, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(text), loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	until := scheduler.LocalTime(date.Year(), date.Month(), date.Day(), 0, 0, loc)
+	if !until.After(now) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// FormatPausedUntil renders the day reminders resume on
+func FormatPausedUntil(until time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return until.In(loc).Format("2006-01-02")
+}
@@ -0,0 +1,96 @@
+package keyboards
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestGetRemindersListMarkup_PauseToggle(t *testing.T) {
+	user := &entities.User{Language: LangEN, Location: time.UTC}
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	active := *entities.NewReminder(1, 1, "Stand-up", entities.DailyAt(start, time.UTC), nil)
+	paused := *entities.NewReminder(2, 1, "Gym", entities.DailyAt(start, time.UTC), nil)
+	paused.Pause()
+	ended := *entities.NewReminder(3, 1, "Call", entities.OnceAt(start, time.UTC), nil)
+	ended.Deactivate()
+
+	markup := GetRemindersListMarkup(NewRemindersPage([]entities.Reminder{active, paused, ended}, RemindersFilterAll, 0), user)
+	actions := func(i int) string {
+		var data []string
+		for _, b := range markup.InlineKeyboard[2*i+1] {
+			data = append(data, *b.CallbackData)
+		}
+		return strings.Join(data, " ")
+	}
+	if !strings.Contains(actions(0), CallbackReminderPausePrefix+"1") {
+		t.Fatalf("expected a pause button for an active reminder, got %s", actions(0))
+	}
+	if !strings.Contains(actions(1), CallbackReminderResumePrefix+"2") {
+		t.Fatalf("expected a resume button for a paused reminder, got %s", actions(1))
+	}
+	if strings.Contains(actions(2), "rem_pause:") || strings.Contains(actions(2), "rem_resume:") {
+		t.Fatalf("expected no pause button for an ended reminder, got %s", actions(2))
+	}
+	if label := markup.InlineKeyboard[2][0].Text; !strings.HasPrefix(label, "⏸") {
+		t.Fatalf("expected the paused status in the label, got %q", label)
+	}
+
+	last := markup.InlineKeyboard[len(markup.InlineKeyboard)-1]
+	if *last[0].CallbackData != CallbackPauseAll {
+		t.Fatalf("expected pause all, got %s", *last[0].CallbackData)
+	}
+	until := time.Now().Add(48 * time.Hour)
+	user.PauseUntil(until)
+	markup = GetRemindersListMarkup(NewRemindersPage([]entities.Reminder{active}, RemindersFilterAll, 0), user)
+	last = markup.InlineKeyboard[len(markup.InlineKeyboard)-1]
+	if *last[0].CallbackData != CallbackResumeAll {
+		t.Fatalf("expected resume all while paused, got %s", *last[0].CallbackData)
+	}
+	if text := FormatRemindersListText(NewRemindersPage([]entities.Reminder{active}, RemindersFilterAll, 0), user); !strings.Contains(text, until.UTC().Format("2006-01-02")) {
+		t.Fatalf("expected the pause in the list text, got %q", text)
+	}
+
+	for _, data := range []string{CallbackReminderPausePrefix + "1", CallbackReminderResumePrefix + "1", CallbackPauseAll, CallbackPrefixPauseAllDays + "7", CallbackPauseAllDate, CallbackResumeAll} {
+		if got := GetKeyboardType(data); got != Reminders {
+			t.Fatalf("GetKeyboardType(%s) = %v, want %v", data, got, Reminders)
+		}
+	}
+}
+
+func TestPauseUntil(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	now := time.Date(2026, 8, 10, 22, 30, 0, 0, time.UTC) // already the 11th in Kyiv
+
+	if days, ok := ParsePauseAllDays(CallbackPrefixPauseAllDays + "7"); !ok || days != 7 {
+		t.Fatalf("expected 7 days, got %d", days)
+	}
+	if _, ok := ParsePauseAllDays(CallbackPrefixPauseAllDays + "0"); ok {
+		t.Fatalf("expected a zero-day pause to be rejected")
+	}
+	if got, want := PauseUntilDays(7, now, kyiv), time.Date(2026, 8, 18, 0, 0, 0, 0, kyiv); !got.Equal(want) {
+		t.Fatalf("PauseUntilDays() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		text string
+		want time.Time
+		ok   bool
+	}{
+		{"2026-08-31", time.Date(2026, 8, 31, 0, 0, 0, 0, kyiv), true},
+		{" 2026-08-12 ", time.Date(2026, 8, 12, 0, 0, 0, 0, kyiv), true},
+		{"2026-08-11", time.Time{}, false}, // started already
+		{"31.08.2026", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParsePauseDate(tt.text, now, kyiv)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Fatalf("ParsePauseDate(%q) = %v, %v, want %v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
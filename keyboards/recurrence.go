@@ -16,18 +16,18 @@ func HandleRecurrenceTypeSelection(callbackData string,
 
 	s := T(user.Language)
 	switch recurrenceType {
-	case entities.Once:
+	case entities.Once, entities.Yearly:
 		return &SelectionResult{Text: s.MsgSelectDate, Markup: nil}, nil
 	case entities.Daily:
 		return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(user.Language)}, nil
 	case entities.Weekly:
 		return &SelectionResult{Text: s.MsgSelectWeekdays, Markup: GetWeekRangeMarkup(userSelection.WeekOptions, user.Language)}, nil
 	case entities.Monthly:
-		return &SelectionResult{Text: s.MsgSelectDate, Markup: GetMonthRangeMarkup(userSelection.MonthOptions, user.Language)}, nil
+		return &SelectionResult{Text: s.MsgSelectDate, Markup: GetMonthRangeMarkup(userSelection.MonthOptions, userSelection.ClampToMonthEnd, user.Language)}, nil
 	case entities.Interval:
-		return &SelectionResult{Text: s.MsgIntervalPrompt, Markup: GetIntervalPrompt(userSelection, user.Language)}, nil
+		return &SelectionResult{Text: s.MsgSelectIntervalUnit, Markup: GetIntervalPrompt(userSelection, user.Language)}, nil
 	case entities.SpacedBasedRepetition:
-		return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(user.Language)}, nil
+		return &SelectionResult{Text: s.MsgSelectSpacedMode, Markup: GetSpacedRepetitionMarkup(user.Language)}, nil
 	}
 
 	return nil, nil
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
@@ -12,16 +13,34 @@ import (
 const (
 	CallbackRemindersList        = "rem_list"
 	CallbackReminderDeletePrefix = "rem_del:"
+	CallbackReminderNagPrefix    = "rem_nag:"
+	CallbackReminderHistPrefix   = "rem_hist:"
+	CallbackReminderEditPrefix   = "rem_edit:"
+	CallbackReminderPausePrefix  = "rem_pause:"
+	CallbackReminderResumePrefix = "rem_resume:"
+	CallbackReminderCritPrefix   = "rem_crit:"
 )
 
 func IsRemindersCallback(callbackData string) bool {
-	return callbackData == CallbackRemindersList || strings.HasPrefix(callbackData, CallbackReminderDeletePrefix)
+	return callbackData == CallbackRemindersList ||
+		strings.HasPrefix(callbackData, CallbackReminderDeletePrefix) ||
+		strings.HasPrefix(callbackData, CallbackReminderNagPrefix) ||
+		strings.HasPrefix(callbackData, CallbackReminderHistPrefix) ||
+		strings.HasPrefix(callbackData, CallbackReminderEditPrefix) ||
+		strings.HasPrefix(callbackData, CallbackReminderPausePrefix) ||
+		strings.HasPrefix(callbackData, CallbackReminderResumePrefix) ||
+		strings.HasPrefix(callbackData, CallbackReminderCritPrefix) ||
+		IsPauseAllCallback(callbackData) ||
+		strings.HasPrefix(callbackData, CallbackPrefixRemindersPage) ||
+		strings.HasPrefix(callbackData, CallbackRemindersFilters) ||
+		strings.HasPrefix(callbackData, CallbackPrefixRemindersFilter)
 }
 
-func GetRemindersListMarkup(reminders []entities.Reminder, lang string) *tgbotapi.InlineKeyboardMarkup {
+func GetRemindersListMarkup(page *RemindersPage, user *entities.User) *tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
+	lang := user.Language
 	s := T(lang)
-	if len(reminders) == 0 {
+	if page.Total == 0 {
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, CallbackBackToMainMenu),
 		))
@@ -29,21 +48,53 @@ func GetRemindersListMarkup(reminders []entities.Reminder, lang string) *tgbotap
 		return &menu
 	}
 
-	for _, r := range reminders {
-		label := formatLabel(r, lang, false)
-		btn := tgbotapi.NewInlineKeyboardButtonData(
-			s.BtnDelete,
-			fmt.Sprintf("%s%d", CallbackReminderDeletePrefix, r.ID),
+	for _, r := range page.Reminders {
+		label := formatLabel(r, user, false)
+		nagLabel := s.BtnNagOff
+		if r.IsNagging() {
+			nagLabel = s.BtnNagOn
+		}
+		actions := tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnHistory, fmt.Sprintf("%s%d", CallbackReminderHistPrefix, r.ID)),
+			tgbotapi.NewInlineKeyboardButtonData(nagLabel, fmt.Sprintf("%s%d", CallbackReminderNagPrefix, r.ID)),
 		)
+		// Ended reminders have nothing to pause
+		if r.Paused {
+			actions = append(actions, tgbotapi.NewInlineKeyboardButtonData(s.BtnResume, fmt.Sprintf("%s%d", CallbackReminderResumePrefix, r.ID)))
+		} else if r.IsActive {
+			actions = append(actions, tgbotapi.NewInlineKeyboardButtonData(s.BtnPause, fmt.Sprintf("%s%d", CallbackReminderPausePrefix, r.ID)))
+		}
+		// With a daily digest, critical reminders still notify on their own; reviews always do
+		if user.Digest != nil && r.Recurrence != nil && r.Recurrence.Type != entities.SpacedBasedRepetition {
+			critLabel := s.BtnCriticalOff
+			if r.Critical {
+				critLabel = s.BtnCriticalOn
+			}
+			actions = append(actions, tgbotapi.NewInlineKeyboardButtonData(critLabel, fmt.Sprintf("%s%d", CallbackReminderCritPrefix, r.ID)))
+		}
+		actions = append(actions, tgbotapi.NewInlineKeyboardButtonData(s.BtnDelete, fmt.Sprintf("%s%d", CallbackReminderDeletePrefix, r.ID)))
 		rows = append(rows,
 			tgbotapi.NewInlineKeyboardRow(
-				tgbotapi.NewInlineKeyboardButtonData(label, "noop"),
-				btn,
+				// Tapping the reminder opens its editor
+				tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("%s%d", CallbackReminderEditPrefix, r.ID)),
 			),
+			actions,
 		)
 	}
 
+	if paging := getRemindersPagingRow(page); paging != nil {
+		rows = append(rows, paging)
+	}
 	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(s.BtnFilter, RemindersFilterLabel(page.Filter, lang)), CallbackRemindersFilters),
+	))
+
+	pauseAll := tgbotapi.NewInlineKeyboardButtonData(s.BtnPauseAll, CallbackPauseAll)
+	if user.IsPaused(time.Now()) {
+		pauseAll = tgbotapi.NewInlineKeyboardButtonData(s.BtnResumeAll, CallbackResumeAll)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		pauseAll,
 		tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, CallbackBackToMainMenu),
 	))
 
@@ -51,32 +102,52 @@ func GetRemindersListMarkup(reminders []entities.Reminder, lang string) *tgbotap
 	return &menu
 }
 
-func FormatRemindersListText(reminders []entities.Reminder, lang string) string {
+func FormatRemindersListText(page *RemindersPage, user *entities.User) string {
+	lang := user.Language
 	s := T(lang)
-	if len(reminders) == 0 {
+	if page.Total == 0 {
 		return s.NoReminders
 	}
 	var b strings.Builder
+	if user.IsPaused(time.Now()) {
+		b.WriteString(fmt.Sprintf(s.MsgAllPaused, FormatPausedUntil(*user.PausedUntil, user.GetLocation())))
+	}
 	b.WriteString(s.YourReminders)
-	for _, r := range reminders {
-		label := formatLabel(r, lang, true)
+	if page.Filter != RemindersFilterAll {
+		b.WriteString(fmt.Sprintf(s.MsgRemindersFilter, RemindersFilterLabel(page.Filter, lang), page.Matching, page.Total))
+	}
+	if page.Matching == 0 {
+		b.WriteString(s.MsgNoMatchingReminders)
+		return b.String()
+	}
+	for _, r := range page.Reminders {
+		label := formatLabel(r, user, true)
 		b.WriteString(fmt.Sprintf("%s\n", label))
 	}
+	if page.Pages > 1 {
+		b.WriteString(fmt.Sprintf(s.MsgRemindersPage, page.Page+1, page.Pages))
+	}
 	return b.String()
 }
 
-func formatLabel(reminder entities.Reminder, lang string, includeMessage bool) string {
+// Longest reminder text shown in the list
+const maxListMessageRunes = 100
+
+func formatLabel(reminder entities.Reminder, user *entities.User, includeMessage bool) string {
+	lang := user.Language
 	s := T(lang)
 
 	// Add indicator for active/inactive status
 	status := "❌" // inactive by default
 	if reminder.IsActive {
 		status = "✅" // active
+	} else if reminder.Paused {
+		status = "⏸" // paused by the user
 	}
 
 	var label string
 	recurrenceType := RecurrenceTypeLabel(lang, reminder.Recurrence.Type)
-	reminderTime := reminder.Recurrence.GetTimeOfDay()
+	reminderTime := strings.Join(reminder.Recurrence.GetTimesOfDay(), ", ")
 
 	switch reminder.Recurrence.Type {
 	case entities.Once:
@@ -94,41 +165,88 @@ func formatLabel(reminder entities.Reminder, lang string, includeMessage bool) s
 		}
 		reminderTime = fmt.Sprintf("%s • %s", daysStr.String(), reminderTime)
 	case entities.Monthly:
-		// format days like: 1, 5, 12
-		var daysStr strings.Builder
-		for i, d := range reminder.Recurrence.DayOfMonth {
-			if i > 0 {
-				daysStr.WriteString(", ")
-			}
-			daysStr.WriteString(fmt.Sprintf("%d", d))
+		// format days like: 1, 15, last
+		daysStr := FormatMonthDays(reminder.Recurrence.DayOfMonth, reminder.Recurrence.ClampToMonthEnd, lang)
+		if len(reminder.Recurrence.NthWeekdays) > 0 {
+			daysStr = FormatNthWeekdays(reminder.Recurrence.NthWeekdays, lang)
 		}
-		reminderTime = fmt.Sprintf("%s • %s", daysStr.String(), reminderTime)
+		reminderTime = fmt.Sprintf("%s • %s", daysStr, reminderTime)
 	case entities.Interval:
-		reminderTime = fmt.Sprintf(s.MsgEveryNDays, reminder.Recurrence.Interval)
+		reminderTime = FormatInterval(reminder.Recurrence.Interval, reminder.Recurrence.IntervalUnit, reminder.Recurrence.ActiveWindow, lang)
 	case entities.SpacedBasedRepetition:
-		var days []string
-		for _, d := range reminder.Recurrence.SpacedBasedRepetitionDays {
-			days = append(days, fmt.Sprintf("%d", d))
-		}
-		reminderTime = fmt.Sprintf(s.MsgEveryNDaysSpaced, strings.Join(days, ", "))
+		reminderTime = FormatSpacedRepetition(reminder.Recurrence.IsAdaptive(), reminder.Recurrence.RemainingLadder(), lang)
+	case entities.RRule:
+		reminderTime = fmt.Sprintf("%s • %s", reminder.Recurrence.RRule, reminder.Recurrence.GetTimeOfDay())
+	case entities.Yearly:
+		start := reminder.Recurrence.StartDate.In(reminder.Recurrence.GetLocation())
+		reminderTime = fmt.Sprintf("%s • %s", FormatYearlyDate(start.Month(), start.Day(), lang), reminderTime)
 	default:
 		reminderTime = reminder.Recurrence.GetTimeOfDay()
 	}
 
 	label = fmt.Sprintf("%s %s %s %s", status, recurrenceType, s.At, reminderTime)
 
+	// Show progress towards the end of a limited recurrence
+	rec := reminder.Recurrence
+	if rec.MaxOccurrences > 0 {
+		label = fmt.Sprintf("%s (%d/%d)", label, rec.OccurrenceCount, rec.MaxOccurrences)
+	}
+	if rec.EndDate != nil && rec.Type != entities.Once {
+		label = fmt.Sprintf("%s 🏁 %s", label, rec.EndDate.In(rec.GetLocation()).Format("2006-01-02"))
+	}
+
+	// The next trigger in the user's timezone
+	if reminder.IsActive && reminder.NextTrigger != nil {
+		loc := user.GetLocation()
+		if loc == nil {
+			loc = rec.GetLocation()
+		}
+		if loc == nil {
+			loc = time.UTC
+		}
+		label = fmt.Sprintf("%s ⏭ %s", label, reminder.NextTrigger.In(loc).Format("2006-01-02 15:04"))
+	}
+
 	if includeMessage {
-		label = fmt.Sprintf("%s — %s", label, reminder.Message)
+		label = fmt.Sprintf("%s — %s", label, truncateMessage(reminder.Message, maxListMessageRunes))
 	}
 
 	return label
 }
 
 func ParseDeleteReminderID(callbackData string) (int64, bool) {
-	if !strings.HasPrefix(callbackData, CallbackReminderDeletePrefix) {
+	return parseReminderID(callbackData, CallbackReminderDeletePrefix)
+}
+
+func ParseToggleNagReminderID(callbackData string) (int64, bool) {
+	return parseReminderID(callbackData, CallbackReminderNagPrefix)
+}
+
+func ParseHistoryReminderID(callbackData string) (int64, bool) {
+	return parseReminderID(callbackData, CallbackReminderHistPrefix)
+}
+
+func ParseEditReminderID(callbackData string) (int64, bool) {
+	return parseReminderID(callbackData, CallbackReminderEditPrefix)
+}
+
+func ParsePauseReminderID(callbackData string) (int64, bool) {
+	return parseReminderID(callbackData, CallbackReminderPausePrefix)
+}
+
+func ParseResumeReminderID(callbackData string) (int64, bool) {
+	return parseReminderID(callbackData, CallbackReminderResumePrefix)
+}
+
+func ParseCriticalReminderID(callbackData string) (int64, bool) {
+	return parseReminderID(callbackData, CallbackReminderCritPrefix)
+}
+
+func parseReminderID(callbackData, prefix string) (int64, bool) {
+	if !strings.HasPrefix(callbackData, prefix) {
 		return 0, false
 	}
-	idStr := strings.TrimPrefix(callbackData, CallbackReminderDeletePrefix)
+	idStr := strings.TrimPrefix(callbackData, prefix)
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
 		return 0, false
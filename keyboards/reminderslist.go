@@ -0,0 +1,191 @@
+package keyboards
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// The callback data of paging and filtering the reminders list
+const (
+	CallbackPrefixRemindersPage   = "rem_page:"
+	CallbackRemindersFilters      = "rem_filters"
+	CallbackPrefixRemindersFilter = "rem_filter:"
+)
+
+// Filters of the reminders list; a recurrence type name shows reminders of that type
+const (
+	RemindersFilterAll      = ""
+	RemindersFilterActive   = "active"
+	RemindersFilterInactive = "inactive"
+)
+
+// RemindersPageSize keeps the list well within Telegram's message and keyboard size limits
+const RemindersPageSize = 5
+
+// RemindersPage is the part of a user's reminders shown in the list
+type RemindersPage struct {
+	Reminders []entities.Reminder // Reminders on the page, soonest first
+	Page      int                 // Zero-based
+	Pages     int
+	Matching  int // Reminders matching the filter
+	Total     int // All reminders of the user
+	Filter    string
+}
+
+// NewRemindersPage filters reminders, sorts them by their next trigger and cuts out a page.
+// A page past the end shows the last one, e.g. after deleting its only reminder.
+func NewRemindersPage(reminders []entities.Reminder, filter string, page int) *RemindersPage {
+	var matching []entities.Reminder
+	for _, r := range reminders {
+		if matchesRemindersFilter(r, filter) {
+			matching = append(matching, r)
+		}
+	}
+	slices.SortStableFunc(matching, compareNextTrigger)
+
+	pages := max(1, (len(matching)+RemindersPageSize-1)/RemindersPageSize)
+	page = min(max(page, 0), pages-1)
+	start := page * RemindersPageSize
+	end := min(start+RemindersPageSize, len(matching))
+
+	return &RemindersPage{
+		Reminders: matching[start:end],
+		Page:      page,
+		Pages:     pages,
+		Matching:  len(matching),
+		Total:     len(reminders),
+		Filter:    filter,
+	}
+}
+
+func matchesRemindersFilter(reminder entities.Reminder, filter string) bool {
+	switch filter {
+	case RemindersFilterAll:
+		return true
+	case RemindersFilterActive:
+		return reminder.IsActive
+	case RemindersFilterInactive:
+		return !reminder.IsActive
+	}
+	recurrenceType, err := entities.ToRecurrenceType(filter)
+	return err == nil && reminder.Recurrence != nil && reminder.Recurrence.Type == recurrenceType
+}
+
+// compareNextTrigger orders reminders by their next trigger, those without one last
+func compareNextTrigger(a, b entities.Reminder) int {
+	switch {
+	case a.NextTrigger == nil && b.NextTrigger == nil:
+		return cmp.Compare(a.ID, b.ID)
+	case a.NextTrigger == nil:
+		return 1
+	case b.NextTrigger == nil:
+		return -1
+	}
+	if c := a.NextTrigger.Compare(*b.NextTrigger); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// ParseRemindersPage returns the page to show from a paging callback
+func ParseRemindersPage(callbackData string) (int, bool) {
+	if !strings.HasPrefix(callbackData, CallbackPrefixRemindersPage) {
+		return 0, false
+	}
+	page, err := strconv.Atoi(callbackData[len(CallbackPrefixRemindersPage):])
+	if err != nil || page < 0 {
+		return 0, false
+	}
+	return page, true
+}
+
+// ParseRemindersFilter returns the filter picked in the filter menu
+func ParseRemindersFilter(callbackData string) (string, bool) {
+	if !strings.HasPrefix(callbackData, CallbackPrefixRemindersFilter) {
+		return "", false
+	}
+	filter := callbackData[len(CallbackPrefixRemindersFilter):]
+	switch filter {
+	case RemindersFilterAll, RemindersFilterActive, RemindersFilterInactive:
+		return filter, true
+	}
+	if _, err := entities.ToRecurrenceType(filter); err != nil {
+		return "", false
+	}
+	return filter, true
+}
+
+// RemindersFilterLabel names a filter of the reminders list
+func RemindersFilterLabel(filter, lang string) string {
+	s := T(lang)
+	switch filter {
+	case RemindersFilterAll:
+		return s.FilterAll
+	case RemindersFilterActive:
+		return s.FilterActive
+	case RemindersFilterInactive:
+		return s.FilterInactive
+	}
+	recurrenceType, err := entities.ToRecurrenceType(filter)
+	if err != nil {
+		return filter
+	}
+	return RecurrenceTypeLabel(lang, recurrenceType)
+}
+
+// GetRemindersFilterMarkup offers the filters of the reminders list
+func GetRemindersFilterMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	button := func(filter string) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(RemindersFilterLabel(filter, lang), CallbackPrefixRemindersFilter+filter)
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(button(RemindersFilterAll), button(RemindersFilterActive), button(RemindersFilterInactive)),
+	}
+	var row []tgbotapi.InlineKeyboardButton
+	for _, recurrenceType := range entities.RecurrenceTypeValues {
+		row = append(row, button(recurrenceType.String()))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, CallbackRemindersList)))
+
+	menu := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return &menu
+}
+
+// getRemindersPagingRow moves between the pages of the list, nil when everything fits one page
+func getRemindersPagingRow(page *RemindersPage) []tgbotapi.InlineKeyboardButton {
+	if page.Pages <= 1 {
+		return nil
+	}
+	var row []tgbotapi.InlineKeyboardButton
+	if page.Page > 0 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("◀️", fmt.Sprintf("%s%d", CallbackPrefixRemindersPage, page.Page-1)))
+	}
+	if page.Page < page.Pages-1 {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("▶️", fmt.Sprintf("%s%d", CallbackPrefixRemindersPage, page.Page+1)))
+	}
+	return row
+}
+
+// truncateMessage shortens long reminder texts in the list to keep the message within limits
+func truncateMessage(message string, maxRunes int) string {
+	runes := []rune(message)
+	if len(runes) <= maxRunes {
+		return message
+	}
+	return string(runes[:maxRunes-1]) + "…"
+}
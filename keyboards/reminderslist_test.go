@@ -0,0 +1,101 @@
+package keyboards
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestNewRemindersPage(t *testing.T) {
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	var reminders []entities.Reminder
+	for i := 1; i <= 12; i++ {
+		r := *entities.NewReminder(int64(i), 1, fmt.Sprintf("r%d", i), entities.DailyAt(start, time.UTC), nil)
+		next := start.Add(time.Duration(12-i) * time.Hour) // later IDs fire sooner
+		r.NextTrigger = &next
+		reminders = append(reminders, r)
+	}
+	reminders[0].Deactivate()
+	reminders[0].NextTrigger = nil
+	reminders[1].Recurrence = entities.CustomWeekly([]time.Weekday{time.Monday}, start, time.UTC)
+
+	page := NewRemindersPage(reminders, RemindersFilterAll, 0)
+	if page.Pages != 3 || page.Matching != 12 || len(page.Reminders) != RemindersPageSize {
+		t.Fatalf("expected 3 pages of %d, got %d pages with %d", RemindersPageSize, page.Pages, len(page.Reminders))
+	}
+	if page.Reminders[0].ID != 12 || page.Reminders[1].ID != 11 {
+		t.Fatalf("expected the soonest reminders first, got %d, %d", page.Reminders[0].ID, page.Reminders[1].ID)
+	}
+
+	page = NewRemindersPage(reminders, RemindersFilterAll, 7)
+	if page.Page != 2 || len(page.Reminders) != 2 || page.Reminders[1].ID != 1 {
+		t.Fatalf("expected the last page ending with the inactive reminder, got page %d with %+v", page.Page, page.Reminders)
+	}
+
+	tests := []struct {
+		filter string
+		want   int
+	}{
+		{RemindersFilterActive, 11},
+		{RemindersFilterInactive, 1},
+		{entities.Weekly.String(), 1},
+		{entities.Daily.String(), 11},
+		{entities.Yearly.String(), 0},
+	}
+	for _, tt := range tests {
+		if page := NewRemindersPage(reminders, tt.filter, 0); page.Matching != tt.want || page.Total != 12 {
+			t.Fatalf("filter %q: expected %d of 12, got %d of %d", tt.filter, tt.want, page.Matching, page.Total)
+		}
+	}
+
+	user := &entities.User{Language: LangEN, Location: time.UTC}
+	text := FormatRemindersListText(NewRemindersPage(reminders, entities.Yearly.String(), 0), user)
+	if !strings.Contains(text, T(LangEN).MsgNoMatchingReminders) {
+		t.Fatalf("expected the empty filter notice, got %q", text)
+	}
+}
+
+func TestRemindersListCallbacks(t *testing.T) {
+	if page, ok := ParseRemindersPage(CallbackPrefixRemindersPage + "2"); !ok || page != 2 {
+		t.Fatalf("expected page 2, got %d", page)
+	}
+	if _, ok := ParseRemindersPage(CallbackPrefixRemindersPage + "-1"); ok {
+		t.Fatalf("expected a negative page to be rejected")
+	}
+	for _, filter := range []string{RemindersFilterAll, RemindersFilterActive, entities.Monthly.String()} {
+		if got, ok := ParseRemindersFilter(CallbackPrefixRemindersFilter + filter); !ok || got != filter {
+			t.Fatalf("expected filter %q, got %q", filter, got)
+		}
+	}
+	if _, ok := ParseRemindersFilter(CallbackPrefixRemindersFilter + "Hourly"); ok {
+		t.Fatalf("expected an unknown filter to be rejected")
+	}
+	for _, data := range []string{CallbackPrefixRemindersPage + "1", CallbackRemindersFilters, CallbackPrefixRemindersFilter + "active"} {
+		if got := GetKeyboardType(data); got != Reminders {
+			t.Fatalf("GetKeyboardType(%s) = %v, want %v", data, got, Reminders)
+		}
+	}
+}
+
+func TestFormatLabel_NextTriggerInUserTimezone(t *testing.T) {
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	user := &entities.User{Language: LangEN, Location: kyiv}
+	start := time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC)
+	reminder := *entities.NewReminder(1, 1, strings.Repeat("a", 300), entities.DailyAt(start, time.UTC), nil)
+	next := time.Date(2026, 7, 2, 9, 0, 0, 0, time.UTC)
+	reminder.NextTrigger = &next
+
+	label := formatLabel(reminder, user, true)
+	if !strings.Contains(label, "⏭ 2026-07-02 12:00") {
+		t.Fatalf("expected the next trigger in Kyiv time, got %q", label)
+	}
+	if strings.Contains(label, strings.Repeat("a", maxListMessageRunes)) || !strings.HasSuffix(label, "…") {
+		t.Fatalf("expected a long message to be shortened, got %q", label)
+	}
+}
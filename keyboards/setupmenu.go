@@ -30,10 +30,11 @@ func GetSetupMenuMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
 			tgbotapi.NewInlineKeyboardButtonData(RecurrenceTypeLabel(lang, entities.Once), entities.Once.String()),
 			tgbotapi.NewInlineKeyboardButtonData(RecurrenceTypeLabel(lang, entities.Daily), entities.Daily.String()),
 		),
-		// Recurring reminders: Weekly and Monthly
+		// Recurring reminders: Weekly, Monthly and Yearly
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData(RecurrenceTypeLabel(lang, entities.Weekly), entities.Weekly.String()),
 			tgbotapi.NewInlineKeyboardButtonData(RecurrenceTypeLabel(lang, entities.Monthly), entities.Monthly.String()),
+			tgbotapi.NewInlineKeyboardButtonData(RecurrenceTypeLabel(lang, entities.Yearly), entities.Yearly.String()),
 		),
 		// Advanced reminders: Interval and Spaced repetition
 		tgbotapi.NewInlineKeyboardRow(
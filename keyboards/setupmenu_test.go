@@ -22,7 +22,7 @@ func TestIsNlpTextInputCallback(t *testing.T) {
 
 func TestGetSetupMenuMarkup(t *testing.T) {
 	expectedRows := 5
-	expectedButtonsPerRow := []int{1, 2, 2, 2, 2} // NLP (1), Once+Daily (2), Weekly+Monthly (2), Interval+Spaced (2), MyReminders+Back (2)
+	expectedButtonsPerRow := []int{1, 2, 3, 2, 2} // NLP (1), Once+Daily (2), Weekly+Monthly+Yearly (3), Interval+Spaced (2), MyReminders+Back (2)
 
 	m := GetSetupMenuMarkup(LangEN)
 	if len(m.InlineKeyboard) != expectedRows {
@@ -0,0 +1,100 @@
+package keyboards
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+const (
+	// Represents reviews adapting to recall grades.
+	CallbackSpacedAdaptive = "srs_adaptive"
+	// Represents the default fixed ladder.
+	CallbackSpacedLadder = "srs_ladder"
+	// Represents typing a custom ladder.
+	CallbackSpacedCustom = "srs_custom"
+)
+
+func IsSpacedRepetitionCallback(callbackData string) bool {
+	return strings.HasPrefix(callbackData, "srs_")
+}
+
+// GetSpacedRepetitionMarkup offers adaptive reviews, the default ladder and a custom one.
+func GetSpacedRepetitionMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnSpacedAdaptive, CallbackSpacedAdaptive)),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf(s.BtnSpacedLadder, FormatLadder(entities.DefaultSpacedRepetitionLadder)), CallbackSpacedLadder)),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnSpacedCustom, CallbackSpacedCustom)),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(s.BtnBack, SetupMenu)),
+	)
+	return &menu
+}
+
+// HandleSpacedRepetitionSelection stores how reviews are spaced and asks for the time,
+// or for the days between reviews of a custom ladder.
+func HandleSpacedRepetitionSelection(callbackData string, user *entities.User, userSelection *entities.UserSelection) *SelectionResult {
+	s := T(user.Language)
+	switch callbackData {
+	case CallbackSpacedAdaptive:
+		userSelection.SetSpacedRepetition(true, nil)
+	case CallbackSpacedLadder:
+		userSelection.SetSpacedRepetition(false, nil)
+	case CallbackSpacedCustom:
+		userSelection.StartCustomLadder()
+		return &SelectionResult{Text: s.MsgEnterLadder, Markup: nil}
+	default:
+		return &SelectionResult{Text: s.MsgSelectSpacedMode, Markup: GetSpacedRepetitionMarkup(user.Language)}
+	}
+	return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(user.Language)}
+}
+
+// HandleCustomLadderInput reads the days between reviews, e.g. "1, 3, 7, 14".
+func HandleCustomLadderInput(text string, userEntity *entities.User, selection *entities.UserSelection) *SelectionResult {
+	s := T(userEntity.Language)
+	ladder, ok := parseLadder(text)
+	if !ok {
+		return &SelectionResult{Text: s.MsgInvalidLadder, Markup: nil}
+	}
+
+	selection.SetSpacedRepetition(false, ladder)
+	return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(userEntity.Language)}
+}
+
+// parseLadder splits a comma or space separated list of days
+func parseLadder(text string) ([]int, bool) {
+	fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == ';' })
+	var ladder []int
+	for _, field := range fields {
+		days, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, false
+		}
+		ladder = append(ladder, days)
+	}
+	if entities.ValidateLadder(ladder) != nil {
+		return nil, false
+	}
+	return ladder, true
+}
+
+// FormatLadder renders days between reviews as "2, 3, 4".
+func FormatLadder(ladder []int) string {
+	var days []string
+	for _, d := range ladder {
+		days = append(days, strconv.Itoa(d))
+	}
+	return strings.Join(days, ", ")
+}
+
+// FormatSpacedRepetition describes how the reviews of a reminder are spaced.
+func FormatSpacedRepetition(adaptive bool, ladder []int, lang string) string {
+	s := T(lang)
+	if adaptive {
+		return s.MsgAdaptiveReviews
+	}
+	return fmt.Sprintf(s.MsgEveryNDaysSpaced, FormatLadder(ladder))
+}
@@ -0,0 +1,58 @@
+package keyboards
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestHandleSpacedRepetitionSelection(t *testing.T) {
+	user := &entities.User{Language: LangEN, Location: time.UTC}
+	sel := entities.NewUserSelection()
+	sel.SetRecurrenceType(entities.SpacedBasedRepetition)
+
+	if got := GetKeyboardType(CallbackSpacedAdaptive); got != SpacedRepetition {
+		t.Fatalf("GetKeyboardType(adaptive) = %v, want %v", got, SpacedRepetition)
+	}
+
+	result := HandleSpacedRepetitionSelection(CallbackSpacedAdaptive, user, sel)
+	if !sel.AdaptiveRecall || result.Text != T(LangEN).MsgSelectTime {
+		t.Fatalf("expected adaptive reviews followed by the time picker, got %q", result.Text)
+	}
+
+	result = HandleSpacedRepetitionSelection(CallbackSpacedCustom, user, sel)
+	if sel.AdaptiveRecall || !sel.CustomLadder || result.Text != T(LangEN).MsgEnterLadder {
+		t.Fatalf("expected to ask for a custom ladder, got %+v", sel)
+	}
+
+	result = HandleCustomLadderInput("1, 3 7;14", user, sel)
+	if sel.CustomLadder || len(sel.Ladder) != 4 || sel.Ladder[3] != 14 || result.Text != T(LangEN).MsgSelectTime {
+		t.Fatalf("expected the ladder to be read, got %v", sel.Ladder)
+	}
+
+	for _, text := range []string{"", "1, x", "0, 3", "400"} {
+		if result := HandleCustomLadderInput(text, user, sel); result.Text != T(LangEN).MsgInvalidLadder {
+			t.Fatalf("expected %q to be rejected", text)
+		}
+	}
+}
+
+func TestFormatSpacedRepetition(t *testing.T) {
+	if got := FormatSpacedRepetition(false, []int{1, 3, 7}, LangEN); got != "Every 1, 3, 7 days" {
+		t.Fatalf("unexpected ladder label %q", got)
+	}
+	if got := FormatSpacedRepetition(true, nil, LangEN); got != T(LangEN).MsgAdaptiveReviews {
+		t.Fatalf("unexpected adaptive label %q", got)
+	}
+}
+
+func TestFormatGradedNotification(t *testing.T) {
+	next := time.Date(2025, 3, 8, 9, 0, 0, 0, time.UTC)
+	if got := FormatGradedNotification("🔔 words", &next, time.UTC, LangEN).Text; got != "🔔 words\n\n🧠 Next review on 2025-03-08 09:00" {
+		t.Fatalf("unexpected graded text %q", got)
+	}
+	if got := FormatGradedNotification("🔔 words", nil, time.UTC, LangEN).Text; got != "🔔 words\n\n"+T(LangEN).MsgRecallLearned {
+		t.Fatalf("unexpected learned text %q", got)
+	}
+}
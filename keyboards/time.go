@@ -20,6 +20,8 @@ const (
 	CallbackPrefixSpecificTime = "time_specific:"
 	// Represents the custom time selection option.
 	CallbackPrefixCustom = "time_custom:"
+	// Represents finishing the selection of several times of day.
+	CallbackTimeDone = "time_done"
 )
 
 func IsTimeSelectionCallback(callbackData string) bool {
@@ -34,6 +36,12 @@ func HandleTimeSelection(callbackData string,
 	case strings.Contains(callbackData, CallbackTimeStart):
 		return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(user.Language)}
 
+	case callbackData == CallbackTimeDone:
+		if len(userSelection.GetSelectedTimes()) == 0 {
+			return &SelectionResult{Text: s.MsgSelectTime, Markup: GetHourRangeMarkup(user.Language)}
+		}
+		return messageStep(userSelection, user.Language)
+
 	case strings.Contains(callbackData, CallbackPrefixHourRange):
 		startHour := 0
 		fmt.Sscanf(callbackData[len(CallbackPrefixHourRange):], "%d", &startHour)
@@ -46,8 +54,13 @@ func HandleTimeSelection(callbackData string,
 
 	case strings.Contains(callbackData, CallbackPrefixSpecificTime):
 		timeStr := callbackData[len(CallbackPrefixSpecificTime):]
+		if userSelection.RecurrenceType.SupportsTimesOfDay() {
+			// Daily, weekly and monthly reminders may fire at several times, so keep picking until done
+			userSelection.ToggleSelectedTime(timeStr)
+			return &SelectionResult{Text: formatSelectedTimes(userSelection, user.Language), Markup: GetSelectedTimesMarkup(user.Language)}
+		}
 		userSelection.SelectedTime = timeStr
-		return &SelectionResult{Text: s.MsgSelectMessage, Markup: GetMessageSelectionMarkup(user.Language)}
+		return messageStep(userSelection, user.Language)
 
 	case strings.Contains(callbackData, CallbackPrefixCustom):
 		userSelection.CustomTime = true
@@ -57,6 +70,36 @@ func HandleTimeSelection(callbackData string,
 	return nil
 }
 
+// messageStep asks for the message after the time, or returns to the editor when editing a reminder
+func messageStep(userSelection *entities.UserSelection, lang string) *SelectionResult {
+	if userSelection.IsEditing() {
+		return EditReminderResult(userSelection, lang)
+	}
+	return &SelectionResult{Text: T(lang).MsgSelectMessage, Markup: GetMessageSelectionMarkup(lang)}
+}
+
+// GetSelectedTimesMarkup lets the user add another time of day or continue to the message.
+func GetSelectedTimesMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
+	s := T(lang)
+	menu := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnAddTime, CallbackTimeStart),
+			tgbotapi.NewInlineKeyboardButtonData(s.BtnSelect, CallbackTimeDone),
+		),
+	)
+	return &menu
+}
+
+// formatSelectedTimes lists the picked times of day, e.g. "Selected times: 08:00, 14:00".
+func formatSelectedTimes(userSelection *entities.UserSelection, lang string) string {
+	s := T(lang)
+	times := userSelection.GetSelectedTimes()
+	if len(times) == 0 {
+		return s.MsgSelectTime
+	}
+	return fmt.Sprintf(s.MsgSelectedTimes, strings.Join(times, ", "))
+}
+
 // getHourRangeMarkup generates the first level of the menu (4-hour ranges).
 func GetHourRangeMarkup(lang string) *tgbotapi.InlineKeyboardMarkup {
 	var rows [][]tgbotapi.InlineKeyboardButton
@@ -136,21 +179,37 @@ func HandleCustomTimeSelection(text string,
 	msg *tgbotapi.MessageConfig,
 	user *entities.User,
 	userSelection *entities.UserSelection) *SelectionResult {
-	_, _, ok := scheduler.ParseHourMinute(text)
-
-	outputText := ""
-	markup := &tgbotapi.InlineKeyboardMarkup{}
+	times, ok := parseTimesOfDay(text)
+	if ok && len(times) > 1 && !userSelection.RecurrenceType.SupportsTimesOfDay() {
+		ok = false
+	}
 
 	if !ok {
 		s := T(user.Language)
-		outputText = fmt.Sprintf("%s. %s", s.MsgInvalidTimeFormat, s.MsgEnterCustomTime)
-		markup = GetHourRangeMarkup(user.Language)
+		return &SelectionResult{Text: fmt.Sprintf("%s. %s", s.MsgInvalidTimeFormat, s.MsgEnterCustomTime), Markup: GetHourRangeMarkup(user.Language)}
+	}
+
+	if len(times) > 1 {
+		userSelection.SetSelectedTimes(times)
 	} else {
-		userSelection.SelectedTime = text
-		s := T(user.Language)
-		outputText = s.MsgSelectMessage
-		markup = GetMessageSelectionMarkup(user.Language)
+		userSelection.SelectedTime = times[0]
 	}
+	return messageStep(userSelection, user.Language)
+}
 
-	return &SelectionResult{Text: outputText, Markup: markup}
+// parseTimesOfDay parses one or more comma or space separated HH:MM times, e.g. "8:00, 14:00, 20:00"
+func parseTimesOfDay(text string) ([]string, bool) {
+	fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == ';' })
+	if len(fields) == 0 {
+		return nil, false
+	}
+	times := make([]string, 0, len(fields))
+	for _, field := range fields {
+		hour, minute, ok := scheduler.ParseHourMinute(field)
+		if !ok {
+			return nil, false
+		}
+		times = append(times, fmt.Sprintf("%02d:%02d", hour, minute))
+	}
+	return times, true
 }
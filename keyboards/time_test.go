@@ -3,6 +3,8 @@ package keyboards
 import (
 	"strings"
 	"testing"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 )
 
 // TestGetHourRangeMarkup verifies the first level of the keyboard.
@@ -70,3 +72,48 @@ func TestGetSpecificTimeMarkup(t *testing.T) {
 		t.Errorf("Expected callback data %s, got %s", expectedCallback, *lastButton.CallbackData)
 	}
 }
+
+func TestHandleTimeSelection_MultipleTimes(t *testing.T) {
+	user := &entities.User{Language: LangEN}
+	sel := entities.NewUserSelection()
+	sel.SetRecurrenceType(entities.Daily)
+
+	HandleTimeSelection(CallbackPrefixSpecificTime+"20:00", user, sel)
+	HandleTimeSelection(CallbackPrefixSpecificTime+"08:00", user, sel)
+	result := HandleTimeSelection(CallbackPrefixSpecificTime+"14:00", user, sel)
+	if got := strings.Join(sel.GetSelectedTimes(), ","); got != "08:00,14:00,20:00" || sel.SelectedTime != "08:00" {
+		t.Fatalf("expected three sorted times, got %q (first %q)", got, sel.SelectedTime)
+	}
+	if !strings.Contains(result.Text, "08:00, 14:00, 20:00") {
+		t.Fatalf("expected the selected times to be listed, got %q", result.Text)
+	}
+
+	// Picking a time again removes it
+	HandleTimeSelection(CallbackPrefixSpecificTime+"14:00", user, sel)
+	if got := strings.Join(sel.GetSelectedTimes(), ","); got != "08:00,20:00" {
+		t.Fatalf("expected 14:00 to be removed, got %q", got)
+	}
+
+	result = HandleTimeSelection(CallbackTimeDone, user, sel)
+	if result.Text != T(LangEN).MsgSelectMessage {
+		t.Fatalf("expected message selection after done, got %q", result.Text)
+	}
+}
+
+func TestHandleCustomTimeSelection_List(t *testing.T) {
+	user := &entities.User{Language: LangEN}
+	sel := entities.NewUserSelection()
+	sel.SetRecurrenceType(entities.Daily)
+
+	HandleCustomTimeSelection("20:00, 8:00 14:00", nil, user, sel)
+	if got := strings.Join(sel.GetSelectedTimes(), ","); got != "08:00,14:00,20:00" {
+		t.Fatalf("expected three times, got %q", got)
+	}
+
+	sel.SetRecurrenceType(entities.Once)
+	sel.SetSelectedTime("")
+	HandleCustomTimeSelection("08:00, 14:00", nil, user, sel)
+	if sel.SelectedTime != "" {
+		t.Fatalf("expected several times to be rejected for a one-time reminder, got %q", sel.SelectedTime)
+	}
+}
@@ -0,0 +1,12 @@
+package keyboards
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatYearlyDate renders the month and day of a yearly reminder, e.g. "March 15".
+func FormatYearlyDate(month time.Month, day int, lang string) string {
+	s := T(lang)
+	return fmt.Sprintf(s.YearlyDate, day, s.MonthNames[month])
+}
@@ -0,0 +1,15 @@
+package keyboards
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatYearlyDate(t *testing.T) {
+	if got := FormatYearlyDate(time.March, 15, LangEN); got != "March 15" {
+		t.Fatalf("unexpected English date %q", got)
+	}
+	if got := FormatYearlyDate(time.March, 15, LangUK); got != "15 березня" {
+		t.Fatalf("unexpected Ukrainian date %q", got)
+	}
+}
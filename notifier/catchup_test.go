@@ -0,0 +1,114 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+// missedDailyReminder creates a daily 09:00 UTC reminder whose trigger was missed three days ago
+func missedDailyReminder(t *testing.T, policy entities.CatchUpPolicy) (repositories.ReminderRepository, *entities.Reminder, time.Time) {
+	t.Helper()
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 7, Location: time.UTC}
+	tod := time.Date(2025, 3, 7, 9, 0, 0, 0, time.UTC)
+	rem, _ := repo.CreateDailyReminder(tod, &user, "ping")
+	rem.NextTrigger = &tod
+	rem.CatchUp = policy
+	repo.UpdateReminder(rem)
+	return repo, rem, time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+}
+
+func TestProcessDueReminders_CatchUpFireOnceAnnotatesLateDelivery(t *testing.T) {
+	repo, rem, now := missedDailyReminder(t, entities.CatchUpFireOnce)
+	sender := &fakeSender{}
+
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != 1 {
+		t.Fatalf("expected 1 message sent, got %d", sender.sent)
+	}
+	msg := sender.last.(tgbotapi.MessageConfig)
+	if !strings.Contains(msg.Text, "2025-03-07 09:00") {
+		t.Fatalf("expected late delivery to mention the original schedule, got %q", msg.Text)
+	}
+	updated, _ := repo.GetReminder(rem.ID)
+	if updated.NextTrigger == nil || !updated.NextTrigger.Equal(time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected next trigger tomorrow 09:00, got %v", updated.NextTrigger)
+	}
+}
+
+func TestProcessDueReminders_CatchUpFireAllSendsEveryMissedOccurrence(t *testing.T) {
+	repo, rem, now := missedDailyReminder(t, entities.CatchUpFireAll)
+	sender := &fakeSender{}
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), deliveries, sender)
+
+	// 7th, 8th, 9th and 10th at 09:00
+	if sender.sent != 4 {
+		t.Fatalf("expected 4 messages sent, got %d", sender.sent)
+	}
+	history, _ := deliveries.GetDeliveriesByReminder(rem.ID, 0)
+	if len(history) != 4 || !history[0].ScheduledAt.Equal(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected one delivery per missed occurrence, got %+v", history)
+	}
+	updated, _ := repo.GetReminder(rem.ID)
+	if updated.NextTrigger == nil || !updated.NextTrigger.Equal(time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected next trigger tomorrow 09:00, got %v", updated.NextTrigger)
+	}
+}
+
+func TestProcessDueReminders_CatchUpSkipDropsMissedOccurrences(t *testing.T) {
+	repo, rem, now := missedDailyReminder(t, entities.CatchUpSkip)
+	sender := &fakeSender{}
+
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != 0 {
+		t.Fatalf("expected no messages sent, got %d", sender.sent)
+	}
+	updated, _ := repo.GetReminder(rem.ID)
+	if !updated.IsActive || updated.NextTrigger == nil || !updated.NextTrigger.Equal(time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected reminder to move on to tomorrow 09:00, got %+v", updated)
+	}
+}
+
+func TestProcessDueReminders_OnTimeDeliveryIgnoresCatchUpPolicy(t *testing.T) {
+	repo, rem, _ := missedDailyReminder(t, entities.CatchUpSkip)
+	sender := &fakeSender{}
+
+	ProcessDueReminders(rem.NextTrigger.Add(30*time.Second), repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != 1 {
+		t.Fatalf("expected 1 message sent, got %d", sender.sent)
+	}
+	if msg := sender.last.(tgbotapi.MessageConfig); msg.Text != "🔔 ping" {
+		t.Fatalf("on-time delivery should not be annotated, got %q", msg.Text)
+	}
+}
+
+func TestProcessDueReminders_DelayedPassAcrossMidnightKeepsIntervalSchedule(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 7, Location: time.UTC}
+	scheduledAt := time.Date(2025, 3, 10, 23, 50, 0, 0, time.UTC)
+	rem, _ := repo.CreateIntervalReminder(2, entities.IntervalUnitDays, nil, scheduledAt, &user, "water the plants")
+	rem.NextTrigger = &scheduledAt
+	repo.UpdateReminder(rem)
+
+	sender := &fakeSender{}
+	ProcessDueReminders(time.Date(2025, 3, 11, 0, 10, 0, 0, time.UTC), repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != 1 {
+		t.Fatalf("expected 1 message sent, got %d", sender.sent)
+	}
+	updated, _ := repo.GetReminder(rem.ID)
+	if want := time.Date(2025, 3, 12, 23, 50, 0, 0, time.UTC); updated.NextTrigger == nil || !updated.NextTrigger.Equal(want) {
+		t.Fatalf("expected next trigger two days after the missed occurrence at %v, got %v", want, updated.NextTrigger)
+	}
+}
@@ -0,0 +1,107 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+	"github.com/ivanenkomaksym/remindme_bot/keyboards"
+	"github.com/ivanenkomaksym/remindme_bot/scheduler"
+)
+
+// maxDigestOccurrences caps the occurrences of a single reminder listed in a digest, e.g. of one firing every minute
+const maxDigestOccurrences = 50
+
+// processDigests sends the daily digests due by now. It runs before due reminders are processed,
+// so occurrences at the time of the digest are still listed in it.
+func processDigests(ctx context.Context, now time.Time, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, sender BotSender) {
+	users, err := userRepo.GetUsersWithDueDigest(now)
+	if err != nil {
+		log.Printf("Failed to load users with a due digest: %v", err)
+		return
+	}
+	for i := range users {
+		if ctx.Err() != nil {
+			return
+		}
+		sendDigest(now, &users[i], reminderRepo, userRepo, sender)
+	}
+}
+
+// sendDigest claims the due digest of a user, moving it to the next day, and sends one message
+// listing the occurrences until then. Digests missed while the user was paused or unreachable are dropped.
+func sendDigest(now time.Time, user *entities.User, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, sender BotSender) {
+	scheduledAt := user.Digest.NextAt
+	next, err := scheduler.NextDigest(now, user.Digest.Time, user.GetLocation())
+	if err != nil {
+		log.Printf("Failed to schedule the digest of user %d: %v", user.ID, err)
+		return
+	}
+	// Another instance may have sent it since the users were loaded
+	claimed, err := userRepo.ClaimDigest(user.ID, scheduledAt, next)
+	if err != nil {
+		log.Printf("Failed to claim the digest of user %d: %v", user.ID, err)
+		return
+	}
+	if !claimed || user.Unreachable || user.IsPaused(now) {
+		return
+	}
+
+	from := scheduledAt
+	if user.PausedUntil != nil && from.Before(*user.PausedUntil) {
+		from = *user.PausedUntil
+	}
+	reminders, err := reminderRepo.GetRemindersByUser(user.ID)
+	if err != nil {
+		log.Printf("Failed to load reminders for the digest of user %d: %v", user.ID, err)
+		return
+	}
+
+	var items []entities.AgendaItem
+	for i := range reminders {
+		rem := &reminders[i]
+		for _, at := range scheduler.UpcomingOccurrences(*rem, from, next, maxDigestOccurrences) {
+			if user.Digest.Covers(rem, at) {
+				items = append(items, entities.AgendaItem{ReminderID: rem.ID, Message: rem.Message, At: at})
+			}
+		}
+	}
+	// Nothing to summarise
+	if len(items) == 0 {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b entities.AgendaItem) int { return a.At.Compare(b.At) })
+
+	msg := tgbotapi.NewMessage(user.ID, keyboards.FormatDigest(items, user.GetLocation(), user.Language))
+	if _, err := sender.Send(msg); err != nil {
+		log.Printf("Failed to send the digest to user %d: %v", user.ID, err)
+		if isUnreachable(err) {
+			markUnreachable(user.ID, err, userRepo)
+		}
+	}
+}
+
+// leaveToDigest advances a reminder past an occurrence listed in the daily digest instead of
+// notifying about it. A one-time reminder is deactivated.
+func leaveToDigest(now time.Time, rem *entities.Reminder) {
+	rem.ClearSnooze()
+	if rem.Recurrence == nil || rem.Recurrence.Type == entities.Once || rem.Recurrence.StartDate == nil {
+		rem.IsActive = false
+		return
+	}
+
+	rem.Recurrence.RecordOccurrence()
+	var next *time.Time
+	if !rem.Recurrence.IsExhausted() {
+		// Use StartDate for the time of day, not the previous NextTrigger
+		next = scheduler.NextForRecurrence(now, *rem.Recurrence.StartDate, rem.Recurrence)
+	}
+	rem.NextTrigger = next
+	if next == nil {
+		rem.IsActive = false
+	}
+}
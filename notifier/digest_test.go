@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+func TestProcessDueReminders_DailyDigest(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	users := inmemory.NewInMemoryUserRepository()
+	user, _ := users.CreateUser(123, "u", "f", "l", "en")
+	now := time.Now().Truncate(time.Minute).UTC()
+	users.SetDigest(user.ID, &entities.Digest{Time: now.Format("15:04"), NextAt: now, Since: now})
+
+	inHour := now.Add(time.Hour)
+	plants, _ := repo.CreateDailyReminder(inHour, user, "water plants")
+	plants.NextTrigger = &inHour
+	repo.UpdateReminder(plants)
+
+	inTwoHours := now.Add(2 * time.Hour)
+	pills, _ := repo.CreateDailyReminder(inTwoHours, user, "take pills")
+	pills.NextTrigger = &inTwoHours
+	pills.Critical = true
+	repo.UpdateReminder(pills)
+
+	sender := &fakeSender{}
+	ProcessDueReminders(now, repo, users, deliveries, sender)
+	if sender.sent != 1 {
+		t.Fatalf("expected one digest, got %d messages", sender.sent)
+	}
+	text := sender.last.(tgbotapi.MessageConfig).Text
+	if !strings.Contains(text, "water plants") || strings.Contains(text, "take pills") {
+		t.Fatalf("digest should list the regular reminder only, got %q", text)
+	}
+
+	// The digest is sent once a day
+	ProcessDueReminders(now, repo, users, deliveries, sender)
+	if sender.sent != 1 {
+		t.Fatalf("the digest should not be sent twice, got %d messages", sender.sent)
+	}
+	digested, _ := users.GetUser(user.ID)
+	if !digested.Digest.NextAt.Equal(now.Add(24 * time.Hour)) {
+		t.Fatalf("expected the next digest a day later, got %v", digested.Digest.NextAt)
+	}
+
+	// The occurrence listed in the digest does not notify on its own but still advances
+	ProcessDueReminders(inHour, repo, users, deliveries, sender)
+	if sender.sent != 1 {
+		t.Fatalf("a reminder in the digest should not notify, got %d messages", sender.sent)
+	}
+	rem, _ := repo.GetReminder(plants.ID)
+	if !rem.IsActive || rem.NextTrigger == nil || !rem.NextTrigger.After(inHour) {
+		t.Fatalf("daily reminder should move to its next occurrence, got %+v", rem)
+	}
+
+	// Critical reminders still notify on their own
+	ProcessDueReminders(inTwoHours, repo, users, deliveries, sender)
+	if sender.sent != 2 {
+		t.Fatalf("expected the critical reminder to notify, got %d messages", sender.sent)
+	}
+	if text := sender.last.(tgbotapi.MessageConfig).Text; !strings.Contains(text, "take pills") {
+		t.Fatalf("expected the critical reminder, got %q", text)
+	}
+}
@@ -0,0 +1,49 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+func TestProcessDueReminders_DeactivatesAfterMaxOccurrences(t *testing.T) {
+	repo, rem, now := missedDailyReminder(t, entities.CatchUpFireAll)
+	rem.Recurrence.MaxOccurrences = 3
+	rem.Recurrence.OccurrenceCount = 1
+	repo.UpdateReminder(rem)
+	sender := &fakeSender{}
+
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	// Only the two occurrences left out of the four missed ones are sent
+	if sender.sent != 2 {
+		t.Fatalf("expected 2 messages sent, got %d", sender.sent)
+	}
+	updated, _ := repo.GetReminder(rem.ID)
+	if updated.IsActive || updated.NextTrigger != nil {
+		t.Fatalf("expected exhausted reminder to be deactivated, got %+v", updated)
+	}
+	if updated.Recurrence.OccurrenceCount != 3 {
+		t.Fatalf("expected 3 occurrences counted, got %d", updated.Recurrence.OccurrenceCount)
+	}
+}
+
+func TestProcessDueReminders_DeactivatesAtEndDate(t *testing.T) {
+	repo, rem, now := missedDailyReminder(t, entities.CatchUpFireOnce)
+	end := time.Date(2025, 3, 10, 23, 59, 59, 0, time.UTC)
+	rem.Recurrence.EndDate = &end
+	repo.UpdateReminder(rem)
+	sender := &fakeSender{}
+
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != 1 {
+		t.Fatalf("expected 1 message sent, got %d", sender.sent)
+	}
+	updated, _ := repo.GetReminder(rem.ID)
+	if updated.IsActive || updated.NextTrigger != nil {
+		t.Fatalf("expected reminder past its end date to be deactivated, got %+v", updated)
+	}
+}
@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+)
+
+// reminderLeaseDuration bounds how long a crashed instance can hold on to a reminder.
+// It must comfortably exceed the time needed to deliver one reminder.
+const reminderLeaseDuration = 2 * time.Minute
+
+// instanceID identifies this process when claiming reminders
+var instanceID = newInstanceID()
+
+// newInstanceID builds an owner name unique to this process, e.g. "host-42-1a2b3c4d"
+func newInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "notifier"
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Sprintf("%s-%d-%d", host, os.Getpid(), time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+// withLease claims a reminder for this instance, runs fn on its current state and releases it.
+// Reminders leased by another instance are skipped; they are being delivered there.
+func withLease(now time.Time, reminderID int64, reminderRepo repositories.ReminderRepository, opts Options, fn func(rem *entities.Reminder)) {
+	rem, err := reminderRepo.ClaimReminder(reminderID, opts.Owner, now, opts.LeaseDuration)
+	if err != nil {
+		log.Printf("Failed to claim reminder %d: %v", reminderID, err)
+		return
+	}
+	if rem == nil {
+		return
+	}
+	defer func() {
+		if err := reminderRepo.ReleaseReminder(reminderID, opts.Owner); err != nil {
+			log.Printf("Failed to release reminder %d: %v", reminderID, err)
+		}
+	}()
+	fn(rem)
+}
@@ -0,0 +1,85 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+// slowSender is safe for concurrent use and holds each send long enough for notifiers to overlap
+type slowSender struct {
+	mu   sync.Mutex
+	sent map[string]int
+}
+
+func (s *slowSender) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	time.Sleep(time.Millisecond)
+	msg := c.(tgbotapi.MessageConfig)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[msg.Text]++
+	return tgbotapi.Message{}, nil
+}
+
+func TestProcessDueReminders_ConcurrentNotifiersDeliverOnce(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	users := inmemory.NewInMemoryUserRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	const total = 50
+	for i := 0; i < total; i++ {
+		repo.CreateOnceReminder(now.Add(-30*time.Second), &user, string(rune('A'+i)))
+	}
+
+	sender := &slowSender{sent: make(map[string]int)}
+	var wg sync.WaitGroup
+	for _, owner := range []string{"instance-a", "instance-b"} {
+		opts := defaultOptions
+		opts.Owner = owner
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			processReminders(context.Background(), now, repo, users, deliveries, sender, opts)
+		}()
+	}
+	wg.Wait()
+
+	if len(sender.sent) != total {
+		t.Fatalf("expected %d distinct reminders sent, got %d", total, len(sender.sent))
+	}
+	for text, count := range sender.sent {
+		if count != 1 {
+			t.Fatalf("reminder %q sent %d times", text, count)
+		}
+	}
+}
+
+func TestWithLease_SkipsReminderHeldByAnotherInstance(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	rem, _ := repo.CreateOnceReminder(now, &user, "ping")
+
+	if claimed, _ := repo.ClaimReminder(rem.ID, "other", now, time.Minute); claimed == nil {
+		t.Fatalf("expected the first claim to succeed")
+	}
+
+	ran := false
+	withLease(now, rem.ID, repo, defaultOptions, func(*entities.Reminder) { ran = true })
+	if ran {
+		t.Fatalf("expected a reminder leased elsewhere to be skipped")
+	}
+
+	// An expired lease can be taken over
+	withLease(now.Add(2*time.Minute), rem.ID, repo, defaultOptions, func(*entities.Reminder) { ran = true })
+	if !ran {
+		t.Fatalf("expected an expired lease to be claimable")
+	}
+}
@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"log"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/scheduler"
+)
+
+// skipPausedOccurrences drops the occurrence of a reminder that fell into a pause of all
+// reminders of its user, which has ended by now. A recurring reminder continues from its next
+// occurrence after now and a one-time reminder is deactivated.
+// Returns false when the occurrence was not paused.
+func skipPausedOccurrences(now time.Time, rem *entities.Reminder, user *entities.User) bool {
+	if user == nil || user.PausedUntil == nil || !rem.IsActive || rem.NextTrigger == nil || !rem.NextTrigger.Before(*user.PausedUntil) {
+		return false
+	}
+
+	log.Printf("Skipping occurrence of reminder %d scheduled for %v during a pause", rem.ID, *rem.NextTrigger)
+	rem.ClearSnooze()
+	if rem.Recurrence == nil || rem.Recurrence.Type == entities.Once || rem.Recurrence.StartDate == nil {
+		rem.IsActive = false
+		return true
+	}
+
+	// Use StartDate for the time of day, not the previous NextTrigger
+	next := scheduler.NextForRecurrence(now, *rem.Recurrence.StartDate, rem.Recurrence)
+	rem.NextTrigger = next
+	if next == nil {
+		rem.IsActive = false
+	}
+	return true
+}
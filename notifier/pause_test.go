@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+func TestProcessDueReminders_PausedUserSkipsOccurrences(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	users := inmemory.NewInMemoryUserRepository()
+	user, _ := users.CreateUser(123, "u", "f", "l", "en")
+	now := time.Now().Truncate(time.Minute).UTC()
+	once, _ := repo.CreateOnceReminder(now, user, "once")
+	daily, _ := repo.CreateDailyReminder(now, user, "daily")
+	daily.NextTrigger = &now
+	repo.UpdateReminder(daily)
+
+	pausedUntil := now.Add(3 * 24 * time.Hour)
+	users.SetPausedUntil(user.ID, &pausedUntil)
+
+	sender := &flakySender{}
+	ProcessDueReminders(now, repo, users, deliveries, sender)
+	ProcessDueReminders(now.Add(24*time.Hour), repo, users, deliveries, sender)
+	if sender.sent != 0 {
+		t.Fatalf("no reminders should be sent while paused, got %d", sender.sent)
+	}
+
+	// The first tick after the pause drops what fell into it instead of catching up
+	after := pausedUntil.Add(time.Minute)
+	ProcessDueReminders(after, repo, users, deliveries, sender)
+	if sender.sent != 0 {
+		t.Fatalf("occurrences during the pause must not be delivered, got %d", sender.sent)
+	}
+
+	rem, _ := repo.GetReminder(once.ID)
+	if rem.IsActive {
+		t.Fatalf("one-time reminder that fell into the pause should be deactivated, got %+v", rem)
+	}
+	rem, _ = repo.GetReminder(daily.ID)
+	if !rem.IsActive || rem.NextTrigger == nil || !rem.NextTrigger.After(after) {
+		t.Fatalf("daily reminder should continue after the pause, got %+v", rem)
+	}
+	if rem.NextTrigger.Sub(after) > 24*time.Hour {
+		t.Fatalf("daily reminder should fire within a day after the pause, got %v", rem.NextTrigger)
+	}
+
+	ProcessDueReminders(*rem.NextTrigger, repo, users, deliveries, sender)
+	if sender.sent != 1 {
+		t.Fatalf("expected the daily reminder to be delivered after the pause, got %d", sender.sent)
+	}
+}
+
+func TestProcessDueReminders_PausedUserDefersRetries(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	users := inmemory.NewInMemoryUserRepository()
+	user, _ := users.CreateUser(123, "u", "f", "l", "en")
+	now := time.Now().Truncate(time.Minute).UTC()
+	rem, _ := repo.CreateOnceReminder(now, user, "once")
+
+	sender := &flakySender{errs: []error{&tgbotapi.Error{Code: http.StatusInternalServerError, Message: "Internal Server Error"}}}
+	ProcessDueReminders(now, repo, users, deliveries, sender)
+
+	pausedUntil := now.Add(3 * time.Hour)
+	users.SetPausedUntil(user.ID, &pausedUntil)
+
+	ProcessDueReminders(now.Add(retryBaseDelay), repo, users, deliveries, sender)
+	if sender.sent != 0 {
+		t.Fatalf("no retry should be sent while paused, got %d", sender.sent)
+	}
+	history, _ := deliveries.GetDeliveriesByReminder(rem.ID, 0)
+	if len(history) != 1 || history[0].Status != entities.DeliveryStatusRetrying || history[0].Attempts != 1 ||
+		history[0].NextRetryAt == nil || !history[0].NextRetryAt.Equal(pausedUntil) {
+		t.Fatalf("expected the retry to be deferred to the end of the pause, got %+v", history)
+	}
+
+	ProcessDueReminders(pausedUntil, repo, users, deliveries, sender)
+	if sender.sent != 1 {
+		t.Fatalf("expected the retry to be sent once the pause ends, got %d", sender.sent)
+	}
+	delivered, _ := repo.GetReminder(rem.ID)
+	if delivered.IsActive {
+		t.Fatalf("one-time reminder should be deactivated after the deferred retry, got %+v", delivered)
+	}
+}
@@ -1,7 +1,7 @@
 package notifier
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
@@ -9,6 +9,7 @@ import (
 	"github.com/ivanenkomaksym/remindme_bot/config"
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+	"github.com/ivanenkomaksym/remindme_bot/keyboards"
 	"github.com/ivanenkomaksym/remindme_bot/scheduler"
 )
 
@@ -17,21 +18,64 @@ type BotSender interface {
 	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
 }
 
-// startReminderNotifier runs a loop that checks for due reminders and notifies users
-func StartReminderNotifier(reminderRepo repositories.ReminderRepository, appConfig config.AppConfig, botConfig config.BotConfig, bot *tgbotapi.BotAPI) {
-	// Calculate the next aligned time to start
-	now := time.Now()
-	nextStart := calculateNextAlignedTime(now, appConfig.NotifierTimeout)
+// Options tunes how a notifier pass treats reminders
+type Options struct {
+	CatchUpPolicy entities.CatchUpPolicy // Fallback for reminders without their own policy
+	Tolerance     time.Duration          // Lateness that still counts as on time, normally the notifier interval
+	Owner         string                 // Name under which reminders are leased
+	LeaseDuration time.Duration          // How long a claimed reminder stays reserved for this instance
+}
+
+// dueReminderPageSize is the number of due reminders loaded per repository query
+const dueReminderPageSize = 100
+
+// maxCatchUpOccurrences caps the notifications sent for one reminder under the fire_all policy
+const maxCatchUpOccurrences = 24
+
+// defaultOptions keeps the historic behaviour of firing a missed reminder once
+var defaultOptions = Options{
+	CatchUpPolicy: entities.CatchUpFireOnce,
+	Tolerance:     time.Minute,
+	Owner:         instanceID,
+	LeaseDuration: reminderLeaseDuration,
+}
 
-	// Wait until the aligned time
-	initialDelay := nextStart.Sub(now)
-	log.Printf("Starting reminder notifier in %v (next aligned time: %v)", initialDelay.Truncate(time.Second), nextStart.Format("15:04:05"))
-	time.Sleep(initialDelay)
+// optionsFromConfig builds notifier options from the application configuration
+func optionsFromConfig(appConfig config.AppConfig) Options {
+	opts := Options{
+		CatchUpPolicy: appConfig.CatchUpPolicy,
+		Tolerance:     appConfig.NotifierTimeout,
+		Owner:         instanceID,
+		LeaseDuration: reminderLeaseDuration,
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = defaultOptions.Tolerance
+	}
+	return opts
+}
+
+// StartReminderNotifier runs a loop that notifies users about due reminders.
+// It wakes exactly when the timer's earliest reminder or digest is due, and at least on every
+// aligned NotifierTimeout boundary to pick up changes the timer has not seen.
+// It returns once ctx is cancelled, after finishing the reminder being delivered.
+func StartReminderNotifier(ctx context.Context, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, timer *Timer, appConfig config.AppConfig, botConfig config.BotConfig, bot *tgbotapi.BotAPI) {
+	nextPoll := calculateNextAlignedTime(time.Now(), appConfig.NotifierTimeout)
+	log.Printf("Starting reminder notifier (next poll at %v)", nextPoll.Format("15:04:05"))
 
-	// Now run the regular loop at aligned intervals
 	for {
-		ProcessDueRemindersWithConfig(time.Now(), reminderRepo, bot, botConfig)
-		time.Sleep(appConfig.NotifierTimeout)
+		now := time.Now()
+		ProcessDueRemindersWithConfig(ctx, now, reminderRepo, userRepo, deliveryRepo, bot, appConfig, botConfig)
+
+		if !now.Before(nextPoll) {
+			nextPoll = calculateNextAlignedTime(now, appConfig.NotifierTimeout)
+		}
+		// Reloading after every pass re-arms the timer with the advanced triggers,
+		// including on startup after a restart
+		timer.Load(reminderRepo, userRepo, now, nextPoll)
+		if !timer.Wait(ctx, nextPoll) {
+			log.Printf("Reminder notifier stopped")
+			return
+		}
 	}
 }
 
@@ -48,8 +92,7 @@ func calculateNextAlignedTime(now time.Time, interval time.Duration) time.Time {
 	// Handle day overflow
 	if nextAlignedMinute >= 24*60 {
 		// Next day
-		tomorrow := now.Add(24 * time.Hour)
-		return time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 0, 0, 0, 0, now.Location())
+		return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
 	}
 
 	// Same day
@@ -61,7 +104,7 @@ func calculateNextAlignedTime(now time.Time, interval time.Duration) time.Time {
 
 // ProcessDueReminders performs a single pass over repository reminders, sending due ones
 // and updating their next trigger. Extracted for testability.
-func ProcessDueReminders(now time.Time, reminderRepo repositories.ReminderRepository, sender BotSender) {
+func ProcessDueReminders(now time.Time, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, sender BotSender) {
 	// Monitor Telegram bot pending updates if sender is the actual bot (with default config)
 	if bot, ok := sender.(*tgbotapi.BotAPI); ok {
 		defaultBotConfig := config.BotConfig{
@@ -72,69 +115,227 @@ func ProcessDueReminders(now time.Time, reminderRepo repositories.ReminderReposi
 		monitorBotUpdatesWithConfig(bot, defaultBotConfig)
 	}
 
-	reminders, _ := reminderRepo.GetReminders()
-	for i := range reminders {
-		rem := &reminders[i]
-		if !rem.IsActive {
-			continue
+	processRetries(context.Background(), now, reminderRepo, userRepo, deliveryRepo, sender, defaultOptions)
+	processDigests(context.Background(), now, reminderRepo, userRepo, sender)
+	processReminders(context.Background(), now, reminderRepo, userRepo, deliveryRepo, sender, defaultOptions)
+}
+
+// ProcessDueRemindersWithConfig performs a single pass over repository reminders with app and bot config.
+// The pass stops early, between reminders, once ctx is cancelled.
+func ProcessDueRemindersWithConfig(ctx context.Context, now time.Time, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, sender BotSender, appConfig config.AppConfig, botConfig config.BotConfig) {
+	// Monitor Telegram bot pending updates if sender is the actual bot and monitoring is enabled
+	if bot, ok := sender.(*tgbotapi.BotAPI); ok && botConfig.MonitorPendingUpdates {
+		monitorBotUpdatesWithConfig(bot, botConfig)
+	}
+
+	opts := optionsFromConfig(appConfig)
+	processRetries(ctx, now, reminderRepo, userRepo, deliveryRepo, sender, opts)
+	processDigests(ctx, now, reminderRepo, userRepo, sender)
+	processReminders(ctx, now, reminderRepo, userRepo, deliveryRepo, sender, opts)
+}
+
+// processReminders pages through due reminders, sending every due occurrence, snoozed re-delivery
+// and nag, then advances the reminder state
+func processReminders(ctx context.Context, now time.Time, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, sender BotSender, opts Options) {
+	var after repositories.DueCursor
+	for {
+		reminders, err := reminderRepo.GetDueReminders(now, after, dueReminderPageSize)
+		if err != nil {
+			log.Printf("Failed to load due reminders: %v", err)
+			return
+		}
+		for _, due := range reminders {
+			if ctx.Err() != nil {
+				return
+			}
+			// Another instance may have delivered it since the page was loaded,
+			// so work on the state returned by the claim
+			withLease(now, due.ID, reminderRepo, opts, func(rem *entities.Reminder) {
+				processReminder(now, rem, reminderRepo, userRepo, deliveryRepo, sender, opts)
+			})
 		}
-		if rem.NextTrigger == nil || rem.NextTrigger.After(now) {
-			continue
+		if len(reminders) < dueReminderPageSize {
+			return
 		}
+		after = repositories.DueCursorAfter(reminders)
+	}
+}
+
+// processReminder handles whatever is due for a single reminder and persists its new state
+func processReminder(now time.Time, rem *entities.Reminder, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, sender BotSender, opts Options) {
+	user, err := userRepo.GetUser(rem.UserID)
+	if err != nil {
+		log.Printf("Failed to load user %d for reminder %d: %v", rem.UserID, rem.ID, err)
+	}
+	// Reminders of users who blocked the bot stay untouched until they /start again
+	if user != nil && user.Unreachable {
+		return
+	}
+	// Nothing is delivered while the user paused all reminders, and what fell into the pause is dropped after it
+	if user != nil && user.IsPaused(now) {
+		return
+	}
+	if skipPausedOccurrences(now, rem, user) {
+		reminderRepo.UpdateReminder(rem)
+		return
+	}
 
-		// Send notification
-		text := fmt.Sprintf("🔔 %s", rem.Message)
-		msg := tgbotapi.NewMessage(rem.UserID, text)
-		if _, err := sender.Send(msg); err != nil {
-			log.Printf("Failed to send reminder to user %d: %v", rem.UserID, err)
+	switch {
+	case rem.IsActive && rem.NextTrigger != nil && !rem.NextTrigger.After(now):
+		// Occurrences listed in the daily digest do not notify on their own
+		if user != nil && user.Digest.Covers(rem, *rem.NextTrigger) {
+			leaveToDigest(now, rem)
+		} else if !processDueOccurrences(now, rem, user, opts, userRepo, deliveryRepo, sender) {
+			return
 		}
 
-		// Update NextTrigger for recurring reminders
-		if rem.Recurrence != nil && rem.Recurrence.Type != entities.Once {
-			// Use StartDate for the time of day, not the previous NextTrigger
-			timeOfDay := *rem.Recurrence.StartDate
-			rem.NextTrigger = scheduler.NextForRecurrence(now, timeOfDay, rem.Recurrence)
-		} else {
-			rem.IsActive = false // deactivate one-time reminders
+	// Snoozed re-delivery and nags are independent of the recurrence and of IsActive,
+	// so a one-time reminder keeps them after deactivation.
+	case rem.IsSnoozeDue(now):
+		err := sendReminder(rem, user, entities.DeliveryKindSnooze, *rem.SnoozedUntil, now, opts, deliveryRepo, sender)
+		if isUnreachable(err) {
+			markUnreachable(rem.UserID, err, userRepo)
+			return
 		}
-		reminderRepo.UpdateReminder(rem)
+		rem.ClearSnooze()
+		if err == nil {
+			rem.StartNagging(now)
+		}
+
+	case rem.IsNagDue(now):
+		err := sendReminder(rem, user, entities.DeliveryKindNag, *rem.Nagging.NextNag, now, opts, deliveryRepo, sender)
+		if isUnreachable(err) {
+			markUnreachable(rem.UserID, err, userRepo)
+			return
+		}
+		rem.RecordNag(now)
+
+	default:
+		return
 	}
+
+	reminderRepo.UpdateReminder(rem)
 }
 
-// ProcessDueRemindersWithConfig performs a single pass over repository reminders with bot config
-func ProcessDueRemindersWithConfig(now time.Time, reminderRepo repositories.ReminderRepository, sender BotSender, botConfig config.BotConfig) {
-	// Monitor Telegram bot pending updates if sender is the actual bot and monitoring is enabled
-	if bot, ok := sender.(*tgbotapi.BotAPI); ok && botConfig.MonitorPendingUpdates {
-		monitorBotUpdatesWithConfig(bot, botConfig)
-	}
+// processDueOccurrences delivers the due occurrence of a reminder, applying its catch-up policy
+// when the occurrence was missed, and advances the schedule.
+// Returns false when the reminder must be left untouched.
+func processDueOccurrences(now time.Time, rem *entities.Reminder, user *entities.User, opts Options, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, sender BotSender) bool {
+	scheduledAt := *rem.NextTrigger
+	recurring := rem.Recurrence != nil && rem.Recurrence.Type != entities.Once
 
-	reminders, _ := reminderRepo.GetReminders()
-	for i := range reminders {
-		rem := &reminders[i]
-		if !rem.IsActive {
-			continue
+	occurrences := []time.Time{scheduledAt}
+	var next *time.Time
+	if now.Sub(scheduledAt) > opts.Tolerance {
+		switch rem.EffectiveCatchUpPolicy(opts.CatchUpPolicy) {
+		case entities.CatchUpSkip:
+			log.Printf("Skipping missed occurrence(s) of reminder %d scheduled for %v", rem.ID, scheduledAt)
+			occurrences = nil
+		case entities.CatchUpFireAll:
+			if recurring {
+				// Use StartDate for the time of day, not the previous NextTrigger
+				occurrences, next = scheduler.OccurrencesUntil(scheduledAt, now, *rem.Recurrence.StartDate, rem.Recurrence, maxCatchUpOccurrences)
+			}
 		}
-		if rem.NextTrigger == nil || rem.NextTrigger.After(now) {
-			continue
+	}
+
+	// Never fire more occurrences than the recurrence has left
+	if recurring {
+		if remaining := rem.Recurrence.RemainingOccurrences(); remaining >= 0 && len(occurrences) > remaining {
+			occurrences = occurrences[:remaining]
 		}
+	}
 
-		// Send notification
-		text := fmt.Sprintf("🔔 %s", rem.Message)
-		msg := tgbotapi.NewMessage(rem.UserID, text)
-		if _, err := sender.Send(msg); err != nil {
-			log.Printf("Failed to send reminder to user %d: %v", rem.UserID, err)
+	delivered, deadLettered := false, false
+	for i, at := range occurrences {
+		err := sendReminder(rem, user, entities.DeliveryKindScheduled, at, now, opts, deliveryRepo, sender)
+		if isUnreachable(err) {
+			markUnreachable(rem.UserID, err, userRepo)
+			if i == 0 {
+				return false
+			}
+			// Resume from the first occurrence that could not be sent
+			rem.NextTrigger = &at
+			return true
 		}
+		if recurring {
+			rem.Recurrence.RecordOccurrence()
+		}
+		delivered = delivered || err == nil
+		// A first attempt failing with a permanent error is not retried
+		deadLettered = deadLettered || (err != nil && !isRetryable(err))
+	}
 
-		// Update NextTrigger for recurring reminders
-		if rem.Recurrence != nil && rem.Recurrence.Type != entities.Once {
+	// A new occurrence supersedes any pending snooze and restarts nagging
+	rem.ClearSnooze()
+	if delivered {
+		rem.StartNagging(now)
+	}
+
+	// Update NextTrigger for recurring reminders
+	if recurring {
+		if rem.Recurrence.IsExhausted() {
+			next = nil
+		} else if next == nil {
 			// Use StartDate for the time of day, not the previous NextTrigger
 			timeOfDay := *rem.Recurrence.StartDate
-			rem.NextTrigger = scheduler.NextForRecurrence(now, timeOfDay, rem.Recurrence)
-		} else {
-			rem.IsActive = false // deactivate one-time reminders
+			next = scheduler.NextAfter(scheduledAt, now, timeOfDay, rem.Recurrence)
 		}
-		reminderRepo.UpdateReminder(rem)
+		rem.NextTrigger = next
+		if next == nil {
+			// The recurrence is exhausted or past its end date
+			rem.IsActive = false
+		}
+	} else if delivered || deadLettered || len(occurrences) == 0 {
+		rem.IsActive = false // deactivate one-time reminders, also when they cannot be delivered
+	} else {
+		// Keep a one-time reminder active until the retry queue delivers it
+		rem.NextTrigger = nil
+	}
+	return true
+}
+
+// sendReminder makes the first delivery attempt of an occurrence and records it in the
+// delivery history; failed attempts are queued for retry. Returns the send error, if any.
+func sendReminder(rem *entities.Reminder, user *entities.User, kind entities.DeliveryKind, scheduledAt, now time.Time, opts Options, deliveryRepo repositories.DeliveryRepository, sender BotSender) error {
+	delivery := entities.NewDelivery(rem, kind, scheduledAt, now)
+	err := attemptDelivery(rem, user, delivery, now, opts, sender)
+
+	if _, err := deliveryRepo.CreateDelivery(delivery); err != nil {
+		log.Printf("Failed to record delivery of reminder %d: %v", rem.ID, err)
+	}
+	return err
+}
+
+// attemptDelivery sends a reminder with snooze/done actions, or recall grades for adaptive reviews, in the user's language
+// and updates the delivery with the outcome. Late deliveries mention the original schedule.
+func attemptDelivery(rem *entities.Reminder, user *entities.User, delivery *entities.Delivery, now time.Time, opts Options, sender BotSender) error {
+	lang := ""
+	location := time.UTC
+	if user != nil {
+		lang = user.Language
+		location = user.GetLocation()
+	}
+
+	text := keyboards.FormatNotificationText(rem.Message)
+	if now.Sub(delivery.ScheduledAt) > opts.Tolerance {
+		text = keyboards.FormatLateNotificationText(rem.Message, delivery.ScheduledAt, location, lang)
+	}
+	msg := tgbotapi.NewMessage(rem.UserID, text)
+	msg.ReplyMarkup = keyboards.GetNotificationMarkup(rem.ID, lang)
+	if rem.Recurrence != nil && rem.Recurrence.IsAdaptive() {
+		msg.ReplyMarkup = keyboards.GetRecallNotificationMarkup(rem.ID, lang)
+	}
+
+	delivery.RecordAttempt(now)
+	sent, err := sender.Send(msg)
+	if err != nil {
+		log.Printf("Failed to send reminder to user %d (attempt %d): %v", rem.UserID, delivery.Attempts, err)
+		scheduleRetryOrDeadLetter(delivery, err, now)
+		return err
 	}
+	delivery.MarkSent(sent.MessageID)
+	return nil
 }
 
 // monitorBotUpdates checks for pending updates and logs them for debugging (with default config)
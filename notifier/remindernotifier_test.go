@@ -1,20 +1,26 @@
 package notifier
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/ivanenkomaksym/remindme_bot/config"
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
 	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
 	"github.com/ivanenkomaksym/remindme_bot/scheduler"
 )
 
-type fakeSender struct{ sent int }
+type fakeSender struct {
+	sent int
+	last tgbotapi.Chattable
+}
 
 func (f *fakeSender) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
 	f.sent++
+	f.last = c
 	return tgbotapi.Message{}, nil
 }
 
@@ -188,7 +194,7 @@ func TestProcessDueReminders_DailyAdvancesNextTrigger(t *testing.T) {
 	sender := &fakeSender{}
 
 	now := past.Add(1 * time.Minute)
-	ProcessDueReminders(now, repo, sender)
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
 
 	if sender.sent != 1 {
 		t.Fatalf("expected 1 message sent, got %d", sender.sent)
@@ -244,7 +250,7 @@ func TestProcessDueReminders_OneTimeDeactivates(t *testing.T) {
 
 	sender := &fakeSender{}
 
-	ProcessDueReminders(now, repo, sender)
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
 
 	if sender.sent != 1 {
 		t.Fatalf("expected 1 message sent, got %d", sender.sent)
@@ -279,7 +285,7 @@ func TestProcessDueRemindersWithConfig_MonitoringDisabled(t *testing.T) {
 	}
 
 	now := past.Add(1 * time.Minute)
-	ProcessDueRemindersWithConfig(now, repo, sender, botConfig)
+	ProcessDueRemindersWithConfig(context.Background(), now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender, config.AppConfig{NotifierTimeout: time.Minute}, botConfig)
 
 	// Should still send reminder
 	if sender.sent != 1 {
@@ -319,7 +325,7 @@ func TestProcessDueRemindersWithConfig_MonitoringEnabled(t *testing.T) {
 	}
 
 	now := past.Add(1 * time.Minute)
-	ProcessDueRemindersWithConfig(now, repo, sender, botConfig)
+	ProcessDueRemindersWithConfig(context.Background(), now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender, config.AppConfig{NotifierTimeout: time.Minute}, botConfig)
 
 	// Should still send reminder (monitoring doesn't affect core functionality)
 	if sender.sent != 1 {
@@ -336,3 +342,171 @@ func TestProcessDueRemindersWithConfig_MonitoringEnabled(t *testing.T) {
 		t.Fatalf("NextTrigger should be updated")
 	}
 }
+
+func TestProcessDueReminders_AttachesNotificationKeyboard(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	past := time.Now().Add(-time.Hour).Truncate(time.Minute).UTC()
+	rem, _ := repo.CreateDailyReminder(past, &user, "ping")
+	rem.NextTrigger = &past
+	repo.UpdateReminder(rem)
+
+	sender := &fakeSender{}
+	ProcessDueReminders(past.Add(time.Minute), repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	msg, ok := sender.last.(tgbotapi.MessageConfig)
+	if !ok {
+		t.Fatalf("expected MessageConfig, got %T", sender.last)
+	}
+	markup, ok := msg.ReplyMarkup.(*tgbotapi.InlineKeyboardMarkup)
+	if !ok || markup == nil {
+		t.Fatalf("expected inline keyboard on notification, got %T", msg.ReplyMarkup)
+	}
+	if len(markup.InlineKeyboard) != 2 || len(markup.InlineKeyboard[0]) != 3 {
+		t.Fatalf("expected 3 snooze buttons and a done row, got %v", markup.InlineKeyboard)
+	}
+}
+
+func TestProcessDueReminders_SnoozeRedeliversWithoutChangingSchedule(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Now().Truncate(time.Minute).UTC()
+	tod := now.Add(-time.Hour)
+	rem, _ := repo.CreateDailyReminder(tod, &user, "ping")
+	originalNext := *rem.NextTrigger
+	rem.Snooze(now.Add(-time.Minute))
+	repo.UpdateReminder(rem)
+
+	sender := &fakeSender{}
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != 1 {
+		t.Fatalf("expected 1 snoozed message sent, got %d", sender.sent)
+	}
+	reminders, _ := repo.GetReminders()
+	updated := reminders[0]
+	if updated.SnoozedUntil != nil {
+		t.Fatalf("expected snooze to be cleared after delivery")
+	}
+	if !updated.NextTrigger.Equal(originalNext) {
+		t.Fatalf("snooze must not change NextTrigger: want %v, got %v", originalNext, updated.NextTrigger)
+	}
+	if updated.Recurrence == nil || !updated.Recurrence.IsDaily() {
+		t.Fatalf("snooze must not change recurrence")
+	}
+}
+
+func TestProcessDueReminders_SnoozedOneTimeAfterDeactivation(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Now().Truncate(time.Minute).UTC()
+	rem, _ := repo.CreateOnceReminder(now.Add(-time.Hour), &user, "once")
+	rem.IsActive = false
+	rem.Snooze(now)
+	repo.UpdateReminder(rem)
+
+	sender := &fakeSender{}
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != 1 {
+		t.Fatalf("expected snoozed one-time reminder to be delivered, got %d", sender.sent)
+	}
+}
+
+func TestProcessDueReminders_NagsUntilMaxReached(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Now().Truncate(time.Minute).UTC()
+	rem, _ := repo.CreateOnceReminder(now, &user, "pill")
+	rem.EnableNagging(10, 2)
+	repo.UpdateReminder(rem)
+
+	sender := &fakeSender{}
+	users := inmemory.NewInMemoryUserRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+
+	// Initial delivery, then two nags, then nothing more
+	for step := 0; step <= 4; step++ {
+		ProcessDueReminders(now.Add(time.Duration(step*10)*time.Minute), repo, users, deliveries, sender)
+	}
+	if sender.sent != 3 {
+		t.Fatalf("expected initial delivery and 2 nags, got %d messages", sender.sent)
+	}
+
+	updated, _ := repo.GetReminder(rem.ID)
+	if updated.IsActive {
+		t.Fatalf("one-time reminder should be deactivated after delivery")
+	}
+	if updated.Nagging == nil || updated.Nagging.Count != 2 || updated.Nagging.NextNag != nil {
+		t.Fatalf("expected nagging to stop after max nags, got %+v", updated.Nagging)
+	}
+}
+
+func TestProcessDueReminders_AcknowledgeStopsNagging(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Now().Truncate(time.Minute).UTC()
+	rem, _ := repo.CreateOnceReminder(now, &user, "pill")
+	rem.EnableNagging(10, 5)
+	repo.UpdateReminder(rem)
+
+	sender := &fakeSender{}
+	users := inmemory.NewInMemoryUserRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	ProcessDueReminders(now, repo, users, deliveries, sender)
+
+	acked, _ := repo.GetReminder(rem.ID)
+	acked.Acknowledge()
+	repo.UpdateReminder(acked)
+
+	ProcessDueReminders(now.Add(time.Hour), repo, users, deliveries, sender)
+	if sender.sent != 1 {
+		t.Fatalf("expected no nags after acknowledgement, got %d messages", sender.sent)
+	}
+	if updated, _ := repo.GetReminder(rem.ID); !updated.IsNagging() {
+		t.Fatalf("acknowledgement must keep the reminder opted in for future occurrences")
+	}
+}
+
+func TestProcessDueReminders_RecordsDeliveryHistory(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Now().Truncate(time.Minute).UTC()
+	scheduled := now.Add(-5 * time.Minute)
+	rem, _ := repo.CreateOnceReminder(scheduled, &user, "once")
+
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), deliveries, &fakeSender{})
+
+	history, _ := deliveries.GetDeliveriesByReminder(rem.ID, 0)
+	if len(history) != 1 {
+		t.Fatalf("expected 1 delivery record, got %d", len(history))
+	}
+	d := history[0]
+	if d.Kind != entities.DeliveryKindScheduled || !d.IsSuccessful() || d.Acknowledged {
+		t.Fatalf("unexpected delivery record: %+v", d)
+	}
+	if !d.ScheduledAt.Equal(scheduled) || !d.SentAt.Equal(now) {
+		t.Fatalf("expected scheduled %v and sent %v, got %v and %v", scheduled, now, d.ScheduledAt, d.SentAt)
+	}
+}
+
+func TestProcessDueReminders_PagesThroughDueReminders(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	total := dueReminderPageSize*2 + 5
+	for i := 0; i < total; i++ {
+		repo.CreateOnceReminder(now.Add(-30*time.Second), &user, "ping")
+	}
+
+	sender := &fakeSender{}
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != total {
+		t.Fatalf("expected %d messages sent, got %d", total, sender.sent)
+	}
+	if due, _ := repo.GetDueReminders(now, repositories.DueCursor{}, 0); len(due) != 0 {
+		t.Fatalf("expected no reminders left due, got %d", len(due))
+	}
+}
@@ -0,0 +1,150 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+)
+
+// Retry policy for failed Telegram sends
+const (
+	maxDeliveryAttempts = 5
+	retryBaseDelay      = time.Minute
+	retryMaxDelay       = time.Hour
+)
+
+// processRetries re-sends failed deliveries whose backoff has elapsed
+func processRetries(ctx context.Context, now time.Time, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, sender BotSender, opts Options) {
+	retries, err := deliveryRepo.GetDueRetries(now)
+	if err != nil {
+		log.Printf("Failed to load pending delivery retries: %v", err)
+		return
+	}
+
+	for _, due := range retries {
+		if ctx.Err() != nil {
+			return
+		}
+		existing, err := reminderRepo.GetReminder(due.ReminderID)
+		if err != nil {
+			log.Printf("Failed to load reminder %d for retry: %v", due.ReminderID, err)
+			continue
+		}
+		if existing == nil {
+			due.MarkDeadLetter(errors.New("reminder no longer exists"))
+			deliveryRepo.UpdateDelivery(&due)
+			continue
+		}
+
+		withLease(now, due.ReminderID, reminderRepo, opts, func(rem *entities.Reminder) {
+			// Another instance may have retried it since the list was loaded
+			delivery, err := deliveryRepo.GetDelivery(due.ID)
+			if err != nil {
+				log.Printf("Failed to load delivery %d for retry: %v", due.ID, err)
+				return
+			}
+			if delivery == nil || !delivery.IsRetryDue(now) {
+				return
+			}
+			retryDelivery(now, rem, delivery, reminderRepo, userRepo, deliveryRepo, sender, opts)
+		})
+	}
+}
+
+// retryDelivery re-sends a single failed delivery of a claimed reminder
+func retryDelivery(now time.Time, rem *entities.Reminder, delivery *entities.Delivery, reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, deliveryRepo repositories.DeliveryRepository, sender BotSender, opts Options) {
+	user, err := userRepo.GetUser(rem.UserID)
+	if err != nil {
+		log.Printf("Failed to load user %d for retry: %v", rem.UserID, err)
+	}
+	// Pending retries wait until an unreachable user comes back
+	if user != nil && user.Unreachable {
+		return
+	}
+	// Retries of a paused user are deferred until the pause ends
+	if user != nil && user.IsPaused(now) {
+		resumeAt := *user.PausedUntil
+		delivery.NextRetryAt = &resumeAt
+		if err := deliveryRepo.UpdateDelivery(delivery); err != nil {
+			log.Printf("Failed to update delivery %d: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	err = attemptDelivery(rem, user, delivery, now, opts, sender)
+	if isUnreachable(err) {
+		markUnreachable(rem.UserID, err, userRepo)
+		// Hand a pending one-time reminder back to the schedule so it fires once the user returns
+		if rem.IsActive && rem.NextTrigger == nil {
+			scheduledAt := delivery.ScheduledAt
+			rem.NextTrigger = &scheduledAt
+			reminderRepo.UpdateReminder(rem)
+		}
+	}
+	if err == nil {
+		if delivery.Kind != entities.DeliveryKindNag {
+			rem.StartNagging(now)
+		}
+		// A one-time reminder waiting for its first successful delivery is done now
+		if awaitsRetry(rem) {
+			rem.IsActive = false
+		}
+		reminderRepo.UpdateReminder(rem)
+	} else if delivery.Status == entities.DeliveryStatusDeadLetter && awaitsRetry(rem) {
+		// Its delivery failed for good, so it must not stay pending forever
+		rem.IsActive = false
+		reminderRepo.UpdateReminder(rem)
+	}
+
+	if err := deliveryRepo.UpdateDelivery(delivery); err != nil {
+		log.Printf("Failed to update delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// awaitsRetry reports whether a one-time reminder is kept active only for the retry queue to deliver it
+func awaitsRetry(rem *entities.Reminder) bool {
+	return rem.IsActive && rem.NextTrigger == nil && (rem.Recurrence == nil || rem.Recurrence.Type == entities.Once)
+}
+
+// scheduleRetryOrDeadLetter decides what happens to a delivery after a failed attempt
+func scheduleRetryOrDeadLetter(delivery *entities.Delivery, err error, now time.Time) {
+	if !isRetryable(err) || delivery.Attempts >= maxDeliveryAttempts {
+		log.Printf("Delivery of reminder %d moved to dead letter after %d attempt(s): %v", delivery.ReminderID, delivery.Attempts, err)
+		delivery.MarkDeadLetter(err)
+		return
+	}
+	delivery.ScheduleRetry(err, now.Add(retryDelay(delivery.Attempts, err)))
+}
+
+// isRetryable reports whether a send error is transient.
+// Telegram rate limits and server errors are retried, other API errors are permanent.
+// Errors that are not Telegram API errors are network failures and are retried.
+func isRetryable(err error) bool {
+	var tgErr *tgbotapi.Error
+	if errors.As(err, &tgErr) {
+		return tgErr.Code == http.StatusTooManyRequests || tgErr.Code >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// retryDelay returns the exponential backoff for the given attempt,
+// or Telegram's retry_after when it asks to wait longer
+func retryDelay(attempt int, err error) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, retryMaxDelay)
+
+	var tgErr *tgbotapi.Error
+	if errors.As(err, &tgErr) && tgErr.RetryAfter > 0 {
+		delay = max(delay, time.Duration(tgErr.RetryAfter)*time.Second)
+	}
+	return delay
+}
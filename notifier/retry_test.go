@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+// flakySender fails with the queued errors before sending successfully
+type flakySender struct {
+	errs []error
+	sent int
+}
+
+func (f *flakySender) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return tgbotapi.Message{}, err
+	}
+	f.sent++
+	return tgbotapi.Message{MessageID: 42}, nil
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		err     error
+		want    time.Duration
+	}{
+		{"first attempt", 1, errors.New("timeout"), time.Minute},
+		{"third attempt doubles twice", 3, errors.New("timeout"), 4 * time.Minute},
+		{"capped", 20, errors.New("timeout"), retryMaxDelay},
+		{"retry_after wins when longer", 1, &tgbotapi.Error{Code: http.StatusTooManyRequests, ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 300}}, 5 * time.Minute},
+		{"backoff wins when longer", 3, &tgbotapi.Error{Code: http.StatusTooManyRequests, ResponseParameters: tgbotapi.ResponseParameters{RetryAfter: 5}}, 4 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.attempt, tt.err); got != tt.want {
+				t.Fatalf("retryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if !isRetryable(errors.New("connection reset")) {
+		t.Fatalf("network errors should be retried")
+	}
+	if !isRetryable(&tgbotapi.Error{Code: http.StatusTooManyRequests}) || !isRetryable(&tgbotapi.Error{Code: http.StatusBadGateway}) {
+		t.Fatalf("rate limits and server errors should be retried")
+	}
+	if isRetryable(&tgbotapi.Error{Code: http.StatusBadRequest, Message: "Bad Request: message text is empty"}) {
+		t.Fatalf("client errors should not be retried")
+	}
+}
+
+func TestProcessDueReminders_OneTimeStaysActiveUntilRetrySucceeds(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	users := inmemory.NewInMemoryUserRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Now().Truncate(time.Minute).UTC()
+	rem, _ := repo.CreateOnceReminder(now, &user, "once")
+
+	sender := &flakySender{errs: []error{&tgbotapi.Error{Code: http.StatusInternalServerError, Message: "Internal Server Error"}}}
+	ProcessDueReminders(now, repo, users, deliveries, sender)
+
+	pending, _ := repo.GetReminder(rem.ID)
+	if !pending.IsActive {
+		t.Fatalf("one-time reminder must stay active after a failed send")
+	}
+	history, _ := deliveries.GetDeliveriesByReminder(rem.ID, 0)
+	if len(history) != 1 || history[0].Status != entities.DeliveryStatusRetrying {
+		t.Fatalf("expected a delivery queued for retry, got %+v", history)
+	}
+
+	// Nothing is re-sent before the backoff elapses
+	ProcessDueReminders(now.Add(30*time.Second), repo, users, deliveries, sender)
+	if sender.sent != 0 {
+		t.Fatalf("retry sent before backoff elapsed")
+	}
+
+	ProcessDueReminders(now.Add(retryBaseDelay), repo, users, deliveries, sender)
+	if sender.sent != 1 {
+		t.Fatalf("expected retry to be sent once, got %d", sender.sent)
+	}
+
+	delivered, _ := repo.GetReminder(rem.ID)
+	if delivered.IsActive {
+		t.Fatalf("one-time reminder should be deactivated after successful retry")
+	}
+	history, _ = deliveries.GetDeliveriesByReminder(rem.ID, 0)
+	if len(history) != 1 || !history[0].IsSuccessful() || history[0].Attempts != 2 || history[0].MessageID != 42 {
+		t.Fatalf("expected the same delivery to succeed on attempt 2, got %+v", history)
+	}
+}
+
+func TestProcessDueReminders_DeadLetterAfterMaxAttempts(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	users := inmemory.NewInMemoryUserRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Now().Truncate(time.Minute).UTC()
+	rem, _ := repo.CreateOnceReminder(now, &user, "once")
+
+	sender := &flakySender{}
+	for i := 0; i < maxDeliveryAttempts+2; i++ {
+		sender.errs = append(sender.errs, errors.New("network unreachable"))
+	}
+
+	at := now
+	for i := 0; i < maxDeliveryAttempts+2; i++ {
+		ProcessDueReminders(at, repo, users, deliveries, sender)
+		at = at.Add(retryMaxDelay)
+	}
+
+	dead, _ := deliveries.GetDeliveriesByStatus(entities.DeliveryStatusDeadLetter)
+	if len(dead) != 1 || dead[0].ReminderID != rem.ID || dead[0].Attempts != maxDeliveryAttempts {
+		t.Fatalf("expected one dead letter after %d attempts, got %+v", maxDeliveryAttempts, dead)
+	}
+	if sender.sent != 0 {
+		t.Fatalf("dead letter must not be sent again")
+	}
+	if failed, _ := repo.GetReminder(rem.ID); failed.IsActive {
+		t.Fatalf("one-time reminder should be deactivated once its delivery is dead-lettered, got %+v", failed)
+	}
+}
+
+func TestProcessDueReminders_PermanentErrorGoesToDeadLetter(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Now().Truncate(time.Minute).UTC()
+	rem, _ := repo.CreateOnceReminder(now, &user, "once")
+
+	sender := &flakySender{errs: []error{&tgbotapi.Error{Code: http.StatusBadRequest, Message: "Bad Request"}}}
+	ProcessDueReminders(now, repo, inmemory.NewInMemoryUserRepository(), deliveries, sender)
+
+	dead, _ := deliveries.GetDeliveriesByStatus(entities.DeliveryStatusDeadLetter)
+	if len(dead) != 1 || dead[0].Attempts != 1 {
+		t.Fatalf("expected permanent error to be dead-lettered immediately, got %+v", dead)
+	}
+	if failed, _ := repo.GetReminder(rem.ID); failed.IsActive {
+		t.Fatalf("one-time reminder should be deactivated after a permanent error, got %+v", failed)
+	}
+}
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/keyboards"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+func TestProcessDueReminders_DeactivatesAfterLadder(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 7, Location: time.UTC}
+	tod := time.Date(2025, 3, 7, 9, 0, 0, 0, time.UTC)
+	rem, _ := repo.CreateSpaceBasedRepetitionReminder(tod, &user, "vocabulary")
+	rem.Recurrence.LadderStep = len(rem.Recurrence.SpacedBasedRepetitionDays)
+	rem.NextTrigger = &tod
+	repo.UpdateReminder(rem)
+	sender := &fakeSender{}
+
+	ProcessDueReminders(tod, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	if sender.sent != 1 {
+		t.Fatalf("expected the last review to be sent, got %d", sender.sent)
+	}
+	updated, _ := repo.GetReminder(rem.ID)
+	if updated.IsActive || updated.NextTrigger != nil {
+		t.Fatalf("expected the reminder to be deactivated after its ladder, got %+v", updated)
+	}
+}
+
+func TestProcessDueReminders_AdaptiveReviewOffersGrades(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 7, Location: time.UTC}
+	tod := time.Date(2025, 3, 7, 9, 0, 0, 0, time.UTC)
+	rem, _ := repo.CreateSpaceBasedRepetitionReminder(tod, &user, "vocabulary")
+	entities.WithRecall(entities.NewRecallState())(rem.Recurrence)
+	rem.NextTrigger = &tod
+	repo.UpdateReminder(rem)
+	sender := &fakeSender{}
+
+	ProcessDueReminders(tod, repo, inmemory.NewInMemoryUserRepository(), inmemory.NewInMemoryDeliveryRepository(), sender)
+
+	msg := sender.last.(tgbotapi.MessageConfig)
+	markup := msg.ReplyMarkup.(*tgbotapi.InlineKeyboardMarkup)
+	grades := markup.InlineKeyboard[len(markup.InlineKeyboard)-1]
+	if len(grades) != len(entities.RecallGrades) {
+		t.Fatalf("expected a button per grade, got %d", len(grades))
+	}
+	if action, ok := keyboards.ParseNotificationCallback(*grades[0].CallbackData); !ok || action.Grade != entities.RecallAgain || action.ReminderID != rem.ID {
+		t.Fatalf("unexpected grade callback %q", *grades[0].CallbackData)
+	}
+
+	// Until graded, the review repeats after the current interval
+	updated, _ := repo.GetReminder(rem.ID)
+	if !updated.IsActive || updated.NextTrigger == nil || !updated.NextTrigger.Equal(tod.AddDate(0, 0, 1)) {
+		t.Fatalf("expected the next review tomorrow, got %+v", updated.NextTrigger)
+	}
+}
@@ -0,0 +1,227 @@
+package notifier
+
+import (
+	"container/heap"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+)
+
+// timerKey identifies what a heap entry wakes the notifier for
+type timerKey struct {
+	id     int64
+	digest bool // id is the user whose digest is due
+}
+
+type timerEntry struct {
+	key   timerKey
+	at    time.Time
+	index int
+}
+
+// timerHeap is a min-heap of upcoming due times
+type timerHeap []*timerEntry
+
+func (h timerHeap) Len() int           { return len(h) }
+func (h timerHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x any) {
+	entry := x.(*timerEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *timerHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
+
+// Timer wakes the notifier exactly when the next reminder or digest is due.
+// It keeps a min-heap of upcoming due times, is re-armed by the reminder use case on every change
+// and is reloaded from the repository after each pass, so nothing is lost across restarts.
+// The aligned poll stays in place as a safety net for changes made by other instances.
+type Timer struct {
+	mu      sync.Mutex
+	heap    timerHeap
+	entries map[timerKey]*timerEntry
+	rearm   chan struct{}
+}
+
+// NewTimer creates an empty timer
+func NewTimer() *Timer {
+	return &Timer{
+		entries: make(map[timerKey]*timerEntry),
+		rearm:   make(chan struct{}, 1),
+	}
+}
+
+// Schedule arms the timer for the reminder's next due time, or forgets it when nothing is pending
+func (t *Timer) Schedule(reminder *entities.Reminder) {
+	at := reminder.NextDueAt()
+	if at == nil {
+		t.Unschedule(reminder.ID)
+		return
+	}
+
+	t.mu.Lock()
+	t.set(timerKey{id: reminder.ID}, *at)
+	t.mu.Unlock()
+	t.wake()
+}
+
+// Unschedule forgets a reminder
+func (t *Timer) Unschedule(reminderID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.remove(timerKey{id: reminderID})
+}
+
+// ScheduleDigest arms the timer for the user's next digest, or forgets it when the digest is off
+func (t *Timer) ScheduleDigest(user *entities.User) {
+	key := timerKey{id: user.ID, digest: true}
+	t.mu.Lock()
+	if user.Digest == nil {
+		t.remove(key)
+		t.mu.Unlock()
+		return
+	}
+	t.set(key, user.Digest.NextAt)
+	t.mu.Unlock()
+	t.wake()
+}
+
+// Next returns the earliest armed due time
+func (t *Timer) Next() (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.heap) == 0 {
+		return time.Time{}, false
+	}
+	return t.heap[0].at, true
+}
+
+// Load arms the timer for every reminder and digest due after now and up to until, and drops
+// entries that are already due: a notifier pass has just handled them, and whatever is still
+// due is left for the next poll rather than waking the notifier in a loop.
+func (t *Timer) Load(reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, now, until time.Time) {
+	t.mu.Lock()
+	for len(t.heap) > 0 && !t.heap[0].at.After(now) {
+		entry := heap.Pop(&t.heap).(*timerEntry)
+		delete(t.entries, entry.key)
+	}
+	t.mu.Unlock()
+
+	t.loadDigests(userRepo, now, until)
+	t.loadReminders(reminderRepo, now, until)
+}
+
+// loadDigests arms the timer for the digests due after now and up to until
+func (t *Timer) loadDigests(userRepo repositories.UserRepository, now, until time.Time) {
+	users, err := userRepo.GetUsersWithDueDigest(until)
+	if err != nil {
+		log.Printf("Failed to load upcoming digests: %v", err)
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, user := range users {
+		if user.Digest.NextAt.After(now) {
+			t.set(timerKey{id: user.ID, digest: true}, user.Digest.NextAt)
+		}
+	}
+}
+
+// loadReminders arms the timer for the reminders due after now and up to until
+func (t *Timer) loadReminders(reminderRepo repositories.ReminderRepository, now, until time.Time) {
+	var after repositories.DueCursor
+	for {
+		reminders, err := reminderRepo.GetDueReminders(until, after, dueReminderPageSize)
+		if err != nil {
+			log.Printf("Failed to load upcoming reminders: %v", err)
+			return
+		}
+		t.mu.Lock()
+		for i := range reminders {
+			if at := reminders[i].NextDueAt(); at != nil && at.After(now) {
+				t.set(timerKey{id: reminders[i].ID}, *at)
+			}
+		}
+		t.mu.Unlock()
+		if len(reminders) < dueReminderPageSize {
+			return
+		}
+		after = repositories.DueCursorAfter(reminders)
+	}
+}
+
+// Wait blocks until the earliest armed due time or the deadline, whichever comes first.
+// Scheduling an earlier reminder while waiting shortens the wait.
+// It returns false if ctx is cancelled before then.
+func (t *Timer) Wait(ctx context.Context, deadline time.Time) bool {
+	for {
+		if ctx.Err() != nil {
+			return false
+		}
+		target := deadline
+		if next, ok := t.Next(); ok && next.Before(target) {
+			target = next
+		}
+		delay := time.Until(target)
+		if delay <= 0 {
+			return true
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+			return true
+		case <-t.rearm:
+			timer.Stop()
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		}
+	}
+}
+
+// set inserts or moves a heap entry; the caller holds the lock
+func (t *Timer) set(key timerKey, at time.Time) {
+	if entry, ok := t.entries[key]; ok {
+		entry.at = at
+		heap.Fix(&t.heap, entry.index)
+		return
+	}
+	entry := &timerEntry{key: key, at: at}
+	heap.Push(&t.heap, entry)
+	t.entries[key] = entry
+}
+
+// remove drops a heap entry; the caller holds the lock
+func (t *Timer) remove(key timerKey) {
+	if entry, ok := t.entries[key]; ok {
+		heap.Remove(&t.heap, entry.index)
+		delete(t.entries, key)
+	}
+}
+
+// wake interrupts a pending Wait so it picks up the new earliest due time
+func (t *Timer) wake() {
+	select {
+	case t.rearm <- struct{}{}:
+	default:
+	}
+}
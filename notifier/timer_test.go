@@ -0,0 +1,128 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+func TestTimer_ScheduleKeepsEarliestFirst(t *testing.T) {
+	timer := NewTimer()
+	base := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+
+	timer.Schedule(&entities.Reminder{ID: 1, IsActive: true, NextTrigger: at(3 * time.Minute)})
+	timer.Schedule(&entities.Reminder{ID: 2, IsActive: true, NextTrigger: at(7 * time.Minute)})
+	timer.Schedule(&entities.Reminder{ID: 3, IsActive: true, NextTrigger: at(5 * time.Minute)})
+	if next, _ := timer.Next(); !next.Equal(*at(3 * time.Minute)) {
+		t.Fatalf("expected 09:03 first, got %v", next)
+	}
+
+	// Moving a reminder later re-orders the heap
+	timer.Schedule(&entities.Reminder{ID: 1, IsActive: true, NextTrigger: at(10 * time.Minute)})
+	if next, _ := timer.Next(); !next.Equal(*at(5 * time.Minute)) {
+		t.Fatalf("expected 09:05 first, got %v", next)
+	}
+
+	// A deactivated reminder and a deleted one are forgotten
+	timer.Schedule(&entities.Reminder{ID: 3, IsActive: false, NextTrigger: at(5 * time.Minute)})
+	timer.Unschedule(2)
+	if next, _ := timer.Next(); !next.Equal(*at(10 * time.Minute)) {
+		t.Fatalf("expected 09:10 first, got %v", next)
+	}
+}
+
+func TestTimer_LoadArmsUpcomingReminders(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	user := entities.User{ID: 123, Location: time.UTC}
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	repo.CreateOnceReminder(now.Add(-time.Minute), &user, "still due")
+	repo.CreateOnceReminder(now.Add(7*time.Minute), &user, "09:07")
+	repo.CreateOnceReminder(now.Add(time.Hour), &user, "after the next poll")
+
+	timer := NewTimer()
+	timer.Schedule(&entities.Reminder{ID: 99, IsActive: true, NextTrigger: &now})
+	timer.Load(repo, inmemory.NewInMemoryUserRepository(), now, now.Add(15*time.Minute))
+
+	next, ok := timer.Next()
+	if !ok || !next.Equal(now.Add(7*time.Minute)) {
+		t.Fatalf("expected the timer to wake at 09:07, got %v", next)
+	}
+	if len(timer.heap) != 1 {
+		t.Fatalf("expected only the reminder due before the next poll, got %d entries", len(timer.heap))
+	}
+}
+
+func TestTimer_LoadArmsUpcomingDigests(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	users := inmemory.NewInMemoryUserRepository()
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	users.CreateUser(1, "u", "f", "l", "en")
+	users.CreateUser(2, "u", "f", "l", "en")
+	users.SetDigest(1, &entities.Digest{Time: "09:05", NextAt: now.Add(5 * time.Minute)})
+	users.SetDigest(2, &entities.Digest{Time: "10:00", NextAt: now.Add(time.Hour)})
+	user := entities.User{ID: 1, Location: time.UTC}
+	repo.CreateOnceReminder(now.Add(7*time.Minute), &user, "09:07")
+
+	timer := NewTimer()
+	timer.Load(repo, users, now, now.Add(15*time.Minute))
+
+	next, ok := timer.Next()
+	if !ok || !next.Equal(now.Add(5*time.Minute)) {
+		t.Fatalf("expected the timer to wake for the 09:05 digest, got %v", next)
+	}
+	if len(timer.heap) != 2 {
+		t.Fatalf("expected the digest and the reminder due before the next poll, got %d entries", len(timer.heap))
+	}
+
+	// A digest and a reminder with the same ID are armed separately
+	timer.Unschedule(1)
+	if next, _ := timer.Next(); !next.Equal(now.Add(5 * time.Minute)) {
+		t.Fatalf("expected the digest to stay armed, got %v", next)
+	}
+
+	// Moving or turning off a digest re-arms the timer without waiting for the next load
+	timer.ScheduleDigest(&entities.User{ID: 1, Digest: &entities.Digest{Time: "09:02", NextAt: now.Add(2 * time.Minute)}})
+	if next, _ := timer.Next(); !next.Equal(now.Add(2 * time.Minute)) {
+		t.Fatalf("expected the moved digest first, got %v", next)
+	}
+	timer.ScheduleDigest(&entities.User{ID: 1})
+	if len(timer.heap) != 0 {
+		t.Fatalf("expected the digest to be forgotten, got %d entries", len(timer.heap))
+	}
+}
+
+func TestTimer_WaitWakesForEarlierReminder(t *testing.T) {
+	timer := NewTimer()
+	done := make(chan struct{})
+	go func() {
+		timer.Wait(context.Background(), time.Now().Add(time.Minute))
+		close(done)
+	}()
+
+	soon := time.Now().Add(20 * time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	timer.Schedule(&entities.Reminder{ID: 1, IsActive: true, NextTrigger: &soon})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected Wait to return at the newly scheduled reminder")
+	}
+}
+
+func TestTimer_WaitStopsOnCancel(t *testing.T) {
+	timer := NewTimer()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(5*time.Millisecond, cancel)
+
+	if timer.Wait(ctx, time.Now().Add(time.Minute)) {
+		t.Fatalf("expected Wait to report cancellation")
+	}
+}
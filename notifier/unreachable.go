@@ -0,0 +1,32 @@
+package notifier
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+)
+
+// isUnreachable reports whether Telegram refused the message because the user can no longer
+// receive it: the bot was blocked, the account was deactivated or the chat does not exist.
+func isUnreachable(err error) bool {
+	var tgErr *tgbotapi.Error
+	if !errors.As(err, &tgErr) {
+		return false
+	}
+	if tgErr.Code == http.StatusForbidden {
+		return true
+	}
+	return tgErr.Code == http.StatusBadRequest && strings.Contains(strings.ToLower(tgErr.Message), "chat not found")
+}
+
+// markUnreachable pauses delivery to a user until they send /start again
+func markUnreachable(userID int64, err error, userRepo repositories.UserRepository) {
+	log.Printf("User %d is unreachable, pausing their reminders: %v", userID, err)
+	if err := userRepo.MarkUnreachable(userID, err.Error()); err != nil {
+		log.Printf("Failed to mark user %d as unreachable: %v", userID, err)
+	}
+}
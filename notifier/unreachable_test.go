@@ -0,0 +1,77 @@
+package notifier
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/ivanenkomaksym/remindme_bot/repositories/inmemory"
+)
+
+func TestIsUnreachable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"blocked", &tgbotapi.Error{Code: http.StatusForbidden, Message: "Forbidden: bot was blocked by the user"}, true},
+		{"deactivated", &tgbotapi.Error{Code: http.StatusForbidden, Message: "Forbidden: user is deactivated"}, true},
+		{"chat not found", &tgbotapi.Error{Code: http.StatusBadRequest, Message: "Bad Request: chat not found"}, true},
+		{"other bad request", &tgbotapi.Error{Code: http.StatusBadRequest, Message: "Bad Request: message is too long"}, false},
+		{"rate limited", &tgbotapi.Error{Code: http.StatusTooManyRequests}, false},
+		{"network", errors.New("connection reset"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUnreachable(tt.err); got != tt.want {
+				t.Fatalf("isUnreachable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessDueReminders_BlockedUserPausesReminders(t *testing.T) {
+	repo := inmemory.NewInMemoryReminderRepository()
+	deliveries := inmemory.NewInMemoryDeliveryRepository()
+	users := inmemory.NewInMemoryUserRepository()
+	user, _ := users.CreateUser(123, "u", "f", "l", "en")
+	now := time.Now().Truncate(time.Minute).UTC()
+	once, _ := repo.CreateOnceReminder(now, user, "once")
+	daily, _ := repo.CreateDailyReminder(now, user, "daily")
+	daily.NextTrigger = &now
+	repo.UpdateReminder(daily)
+
+	blocked := &tgbotapi.Error{Code: http.StatusForbidden, Message: "Forbidden: bot was blocked by the user"}
+	sender := &flakySender{errs: []error{blocked}}
+	ProcessDueReminders(now, repo, users, deliveries, sender)
+
+	stored, _ := users.GetUser(user.ID)
+	if !stored.Unreachable || stored.UnreachableReason == "" {
+		t.Fatalf("expected user to be marked unreachable, got %+v", stored)
+	}
+	if sender.sent != 0 {
+		t.Fatalf("no reminders should be sent to an unreachable user, got %d", sender.sent)
+	}
+	for _, id := range []int64{once.ID, daily.ID} {
+		rem, _ := repo.GetReminder(id)
+		if !rem.IsActive || rem.NextTrigger == nil || !rem.NextTrigger.Equal(now) {
+			t.Fatalf("paused reminder %d must keep its schedule, got %+v", id, rem)
+		}
+	}
+
+	// Later ticks do not keep hammering Telegram
+	ProcessDueReminders(now.Add(time.Hour), repo, users, deliveries, sender)
+	if history, _ := deliveries.GetDeliveriesByReminder(once.ID, 0); len(history) > 1 {
+		t.Fatalf("expected no further attempts while unreachable, got %d", len(history))
+	}
+
+	// Coming back with /start resumes delivery
+	users.MarkReachable(user.ID)
+	ProcessDueReminders(now.Add(2*time.Hour), repo, users, deliveries, sender)
+	if sender.sent != 2 {
+		t.Fatalf("expected both reminders to be delivered after resume, got %d", sender.sent)
+	}
+}
@@ -0,0 +1,142 @@
+package inmemory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+)
+
+type InMemoryDeliveryRepository struct {
+	mu         sync.RWMutex
+	nextID     int64
+	deliveries []entities.Delivery
+}
+
+func NewInMemoryDeliveryRepository() repositories.DeliveryRepository {
+	return &InMemoryDeliveryRepository{
+		nextID:     1,
+		deliveries: make([]entities.Delivery, 0),
+	}
+}
+
+func (r *InMemoryDeliveryRepository) CreateDelivery(delivery *entities.Delivery) (*entities.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery.ID = r.nextID
+	r.nextID++
+	r.deliveries = append(r.deliveries, *delivery)
+
+	deliveryCopy := *delivery
+	return &deliveryCopy, nil
+}
+
+func (r *InMemoryDeliveryRepository) UpdateDelivery(delivery *entities.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.deliveries {
+		if r.deliveries[i].ID == delivery.ID {
+			r.deliveries[i] = *delivery
+			return nil
+		}
+	}
+	return nil // Delivery not found, nothing to update
+}
+
+func (r *InMemoryDeliveryRepository) GetDelivery(deliveryID int64) (*entities.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, d := range r.deliveries {
+		if d.ID == deliveryID {
+			deliveryCopy := d
+			return &deliveryCopy, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *InMemoryDeliveryRepository) GetDeliveriesByReminder(reminderID int64, limit int) ([]entities.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]entities.Delivery, 0)
+	for _, d := range r.deliveries {
+		if d.ReminderID == reminderID {
+			result = append(result, d)
+		}
+	}
+	sortNewestFirst(result)
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *InMemoryDeliveryRepository) GetDueRetries(now time.Time) ([]entities.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]entities.Delivery, 0)
+	for _, d := range r.deliveries {
+		if d.IsRetryDue(now) {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (r *InMemoryDeliveryRepository) GetDeliveriesByStatus(status entities.DeliveryStatus) ([]entities.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]entities.Delivery, 0)
+	for _, d := range r.deliveries {
+		if d.Status == status {
+			result = append(result, d)
+		}
+	}
+	sortNewestFirst(result)
+	return result, nil
+}
+
+func (r *InMemoryDeliveryRepository) AcknowledgeDeliveries(reminderID int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.deliveries {
+		d := &r.deliveries[i]
+		if d.ReminderID == reminderID && d.IsSuccessful() && !d.Acknowledged {
+			d.Acknowledge(at)
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryDeliveryRepository) DeleteDeliveriesByReminder(reminderID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.deliveries[:0]
+	for _, d := range r.deliveries {
+		if d.ReminderID != reminderID {
+			kept = append(kept, d)
+		}
+	}
+	r.deliveries = kept
+	return nil
+}
+
+func sortNewestFirst(deliveries []entities.Delivery) {
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].SentAt.Equal(deliveries[j].SentAt) {
+			return deliveries[i].SentAt.After(deliveries[j].SentAt)
+		}
+		// Catch-up deliveries share a send time, order them by schedule
+		return deliveries[i].ScheduledAt.After(deliveries[j].ScheduledAt)
+	})
+}
@@ -0,0 +1,68 @@
+package inmemory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestDeliveryRepository_HistoryNewestFirst(t *testing.T) {
+	repo := NewInMemoryDeliveryRepository()
+	rem := &entities.Reminder{ID: 1, UserID: 10}
+	base := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		at := base.Add(time.Duration(i) * time.Hour)
+		d := entities.NewDelivery(rem, entities.DeliveryKindScheduled, at, at)
+		d.MarkSent(100 + i)
+		if _, err := repo.CreateDelivery(d); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	other := entities.NewDelivery(&entities.Reminder{ID: 2, UserID: 10}, entities.DeliveryKindScheduled, base, base)
+	repo.CreateDelivery(other)
+
+	history, _ := repo.GetDeliveriesByReminder(1, 0)
+	if len(history) != 3 {
+		t.Fatalf("expected 3 deliveries, got %d", len(history))
+	}
+	if history[0].MessageID != 102 || history[2].MessageID != 100 {
+		t.Fatalf("expected newest first, got message IDs %d..%d", history[0].MessageID, history[2].MessageID)
+	}
+
+	limited, _ := repo.GetDeliveriesByReminder(1, 2)
+	if len(limited) != 2 {
+		t.Fatalf("expected limit to apply, got %d", len(limited))
+	}
+}
+
+func TestDeliveryRepository_AcknowledgeOnlySuccessful(t *testing.T) {
+	repo := NewInMemoryDeliveryRepository()
+	rem := &entities.Reminder{ID: 1, UserID: 10}
+	now := time.Now()
+
+	sent := entities.NewDelivery(rem, entities.DeliveryKindScheduled, now, now)
+	sent.MarkSent(1)
+	repo.CreateDelivery(sent)
+	failed := entities.NewDelivery(rem, entities.DeliveryKindNag, now, now.Add(time.Minute))
+	failed.MarkDeadLetter(errors.New("boom"))
+	repo.CreateDelivery(failed)
+
+	if err := repo.AcknowledgeDeliveries(1, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	history, _ := repo.GetDeliveriesByReminder(1, 0)
+	for _, d := range history {
+		if d.IsSuccessful() != d.Acknowledged {
+			t.Fatalf("delivery %d: successful=%v acknowledged=%v", d.ID, d.IsSuccessful(), d.Acknowledged)
+		}
+	}
+
+	repo.DeleteDeliveriesByReminder(1)
+	if history, _ := repo.GetDeliveriesByReminder(1, 0); len(history) != 0 {
+		t.Fatalf("expected history to be deleted, got %d", len(history))
+	}
+}
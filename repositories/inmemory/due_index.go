@@ -0,0 +1,75 @@
+package inmemory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+)
+
+type dueEntry struct {
+	at time.Time
+	id int64
+}
+
+// before orders entries by due time, then by ID
+func (e dueEntry) before(other dueEntry) bool {
+	return e.at.Before(other.at) || e.at.Equal(other.at) && e.id < other.id
+}
+
+// dueIndex keeps reminders ordered by the earliest time the notifier has work for them,
+// so due reminders can be found without scanning the whole repository
+type dueIndex struct {
+	entries []dueEntry          // Ordered by due time, then ID
+	byID    map[int64]time.Time // Indexed due time of each reminder, to find its entry
+}
+
+// set (re)indexes a reminder after it was created or changed
+func (d *dueIndex) set(rem *entities.Reminder) {
+	d.remove(rem.ID)
+	at := rem.NextDueAt()
+	if at == nil {
+		return
+	}
+	if d.byID == nil {
+		d.byID = make(map[int64]time.Time)
+	}
+	entry := dueEntry{at: *at, id: rem.ID}
+	i := d.search(entry)
+	d.entries = append(d.entries, dueEntry{})
+	copy(d.entries[i+1:], d.entries[i:])
+	d.entries[i] = entry
+	d.byID[rem.ID] = entry.at
+}
+
+// remove drops a reminder from the index
+func (d *dueIndex) remove(id int64) {
+	at, ok := d.byID[id]
+	if !ok {
+		return
+	}
+	i := d.search(dueEntry{at: at, id: id})
+	d.entries = append(d.entries[:i], d.entries[i+1:]...)
+	delete(d.byID, id)
+}
+
+// search returns the position of the first entry not before the given one
+func (d *dueIndex) search(entry dueEntry) int {
+	return sort.Search(len(d.entries), func(i int) bool { return !d.entries[i].before(entry) })
+}
+
+// dueIDs returns the IDs of up to limit reminders due at now that come after the cursor, in order
+func (d *dueIndex) dueIDs(now time.Time, after repositories.DueCursor, limit int) []int64 {
+	cursor := dueEntry{at: after.At, id: after.ID}
+	start := sort.Search(len(d.entries), func(i int) bool { return cursor.before(d.entries[i]) })
+
+	var ids []int64
+	for _, entry := range d.entries[start:] {
+		if entry.at.After(now) || (limit > 0 && len(ids) == limit) {
+			break
+		}
+		ids = append(ids, entry.id)
+	}
+	return ids
+}
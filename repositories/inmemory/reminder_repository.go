@@ -1,6 +1,7 @@
 package inmemory
 
 import (
+	"slices"
 	"sync"
 	"time"
 
@@ -13,12 +14,23 @@ type InMemoryReminderRepository struct {
 	mu        sync.RWMutex
 	nextID    int64
 	reminders []entities.Reminder
+	positions map[int64]int // Index of each reminder in reminders
+	due       dueIndex
+	leases    map[int64]reminderLease
+}
+
+// reminderLease records which notifier instance holds a reminder and until when
+type reminderLease struct {
+	owner string
+	until time.Time
 }
 
 func NewInMemoryReminderRepository() repositories.ReminderRepository {
 	return &InMemoryReminderRepository{
 		nextID:    1,
 		reminders: make([]entities.Reminder, 0),
+		positions: make(map[int64]int),
+		leases:    make(map[int64]reminderLease),
 	}
 }
 
@@ -31,9 +43,7 @@ func (r *InMemoryReminderRepository) CreateOnceReminder(dateTime time.Time, user
 	nextTrigger := *recurrence.StartDate
 	reminder := entities.NewReminder(r.nextID, user.ID, message, recurrence, &nextTrigger)
 	r.nextID++
-	r.reminders = append(r.reminders, *reminder)
-
-	return &r.reminders[len(r.reminders)-1], nil
+	return r.add(reminder), nil
 }
 
 func (r *InMemoryReminderRepository) CreateDailyReminder(timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error) {
@@ -47,9 +57,7 @@ func (r *InMemoryReminderRepository) CreateDailyReminder(timeOfDay time.Time, us
 
 	reminder := entities.NewReminder(r.nextID, user.ID, message, recurrence, &next)
 	r.nextID++
-	r.reminders = append(r.reminders, *reminder)
-
-	return &r.reminders[len(r.reminders)-1], nil
+	return r.add(reminder), nil
 }
 
 func (r *InMemoryReminderRepository) CreateWeeklyReminder(daysOfWeek []time.Weekday, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error) {
@@ -63,9 +71,7 @@ func (r *InMemoryReminderRepository) CreateWeeklyReminder(daysOfWeek []time.Week
 	recurrence := entities.CustomWeekly(daysOfWeek, timeOfDay, loc)
 	reminder := entities.NewReminder(r.nextID, user.ID, message, recurrence, &next)
 	r.nextID++
-	r.reminders = append(r.reminders, *reminder)
-
-	return &r.reminders[len(r.reminders)-1], nil
+	return r.add(reminder), nil
 }
 
 func (r *InMemoryReminderRepository) CreateMonthlyReminder(daysOfMonth []int, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error) {
@@ -78,27 +84,21 @@ func (r *InMemoryReminderRepository) CreateMonthlyReminder(daysOfMonth []int, ti
 	recurrence := entities.MonthlyOnDay(daysOfMonth, timeOfDay, loc)
 	reminder := entities.NewReminder(r.nextID, user.ID, message, recurrence, &next)
 	r.nextID++
-	r.reminders = append(r.reminders, *reminder)
-
-	return &r.reminders[len(r.reminders)-1], nil
+	return r.add(reminder), nil
 }
 
-func (r *InMemoryReminderRepository) CreateIntervalReminder(intervalDays int, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error) {
+func (r *InMemoryReminderRepository) CreateIntervalReminder(interval int, unit entities.IntervalUnit, window *entities.ActiveWindow, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	now := time.Now()
 	loc := user.GetLocation()
-	// Next trigger is N days from now at specified time
-	base := scheduler.NextDailyTrigger(now, timeOfDay, loc)
-	next := base.Add(time.Duration(intervalDays-1) * 24 * time.Hour)
+	recurrence := entities.IntervalEvery(interval, unit, window, timeOfDay, loc)
+	next := scheduler.FirstIntervalTrigger(now, timeOfDay, recurrence)
 
-	recurrence := entities.IntervalEveryDays(intervalDays, timeOfDay, loc)
 	reminder := entities.NewReminder(r.nextID, user.ID, message, recurrence, &next)
 	r.nextID++
-	r.reminders = append(r.reminders, *reminder)
-
-	return &r.reminders[len(r.reminders)-1], nil
+	return r.add(reminder), nil
 }
 
 func (r *InMemoryReminderRepository) CreateSpaceBasedRepetitionReminder(timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error) {
@@ -112,9 +112,24 @@ func (r *InMemoryReminderRepository) CreateSpaceBasedRepetitionReminder(timeOfDa
 
 	reminder := entities.NewReminder(r.nextID, user.ID, message, recurrence, next)
 	r.nextID++
-	r.reminders = append(r.reminders, *reminder)
+	return r.add(reminder), nil
+}
+
+func (r *InMemoryReminderRepository) CreateReminder(reminder *entities.Reminder) (*entities.Reminder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reminder.ID = r.nextID
+	r.nextID++
+	return r.add(reminder), nil
+}
 
-	return &r.reminders[len(r.reminders)-1], nil
+// add stores a new reminder and indexes it
+func (r *InMemoryReminderRepository) add(reminder *entities.Reminder) *entities.Reminder {
+	r.positions[reminder.ID] = len(r.reminders)
+	r.reminders = append(r.reminders, *cloneReminder(reminder))
+	r.due.set(reminder)
+	return cloneReminder(reminder)
 }
 
 // Reminder retrieval methods
@@ -122,8 +137,10 @@ func (r *InMemoryReminderRepository) GetReminders() ([]entities.Reminder, error)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	out := make([]entities.Reminder, len(r.reminders))
-	copy(out, r.reminders)
+	out := make([]entities.Reminder, 0, len(r.reminders))
+	for i := range r.reminders {
+		out = append(out, *cloneReminder(&r.reminders[i]))
+	}
 	return out, nil
 }
 
@@ -132,9 +149,9 @@ func (r *InMemoryReminderRepository) GetRemindersByUser(userID int64) ([]entitie
 	defer r.mu.RUnlock()
 
 	result := make([]entities.Reminder, 0)
-	for _, rem := range r.reminders {
-		if rem.UserID == userID {
-			result = append(result, rem)
+	for i := range r.reminders {
+		if r.reminders[i].UserID == userID {
+			result = append(result, *cloneReminder(&r.reminders[i]))
 		}
 	}
 	return result, nil
@@ -144,10 +161,9 @@ func (r *InMemoryReminderRepository) GetReminder(reminderID int64) (*entities.Re
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	for _, rem := range r.reminders {
-		if rem.ID == reminderID {
-			remCopy := rem
-			return &remCopy, nil
+	for i := range r.reminders {
+		if r.reminders[i].ID == reminderID {
+			return cloneReminder(&r.reminders[i]), nil
 		}
 	}
 	return nil, nil
@@ -160,7 +176,8 @@ func (r *InMemoryReminderRepository) UpdateReminder(reminder *entities.Reminder)
 
 	for i := range r.reminders {
 		if r.reminders[i].ID == reminder.ID {
-			r.reminders[i] = *reminder
+			r.reminders[i] = *cloneReminder(reminder)
+			r.due.set(reminder)
 			return nil
 		}
 	}
@@ -174,8 +191,13 @@ func (r *InMemoryReminderRepository) DeleteReminder(reminderID int64, userID int
 	for i, rem := range r.reminders {
 		if rem.ID == reminderID && rem.UserID == userID {
 			// Delete without preserving order
-			r.reminders[i] = r.reminders[len(r.reminders)-1]
-			r.reminders = r.reminders[:len(r.reminders)-1]
+			last := len(r.reminders) - 1
+			r.reminders[i] = r.reminders[last]
+			r.positions[r.reminders[i].ID] = i
+			r.reminders = r.reminders[:last]
+			delete(r.positions, reminderID)
+			r.due.remove(reminderID)
+			delete(r.leases, reminderID)
 			return nil
 		}
 	}
@@ -189,6 +211,7 @@ func (r *InMemoryReminderRepository) DeactivateReminder(reminderID int64, userID
 	for i := range r.reminders {
 		if r.reminders[i].ID == reminderID && r.reminders[i].UserID == userID {
 			r.reminders[i].Deactivate()
+			r.due.set(&r.reminders[i])
 			return nil
 		}
 	}
@@ -201,14 +224,26 @@ func (r *InMemoryReminderRepository) GetActiveReminders() ([]entities.Reminder,
 	defer r.mu.RUnlock()
 
 	result := make([]entities.Reminder, 0)
-	for _, rem := range r.reminders {
-		if rem.IsActive {
-			result = append(result, rem)
+	for i := range r.reminders {
+		if r.reminders[i].IsActive {
+			result = append(result, *cloneReminder(&r.reminders[i]))
 		}
 	}
 	return result, nil
 }
 
+func (r *InMemoryReminderRepository) GetDueReminders(now time.Time, after repositories.DueCursor, limit int) ([]entities.Reminder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.due.dueIDs(now, after, limit)
+	result := make([]entities.Reminder, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, *cloneReminder(&r.reminders[r.positions[id]]))
+	}
+	return result, nil
+}
+
 func (r *InMemoryReminderRepository) UpdateNextTrigger(reminderID int64, nextTrigger time.Time) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -216,8 +251,82 @@ func (r *InMemoryReminderRepository) UpdateNextTrigger(reminderID int64, nextTri
 	for i := range r.reminders {
 		if r.reminders[i].ID == reminderID {
 			r.reminders[i].UpdateNextTrigger(&nextTrigger)
+			r.due.set(&r.reminders[i])
 			return nil
 		}
 	}
 	return nil // Reminder not found, nothing to update
 }
+
+// Reminder leasing methods
+func (r *InMemoryReminderRepository) ClaimReminder(reminderID int64, owner string, now time.Time, leaseFor time.Duration) (*entities.Reminder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lease, ok := r.leases[reminderID]; ok && lease.until.After(now) {
+		return nil, nil
+	}
+	for i := range r.reminders {
+		if r.reminders[i].ID == reminderID {
+			r.leases[reminderID] = reminderLease{owner: owner, until: now.Add(leaseFor)}
+			return cloneReminder(&r.reminders[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *InMemoryReminderRepository) ReleaseReminder(reminderID int64, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lease, ok := r.leases[reminderID]; ok && lease.owner == owner {
+		delete(r.leases, reminderID)
+	}
+	return nil
+}
+
+// cloneReminder deep-copies a reminder, so that the reminders handed out and the ones stored
+// never share their recurrence, nagging or its slices and callers only change state through the repository
+func cloneReminder(reminder *entities.Reminder) *entities.Reminder {
+	clone := *reminder
+	clone.NextTrigger = cloneTime(reminder.NextTrigger)
+	clone.SnoozedUntil = cloneTime(reminder.SnoozedUntil)
+	if reminder.Nagging != nil {
+		nagging := *reminder.Nagging
+		nagging.NextNag = cloneTime(nagging.NextNag)
+		clone.Nagging = &nagging
+	}
+	if reminder.Recurrence != nil {
+		rec := *reminder.Recurrence
+		rec.Weekdays = slices.Clone(rec.Weekdays)
+		rec.DayOfMonth = slices.Clone(rec.DayOfMonth)
+		rec.StartDate = cloneTime(rec.StartDate)
+		rec.EndDate = cloneTime(rec.EndDate)
+		rec.SpacedBasedRepetitionDays = slices.Clone(rec.SpacedBasedRepetitionDays)
+		rec.TimesOfDay = slices.Clone(rec.TimesOfDay)
+		rec.NthWeekdays = slices.Clone(rec.NthWeekdays)
+		if rec.ActiveWindow != nil {
+			window := *rec.ActiveWindow
+			rec.ActiveWindow = &window
+		}
+		if rec.BusinessDays != nil {
+			businessDays := *rec.BusinessDays
+			businessDays.ExcludedDates = slices.Clone(businessDays.ExcludedDates)
+			rec.BusinessDays = &businessDays
+		}
+		if rec.Recall != nil {
+			recall := *rec.Recall
+			rec.Recall = &recall
+		}
+		clone.Recurrence = &rec
+	}
+	return &clone
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
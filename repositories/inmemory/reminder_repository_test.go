@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
 )
 
 func TestCreateDailyReminder_Happy(t *testing.T) {
@@ -133,7 +134,7 @@ func TestCreateIntervalReminder_Happy(t *testing.T) {
 	user := entities.User{ID: 7, UserName: "tester", Location: loc}
 	intervalDays := 3
 	todI, _ := time.ParseInLocation("15:04", "08:20", loc)
-	rem, _ := repo.CreateIntervalReminder(intervalDays, todI, &user, "interval msg")
+	rem, _ := repo.CreateIntervalReminder(intervalDays, entities.IntervalUnitDays, nil, todI, &user, "interval msg")
 
 	if rem == nil {
 		t.Fatalf("expected reminder, got nil")
@@ -159,7 +160,7 @@ func TestCreateIntervalReminder_OneDayBehavesDaily(t *testing.T) {
 	loc, _ := time.LoadLocation("Asia/Shanghai")
 	user := entities.User{ID: 8, UserName: "tester", Location: loc}
 	tod1, _ := time.ParseInLocation("15:04", "05:05", loc)
-	rem, _ := repo.CreateIntervalReminder(1, tod1, &user, "interval 1d")
+	rem, _ := repo.CreateIntervalReminder(1, entities.IntervalUnitDays, nil, tod1, &user, "interval 1d")
 	if rem == nil {
 		t.Fatalf("expected reminder, got nil")
 	}
@@ -193,6 +194,37 @@ func TestGetReminders_ReturnsCopy(t *testing.T) {
 	}
 }
 
+func TestGetReminder_ReturnsDeepCopy(t *testing.T) {
+	repo := NewInMemoryReminderRepository()
+	user := entities.User{ID: 9, UserName: "tester", Location: time.UTC}
+	tod := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	created, _ := repo.CreateWeeklyReminder([]time.Weekday{time.Monday}, tod, &user, "original")
+	created.Recurrence.TimesOfDay = []string{"10:00", "18:00"}
+	created.EnableNagging(15, 3)
+	repo.UpdateReminder(created)
+	created.Recurrence.TimesOfDay[0] = "07:00"
+
+	// The returned and the claimed copies share nothing with the stored reminder
+	got, _ := repo.GetReminder(created.ID)
+	got.Recurrence.RecordOccurrence()
+	got.Recurrence.Weekdays[0] = time.Friday
+	got.Recurrence.TimesOfDay[1] = "20:00"
+	got.Nagging.Count = 3
+	claimed, _ := repo.ClaimReminder(created.ID, "a", time.Now(), time.Minute)
+	claimed.Recurrence.Weekdays[0] = time.Sunday
+
+	stored, _ := repo.GetReminder(created.ID)
+	if stored.Recurrence.OccurrenceCount != 0 || stored.Recurrence.Weekdays[0] != time.Monday {
+		t.Errorf("expected the stored recurrence unchanged, got %+v", stored.Recurrence)
+	}
+	if !slices.Equal(stored.Recurrence.TimesOfDay, []string{"10:00", "18:00"}) {
+		t.Errorf("expected the stored times unchanged, got %v", stored.Recurrence.TimesOfDay)
+	}
+	if stored.Nagging.Count != 0 {
+		t.Errorf("expected the stored nagging unchanged, got %+v", stored.Nagging)
+	}
+}
+
 func TestUpdateReminder_Happy(t *testing.T) {
 	repo := NewInMemoryReminderRepository()
 	loc, _ := time.LoadLocation("Asia/Shanghai")
@@ -225,3 +257,46 @@ func TestUpdateReminder_NotFound(t *testing.T) {
 		t.Fatalf("expected update to fail for non-existent reminder")
 	}
 }
+
+func TestGetDueReminders_IndexFollowsUpdates(t *testing.T) {
+	repo := NewInMemoryReminderRepository()
+	user := entities.User{ID: 1, Location: time.UTC}
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	due, _ := repo.CreateOnceReminder(past, &user, "due")
+	repo.CreateOnceReminder(future, &user, "later")
+	snoozed, _ := repo.CreateOnceReminder(future, &user, "snoozed")
+	snoozed.Deactivate()
+	snoozed.Snooze(past)
+	repo.UpdateReminder(snoozed)
+	overdue, _ := repo.CreateOnceReminder(past.Add(-time.Hour), &user, "overdue")
+
+	got, _ := repo.GetDueReminders(now, repositories.DueCursor{}, 0)
+	if len(got) != 3 || got[0].ID != overdue.ID || got[1].ID != due.ID || got[2].ID != snoozed.ID {
+		t.Fatalf("expected overdue, due and snoozed reminders in due order, got %+v", got)
+	}
+
+	page, _ := repo.GetDueReminders(now, repositories.DueCursor{}, 2)
+	if len(page) != 2 || page[0].ID != overdue.ID || page[1].ID != due.ID {
+		t.Fatalf("expected first page to hold the overdue and due reminders, got %+v", page)
+	}
+	page, _ = repo.GetDueReminders(now, repositories.DueCursorAfter(page), 2)
+	if len(page) != 1 || page[0].ID != snoozed.ID {
+		t.Fatalf("expected second page to hold the snoozed reminder, got %+v", page)
+	}
+
+	repo.UpdateNextTrigger(due.ID, future)
+	repo.DeleteReminder(snoozed.ID, user.ID)
+	repo.DeleteReminder(overdue.ID, user.ID)
+	if got, _ := repo.GetDueReminders(now, repositories.DueCursor{}, 0); len(got) != 0 {
+		t.Fatalf("expected nothing due after rescheduling and deletion, got %+v", got)
+	}
+	if got, _ := repo.GetDueReminders(future, repositories.DueCursor{}, 0); len(got) != 2 {
+		t.Fatalf("expected both remaining reminders due an hour later, got %+v", got)
+	}
+	if rem, _ := repo.GetReminder(due.ID); rem == nil || !rem.NextTrigger.Equal(future) {
+		t.Fatalf("expected the rescheduled reminder to stay retrievable after deletions, got %+v", rem)
+	}
+}
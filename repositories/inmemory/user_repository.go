@@ -104,6 +104,90 @@ func (r *InMemoryUserRepository) UpdateUserInfo(userID int64, userName, firstNam
 	return nil
 }
 
+func (r *InMemoryUserRepository) MarkUnreachable(userID int64, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return nil // User doesn't exist, nothing to update
+	}
+
+	user.MarkUnreachable(reason)
+	return nil
+}
+
+func (r *InMemoryUserRepository) MarkReachable(userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return nil // User doesn't exist, nothing to update
+	}
+
+	user.MarkReachable()
+	return nil
+}
+
+func (r *InMemoryUserRepository) SetPausedUntil(userID int64, until *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return nil // User doesn't exist, nothing to update
+	}
+
+	if until == nil {
+		user.Resume()
+	} else {
+		user.PauseUntil(*until)
+	}
+	return nil
+}
+
+func (r *InMemoryUserRepository) SetDigest(userID int64, digest *entities.Digest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists {
+		return nil // User doesn't exist, nothing to update
+	}
+
+	user.SetDigest(digest)
+	return nil
+}
+
+func (r *InMemoryUserRepository) GetUsersWithDueDigest(now time.Time) ([]entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []entities.User
+	for _, user := range r.users {
+		if user.Digest != nil && !user.Digest.NextAt.After(now) {
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
+func (r *InMemoryUserRepository) ClaimDigest(userID int64, scheduledAt, next time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, exists := r.users[userID]
+	if !exists || user.Digest == nil || !user.Digest.NextAt.Equal(scheduledAt) {
+		return false, nil
+	}
+
+	digest := *user.Digest
+	digest.NextAt = next
+	user.Digest = &digest
+	return true, nil
+}
+
 func (r *InMemoryUserRepository) CreateUser(userID int64, userName, firstName, lastName, language string) (*entities.User, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -0,0 +1,155 @@
+package persistent
+
+import (
+	"context"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type MongoDeliveryRepository struct {
+	client   *mongo.Client
+	database string
+	col      *mongo.Collection
+}
+
+func NewMongoDeliveryRepository(connectionString string, database string) (repositories.DeliveryRepository, error) {
+	serverAPI := options.ServerAPI(options.ServerAPIVersion1)
+	client, err := mongo.Connect(options.Client().ApplyURI(connectionString).SetServerAPIOptions(serverAPI))
+	if err != nil {
+		return nil, err
+	}
+	db := client.Database(database)
+	repo := &MongoDeliveryRepository{
+		client:   client,
+		database: database,
+		col:      db.Collection("deliveries"),
+	}
+	if err := repo.ensureIndexes(); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// deliveryRetention is how long successful deliveries are kept in the log.
+// Pending retries and dead letters stay until they are handled.
+const deliveryRetention = 90 * 24 * time.Hour
+
+// ensureIndexes creates the indexes backing GetDueRetries and the history and acknowledge
+// queries of a reminder, and the TTL index that drops successful deliveries after deliveryRetention
+func (r *MongoDeliveryRepository) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "nextRetryAt", Value: 1}},
+			Options: options.Index().SetName("retries"),
+		},
+		{
+			Keys:    bson.D{{Key: "reminderId", Value: 1}, {Key: "sentAt", Value: -1}},
+			Options: options.Index().SetName("history"),
+		},
+		{
+			Keys: bson.D{{Key: "sentAt", Value: 1}},
+			Options: options.Index().SetName("retention").
+				SetExpireAfterSeconds(int32(deliveryRetention / time.Second)).
+				SetPartialFilterExpression(bson.M{"status": entities.DeliveryStatusSent}),
+		},
+	})
+	return err
+}
+
+func (r *MongoDeliveryRepository) CreateDelivery(delivery *entities.Delivery) (*entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Same time-based ID scheme as reminders
+	if delivery.ID == 0 {
+		delivery.ID = time.Now().UnixNano()
+	}
+	if _, err := r.col.InsertOne(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (r *MongoDeliveryRepository) UpdateDelivery(delivery *entities.Delivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.col.UpdateOne(ctx, bson.M{"id": delivery.ID}, bson.M{"$set": delivery})
+	return err
+}
+
+func (r *MongoDeliveryRepository) GetDelivery(deliveryID int64) (*entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var d entities.Delivery
+	err := r.col.FindOne(ctx, bson.M{"id": deliveryID}).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *MongoDeliveryRepository) GetDeliveriesByReminder(reminderID int64, limit int) ([]entities.Delivery, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "sentAt", Value: -1}, {Key: "scheduledAt", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	return r.find(bson.M{"reminderId": reminderID}, opts)
+}
+
+func (r *MongoDeliveryRepository) find(filter bson.M, opts *options.FindOptionsBuilder) ([]entities.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	res := make([]entities.Delivery, 0)
+	for cur.Next(ctx) {
+		var d entities.Delivery
+		if err := cur.Decode(&d); err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+	return res, cur.Err()
+}
+
+func (r *MongoDeliveryRepository) GetDueRetries(now time.Time) ([]entities.Delivery, error) {
+	filter := bson.M{"status": entities.DeliveryStatusRetrying, "nextRetryAt": bson.M{"$lte": now}}
+	return r.find(filter, options.Find().SetSort(bson.D{{Key: "nextRetryAt", Value: 1}}))
+}
+
+func (r *MongoDeliveryRepository) GetDeliveriesByStatus(status entities.DeliveryStatus) ([]entities.Delivery, error) {
+	return r.find(bson.M{"status": status}, options.Find().SetSort(bson.D{{Key: "sentAt", Value: -1}, {Key: "scheduledAt", Value: -1}}))
+}
+
+func (r *MongoDeliveryRepository) AcknowledgeDeliveries(reminderID int64, at time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	filter := bson.M{"reminderId": reminderID, "status": entities.DeliveryStatusSent, "acknowledged": false}
+	update := bson.M{"$set": bson.M{"acknowledged": true, "acknowledgedAt": at}}
+	_, err := r.col.UpdateMany(ctx, filter, update)
+	return err
+}
+
+func (r *MongoDeliveryRepository) DeleteDeliveriesByReminder(reminderID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.col.DeleteMany(ctx, bson.M{"reminderId": reminderID})
+	return err
+}
+
+// Disconnect closes the repository's Mongo connection
+func (r *MongoDeliveryRepository) Disconnect(ctx context.Context) error {
+	return r.client.Disconnect(ctx)
+}
@@ -157,3 +157,8 @@ func (r *MongoPremiumUsageRepository) GetUsageByPremiumStatus(status entities.Pr
 
 	return usages, nil
 }
+
+// Disconnect closes the repository's Mongo connection
+func (r *MongoPremiumUsageRepository) Disconnect(ctx context.Context) error {
+	return r.collection.Database().Client().Disconnect(ctx)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
 	"github.com/ivanenkomaksym/remindme_bot/scheduler"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
@@ -24,11 +25,50 @@ func NewMongoReminderRepository(connectionString string, database string) (repos
 		return nil, err
 	}
 	db := client.Database(database)
-	return &MongoReminderRepository{
+	repo := &MongoReminderRepository{
 		client:   client,
 		database: database,
 		col:      db.Collection("reminders"),
-	}, nil
+	}
+	if err := repo.ensureIndexes(); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// reminderDocument is a reminder as stored, with the earliest time the notifier has work for it
+// (Reminder.NextDueAt) that GetDueReminders filters, sorts and pages by. Like the lease fields,
+// dueAt lives only in the document and is rewritten with every change of the fields it derives from.
+type reminderDocument struct {
+	entities.Reminder `bson:",inline"`
+	DueAt             *time.Time `bson:"dueAt"`
+}
+
+func newReminderDocument(rem *entities.Reminder) reminderDocument {
+	return reminderDocument{Reminder: *rem, DueAt: rem.NextDueAt()}
+}
+
+// dueAtStage recomputes dueAt in an update pipeline after a partial update, as Reminder.NextDueAt does
+var dueAtStage = bson.D{{Key: "$set", Value: bson.M{"dueAt": bson.M{"$min": bson.A{
+	bson.M{"$cond": bson.A{"$isActive", "$nextTrigger", nil}},
+	"$snoozedUntil",
+	"$nagging.nextNag",
+}}}}}
+
+// ensureIndexes creates the index backing GetDueReminders, which serves both its filter and its
+// (dueAt, id) order, and fills in dueAt for reminders stored before it existed
+func (r *MongoReminderRepository) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "dueAt", Value: 1}, {Key: "id", Value: 1}},
+		Options: options.Index().SetName("due"),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.col.UpdateMany(ctx, bson.M{"dueAt": bson.M{"$exists": false}}, mongo.Pipeline{dueAtStage})
+	return err
 }
 
 func (r *MongoReminderRepository) CreateOnceReminder(dateTime time.Time, user *entities.User, message string) (*entities.Reminder, error) {
@@ -59,11 +99,11 @@ func (r *MongoReminderRepository) CreateMonthlyReminder(daysOfMonth []int, timeO
 	return r.insertAndReturn(rem)
 }
 
-func (r *MongoReminderRepository) CreateIntervalReminder(intervalDays int, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error) {
+func (r *MongoReminderRepository) CreateIntervalReminder(interval int, unit entities.IntervalUnit, window *entities.ActiveWindow, timeOfDay time.Time, user *entities.User, message string) (*entities.Reminder, error) {
 	now := time.Now()
-	base := scheduler.NextDailyTrigger(now, timeOfDay, user.GetLocation())
-	next := base.Add(time.Duration(intervalDays-1) * 24 * time.Hour)
-	rem := entities.NewReminder(0, user.ID, message, entities.IntervalEveryDays(intervalDays, timeOfDay, user.GetLocation()), &next)
+	recurrence := entities.IntervalEvery(interval, unit, window, timeOfDay, user.GetLocation())
+	next := scheduler.FirstIntervalTrigger(now, timeOfDay, recurrence)
+	rem := entities.NewReminder(0, user.ID, message, recurrence, &next)
 	return r.insertAndReturn(rem)
 }
 
@@ -76,6 +116,11 @@ func (r *MongoReminderRepository) CreateSpaceBasedRepetitionReminder(timeOfDay t
 	return r.insertAndReturn(rem)
 }
 
+func (r *MongoReminderRepository) CreateReminder(reminder *entities.Reminder) (*entities.Reminder, error) {
+	reminder.ID = 0
+	return r.insertAndReturn(reminder)
+}
+
 func (r *MongoReminderRepository) insertAndReturn(rem *entities.Reminder) (*entities.Reminder, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -84,7 +129,7 @@ func (r *MongoReminderRepository) insertAndReturn(rem *entities.Reminder) (*enti
 	if rem.ID == 0 {
 		rem.ID = time.Now().UnixNano()
 	}
-	_, err := r.col.InsertOne(ctx, rem)
+	_, err := r.col.InsertOne(ctx, newReminderDocument(rem))
 	if err != nil {
 		return nil, err
 	}
@@ -146,7 +191,7 @@ func (r *MongoReminderRepository) GetReminder(reminderID int64) (*entities.Remin
 func (r *MongoReminderRepository) UpdateReminder(reminder *entities.Reminder) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	_, err := r.col.UpdateOne(ctx, map[string]any{"id": reminder.ID}, map[string]any{"$set": reminder})
+	_, err := r.col.UpdateOne(ctx, map[string]any{"id": reminder.ID}, map[string]any{"$set": newReminderDocument(reminder)})
 	return err
 }
 
@@ -160,7 +205,8 @@ func (r *MongoReminderRepository) DeleteReminder(reminderID int64, userID int64)
 func (r *MongoReminderRepository) DeactivateReminder(reminderID int64, userID int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	_, err := r.col.UpdateOne(ctx, map[string]any{"id": reminderID, "userId": userID}, map[string]any{"$set": map[string]any{"isActive": false}})
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{"isActive": false}}}, dueAtStage}
+	_, err := r.col.UpdateOne(ctx, map[string]any{"id": reminderID, "userId": userID}, update)
 	return err
 }
 
@@ -183,9 +229,78 @@ func (r *MongoReminderRepository) GetActiveReminders() ([]entities.Reminder, err
 	return res, cur.Err()
 }
 
+func (r *MongoReminderRepository) GetDueReminders(now time.Time, after repositories.DueCursor, limit int) ([]entities.Reminder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	filter := bson.M{
+		"dueAt": bson.M{"$lte": now},
+		"$or": bson.A{
+			bson.M{"dueAt": bson.M{"$gt": after.At}},
+			bson.M{"dueAt": after.At, "id": bson.M{"$gt": after.ID}},
+		},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "dueAt", Value: 1}, {Key: "id", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cur, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var res []entities.Reminder
+	for cur.Next(ctx) {
+		var rm entities.Reminder
+		if err := cur.Decode(&rm); err != nil {
+			return nil, err
+		}
+		res = append(res, rm)
+	}
+	return res, cur.Err()
+}
+
 func (r *MongoReminderRepository) UpdateNextTrigger(reminderID int64, nextTrigger time.Time) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	_, err := r.col.UpdateOne(ctx, map[string]any{"id": reminderID}, map[string]any{"$set": map[string]any{"nextTrigger": nextTrigger}})
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{"nextTrigger": nextTrigger}}}, dueAtStage}
+	_, err := r.col.UpdateOne(ctx, map[string]any{"id": reminderID}, update)
+	return err
+}
+
+// ClaimReminder takes the lease with a single findOneAndUpdate, so concurrent instances
+// cannot both win. Lease fields live only in the document and are never part of the entity,
+// which keeps UpdateReminder's full-document $set from touching them.
+func (r *MongoReminderRepository) ClaimReminder(reminderID int64, owner string, now time.Time, leaseFor time.Duration) (*entities.Reminder, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	filter := bson.M{
+		"id": reminderID,
+		"$or": bson.A{
+			bson.M{"leaseUntil": nil},
+			bson.M{"leaseUntil": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"leasedBy": owner, "leaseUntil": now.Add(leaseFor)}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var rm entities.Reminder
+	err := r.col.FindOneAndUpdate(ctx, filter, update, opts).Decode(&rm)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rm, nil
+}
+
+func (r *MongoReminderRepository) ReleaseReminder(reminderID int64, owner string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.col.UpdateOne(ctx, bson.M{"id": reminderID, "leasedBy": owner}, bson.M{"$unset": bson.M{"leasedBy": "", "leaseUntil": ""}})
 	return err
 }
+
+// Disconnect closes the repository's Mongo connection
+func (r *MongoReminderRepository) Disconnect(ctx context.Context) error {
+	return r.client.Disconnect(ctx)
+}
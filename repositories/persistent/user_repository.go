@@ -7,6 +7,7 @@ import (
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
 	"github.com/ivanenkomaksym/remindme_bot/domain/errors"
 	"github.com/ivanenkomaksym/remindme_bot/domain/repositories"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
@@ -24,11 +25,26 @@ func NewMongoUserRepository(connectionString string, database string) (repositor
 		return nil, err
 	}
 	db := client.Database(database)
-	return &MongoUserRepository{
+	repo := &MongoUserRepository{
 		client:   client,
 		database: database,
 		usersCol: db.Collection("users"),
-	}, nil
+	}
+	if err := repo.ensureIndexes(); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// ensureIndexes creates the index backing GetUsersWithDueDigest, sparse as most users have no digest
+func (r *MongoUserRepository) ensureIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.usersCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "digest.nextAt", Value: 1}},
+		Options: options.Index().SetName("digest").SetSparse(true),
+	})
+	return err
 }
 
 func (r *MongoUserRepository) GetUsers() ([]*entities.User, error) {
@@ -103,6 +119,65 @@ func (r *MongoUserRepository) UpdateLocation(userID int64, location string) erro
 	return err
 }
 
+func (r *MongoUserRepository) MarkUnreachable(userID int64, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	now := time.Now()
+	_, err := r.usersCol.UpdateOne(ctx, map[string]any{"id": userID}, map[string]any{"$set": map[string]any{"unreachable": true, "unreachableSince": now, "unreachableReason": reason, "updatedAt": now}})
+	return err
+}
+
+func (r *MongoUserRepository) MarkReachable(userID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.usersCol.UpdateOne(ctx, map[string]any{"id": userID}, map[string]any{"$set": map[string]any{"unreachable": false, "unreachableSince": nil, "unreachableReason": "", "updatedAt": time.Now()}})
+	return err
+}
+
+func (r *MongoUserRepository) SetPausedUntil(userID int64, until *time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.usersCol.UpdateOne(ctx, map[string]any{"id": userID}, map[string]any{"$set": map[string]any{"pausedUntil": until, "updatedAt": time.Now()}})
+	return err
+}
+
+func (r *MongoUserRepository) SetDigest(userID int64, digest *entities.Digest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := r.usersCol.UpdateOne(ctx, map[string]any{"id": userID}, map[string]any{"$set": map[string]any{"digest": digest, "updatedAt": time.Now()}})
+	return err
+}
+
+func (r *MongoUserRepository) GetUsersWithDueDigest(now time.Time) ([]entities.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	cur, err := r.usersCol.Find(ctx, map[string]any{"digest.nextAt": map[string]any{"$lte": now}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+	var users []entities.User
+	for cur.Next(ctx) {
+		var u entities.User
+		if err := cur.Decode(&u); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, cur.Err()
+}
+
+func (r *MongoUserRepository) ClaimDigest(userID int64, scheduledAt, next time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Only the instance that still sees the digest at scheduledAt moves it
+	res, err := r.usersCol.UpdateOne(ctx, map[string]any{"id": userID, "digest.nextAt": scheduledAt}, map[string]any{"$set": map[string]any{"digest.nextAt": next}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
 func (r *MongoUserRepository) CreateUser(userID int64, userName, firstName, lastName, language string) (*entities.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -133,3 +208,8 @@ func (r *MongoUserRepository) DeleteUser(userID int64) error {
 	_, err := r.usersCol.DeleteOne(ctx, map[string]any{"id": userID})
 	return err
 }
+
+// Disconnect closes the repository's Mongo connection
+func (r *MongoUserRepository) Disconnect(ctx context.Context) error {
+	return r.client.Disconnect(ctx)
+}
@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone data for %s not available", name)
+	}
+	return loc
+}
+
+func TestLocalTime_DSTTransitions(t *testing.T) {
+	tests := []struct {
+		name string
+		zone string
+		date time.Time // wall clock read in zone
+		want time.Time
+	}{
+		{"New York regular time", "America/New_York", time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC), time.Date(2026, 7, 1, 13, 0, 0, 0, time.UTC)},
+		{"New York nonexistent 02:30", "America/New_York", time.Date(2026, 3, 8, 2, 30, 0, 0, time.UTC), time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC)},
+		{"New York ambiguous 01:30", "America/New_York", time.Date(2026, 11, 1, 1, 30, 0, 0, time.UTC), time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC)},
+		{"Kyiv nonexistent 03:30", "Europe/Kyiv", time.Date(2026, 3, 29, 3, 30, 0, 0, time.UTC), time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC)},
+		{"Kyiv ambiguous 03:30", "Europe/Kyiv", time.Date(2026, 10, 25, 3, 30, 0, 0, time.UTC), time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC)},
+		{"London nonexistent 01:30", "Europe/London", time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC), time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC)},
+		{"London ambiguous 01:30", "Europe/London", time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC), time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC)},
+		{"Sydney nonexistent 02:30", "Australia/Sydney", time.Date(2026, 10, 4, 2, 30, 0, 0, time.UTC), time.Date(2026, 10, 3, 16, 30, 0, 0, time.UTC)},
+		{"Sydney ambiguous 02:30", "Australia/Sydney", time.Date(2026, 4, 5, 2, 30, 0, 0, time.UTC), time.Date(2026, 4, 4, 15, 30, 0, 0, time.UTC)},
+		{"day overflow normalizes", "Europe/Kyiv", time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC).AddDate(0, 0, 1), time.Date(2026, 2, 1, 7, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := loadLocation(t, tt.zone)
+			got := LocalTime(tt.date.Year(), tt.date.Month(), tt.date.Day(), tt.date.Hour(), tt.date.Minute(), loc)
+			if !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got.UTC())
+			}
+			if got.Location() != loc {
+				t.Fatalf("expected the result in %s, got %s", loc, got.Location())
+			}
+		})
+	}
+}
+
+func TestNextForRecurrence_DSTTransitions(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	kyiv := loadLocation(t, "Europe/Kyiv")
+	london := loadLocation(t, "Europe/London")
+	sydney := loadLocation(t, "Australia/Sydney")
+
+	afterFirstReview := func(r *entities.Recurrence) { r.LadderStep = 1 }
+	recall := entities.NewRecallState()
+	recall.IntervalDays = 6
+
+	tests := []struct {
+		name      string
+		rec       *entities.Recurrence
+		timeOfDay time.Time
+		last      time.Time
+		want      time.Time
+	}{
+		{"daily keeps 09:00 over spring forward",
+			entities.New(entities.Daily, nil, newYork), time.Date(2026, 3, 1, 9, 0, 0, 0, newYork),
+			time.Date(2026, 3, 7, 9, 0, 0, 0, newYork), time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC)},
+		{"daily keeps 09:00 over fall back",
+			entities.New(entities.Daily, nil, kyiv), time.Date(2026, 10, 1, 9, 0, 0, 0, kyiv),
+			time.Date(2026, 10, 24, 9, 0, 0, 0, kyiv), time.Date(2026, 10, 25, 7, 0, 0, 0, time.UTC)},
+		{"daily nonexistent 02:30 fires after the gap",
+			entities.New(entities.Daily, nil, newYork), time.Date(2026, 3, 1, 2, 30, 0, 0, newYork),
+			time.Date(2026, 3, 7, 2, 30, 0, 0, newYork), time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC)},
+		{"daily returns to 02:30 the day after the gap",
+			entities.New(entities.Daily, nil, newYork), time.Date(2026, 3, 1, 2, 30, 0, 0, newYork),
+			time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC), time.Date(2026, 3, 9, 6, 30, 0, 0, time.UTC)},
+		{"daily ambiguous 01:30 fires once",
+			entities.New(entities.Daily, nil, newYork), time.Date(2026, 10, 1, 1, 30, 0, 0, newYork),
+			time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC), time.Date(2026, 11, 2, 6, 30, 0, 0, time.UTC)},
+		{"weekly on Sunday keeps 09:00 over spring forward",
+			entities.New(entities.Weekly, nil, london, entities.WithWeekdays([]time.Weekday{time.Sunday})), time.Date(2026, 3, 1, 9, 0, 0, 0, london),
+			time.Date(2026, 3, 22, 9, 0, 0, 0, london), time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC)},
+		{"monthly keeps 09:00 over fall back",
+			entities.New(entities.Monthly, nil, sydney, entities.WithDaysOfMonth([]int{5})), time.Date(2026, 3, 1, 9, 0, 0, 0, sydney),
+			time.Date(2026, 3, 5, 9, 0, 0, 0, sydney), time.Date(2026, 4, 4, 23, 0, 0, 0, time.UTC)},
+		{"yearly keeps 09:00 on the fall back day",
+			entities.New(entities.Yearly, timePtr(time.Date(2025, 10, 25, 9, 0, 0, 0, kyiv)), kyiv), time.Date(2025, 10, 25, 9, 0, 0, 0, kyiv),
+			time.Date(2025, 10, 25, 9, 0, 0, 0, kyiv), time.Date(2026, 10, 25, 7, 0, 0, 0, time.UTC)},
+		{"every 2 days keeps 09:00 over spring forward",
+			entities.New(entities.Interval, nil, newYork, entities.WithInterval(2)), time.Date(2026, 3, 1, 9, 0, 0, 0, newYork),
+			time.Date(2026, 3, 7, 9, 0, 0, 0, newYork), time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC)},
+		{"every 2 days processed late keeps 09:00 over spring forward",
+			entities.New(entities.Interval, nil, newYork, entities.WithInterval(2)), time.Date(2026, 3, 1, 9, 0, 0, 0, newYork),
+			time.Date(2026, 3, 7, 9, 14, 0, 0, newYork), time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC)},
+		{"every week keeps 09:00 over fall back",
+			entities.New(entities.Interval, nil, kyiv, entities.WithInterval(1), entities.WithIntervalUnit(entities.IntervalUnitWeeks)), time.Date(2026, 10, 1, 9, 0, 0, 0, kyiv),
+			time.Date(2026, 10, 20, 9, 0, 0, 0, kyiv), time.Date(2026, 10, 27, 7, 0, 0, 0, time.UTC)},
+		{"every hour steps elapsed time over spring forward",
+			entities.New(entities.Interval, nil, newYork, entities.WithInterval(1), entities.WithIntervalUnit(entities.IntervalUnitHours)), time.Date(2026, 3, 8, 0, 0, 0, 0, newYork),
+			time.Date(2026, 3, 8, 1, 0, 0, 0, newYork), time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC)},
+		{"spaced repetition ladder counts calendar days",
+			entities.New(entities.SpacedBasedRepetition, nil, london, entities.WithSpacedRepetitionLadder([]int{4}), afterFirstReview), time.Date(2026, 3, 1, 9, 0, 0, 0, london),
+			time.Date(2026, 3, 26, 9, 0, 0, 0, london), time.Date(2026, 3, 30, 8, 0, 0, 0, time.UTC)},
+		{"adaptive review counts calendar days",
+			entities.New(entities.SpacedBasedRepetition, nil, london, entities.WithRecall(recall)), time.Date(2026, 3, 1, 9, 0, 0, 0, london),
+			time.Date(2026, 3, 25, 9, 0, 0, 0, london), time.Date(2026, 3, 31, 8, 0, 0, 0, time.UTC)},
+		{"rule with nonexistent 02:30 fires after the gap",
+			entities.RRuleFrom("FREQ=WEEKLY;BYDAY=SU", time.Date(2026, 3, 1, 2, 30, 0, 0, newYork), newYork), time.Date(2026, 3, 1, 2, 30, 0, 0, newYork),
+			time.Date(2026, 3, 1, 2, 30, 0, 0, newYork), time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextForRecurrence(tt.last, tt.timeOfDay, tt.rec)
+			if got == nil {
+				t.Fatalf("expected %v, got nil", tt.want)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got.UTC())
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestNextForRecurrence_StopsAtEndDate(t *testing.T) {
+	tod := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 12, 23, 59, 59, 0, time.UTC)
+	rec := entities.DailyAt(tod, time.UTC)
+	entities.WithEnd(&end, 0)(rec)
+
+	next := NextForRecurrence(time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC), tod, rec)
+	if next == nil || !next.Equal(time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the last day to fire, got %v", next)
+	}
+	if next := NextForRecurrence(*next, tod, rec); next != nil {
+		t.Fatalf("expected no trigger past the end date, got %v", next)
+	}
+}
+
+func TestNextForRecurrence_StopsWhenExhausted(t *testing.T) {
+	tod := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	rec := entities.DailyAt(tod, time.UTC)
+	entities.WithEnd(nil, 2)(rec)
+
+	if next := NextForRecurrence(tod, tod, rec); next == nil {
+		t.Fatalf("expected a trigger before any occurrence fired")
+	}
+	rec.RecordOccurrence()
+	rec.RecordOccurrence()
+	if next := NextForRecurrence(tod, tod, rec); next != nil {
+		t.Fatalf("expected no trigger after 2 of 2 occurrences, got %v", next)
+	}
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestNextForRecurrence_BusinessDays(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	timeOfDay := time.Date(2025, 1, 1, 9, 0, 0, 0, loc)
+	skipUA := entities.WithBusinessDays(&entities.BusinessDays{Policy: entities.HolidayPolicySkip, Country: "UA"})
+	shiftUA := entities.WithBusinessDays(&entities.BusinessDays{Policy: entities.HolidayPolicyShift, Country: "UA"})
+	shiftUS := entities.WithBusinessDays(&entities.BusinessDays{Policy: entities.HolidayPolicyShift, Country: "US"})
+	excluded := entities.WithBusinessDays(&entities.BusinessDays{Policy: entities.HolidayPolicySkip, ExcludedDates: []string{"2025-12-24"}})
+
+	tests := []struct {
+		name string
+		rec  *entities.Recurrence
+		last time.Time
+		want *time.Time
+	}{
+		{"daily skips the weekend and the observed Independence Day",
+			entities.New(entities.Daily, &timeOfDay, loc, skipUA),
+			time.Date(2025, 8, 22, 9, 0, 0, 0, loc), timePtr(time.Date(2025, 8, 26, 9, 0, 0, 0, loc))},
+		{"weekly on Saturday shifts to Monday",
+			entities.New(entities.Weekly, &timeOfDay, loc, entities.WithWeekdays([]time.Weekday{time.Saturday}), shiftUA),
+			time.Date(2025, 10, 4, 9, 0, 0, 0, loc), timePtr(time.Date(2025, 10, 13, 9, 0, 0, 0, loc))},
+		{"weekly on Saturday never fires when skipping",
+			entities.New(entities.Weekly, &timeOfDay, loc, entities.WithWeekdays([]time.Weekday{time.Saturday}), skipUA),
+			time.Date(2025, 10, 4, 9, 0, 0, 0, loc), nil},
+		{"monthly on New Year's Day shifts to the next business day",
+			entities.New(entities.Monthly, &timeOfDay, loc, entities.WithDaysOfMonth([]int{1}), shiftUS),
+			time.Date(2025, 12, 1, 9, 0, 0, 0, loc), timePtr(time.Date(2026, 1, 2, 9, 0, 0, 0, loc))},
+		{"daily skips an excluded date",
+			entities.New(entities.Daily, &timeOfDay, loc, excluded),
+			time.Date(2025, 12, 23, 9, 0, 0, 0, loc), timePtr(time.Date(2025, 12, 25, 9, 0, 0, 0, loc))},
+		{"no policy fires on weekends",
+			entities.New(entities.Daily, &timeOfDay, loc),
+			time.Date(2025, 8, 22, 9, 0, 0, 0, loc), timePtr(time.Date(2025, 8, 23, 9, 0, 0, 0, loc))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextForRecurrence(tt.last, timeOfDay, tt.rec)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("expected no next trigger, got %v", got)
+				}
+				return
+			}
+			if got == nil || !got.Equal(*tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNextForRecurrence_BusinessDaysRespectEnd(t *testing.T) {
+	loc := time.UTC
+	timeOfDay := time.Date(2025, 1, 1, 9, 0, 0, 0, loc)
+	end := time.Date(2025, 8, 24, 23, 59, 0, 0, loc)
+	rec := entities.New(entities.Daily, &timeOfDay, loc,
+		entities.WithBusinessDays(&entities.BusinessDays{Policy: entities.HolidayPolicyShift}), entities.WithEnd(&end, 0))
+
+	// Saturday shifts to Monday, past the end date
+	if next := NextForRecurrence(time.Date(2025, 8, 22, 9, 0, 0, 0, loc), timeOfDay, rec); next != nil {
+		t.Fatalf("expected no trigger past the end date, got %v", next)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
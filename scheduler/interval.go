@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// FirstIntervalTrigger returns the first trigger of a new interval recurrence.
+// Day and week intervals fire N units after the next occurrence of the time of day,
+// minute and hour intervals fire at the first step of their schedule after from.
+func FirstIntervalTrigger(from time.Time, timeOfDay time.Time, rec *entities.Recurrence) time.Time {
+	if rec.GetIntervalUnit().IsSubDaily() {
+		return NextIntervalTrigger(from, timeOfDay, rec)
+	}
+
+	base := NextDailyTrigger(from, timeOfDay, rec.GetLocation())
+	return AddLocalDays(base, intervalDays(rec)-1, timeOfDay, rec.GetLocation()).UTC()
+}
+
+// NextIntervalTrigger advances an interval recurrence past last.
+// Day and week intervals add N calendar days or weeks to the day of last and fire at the time
+// of day, so they keep their wall clock across DST transitions and a late notifier pass does not
+// shift later triggers. Minute and hour intervals step elapsed time from the time of day on a fixed
+// grid, so a late notifier pass does not shift later triggers;
+// with an active window the grid restarts at the window start every day and steps
+// falling outside the window are skipped.
+func NextIntervalTrigger(last time.Time, timeOfDay time.Time, rec *entities.Recurrence) time.Time {
+	location := rec.GetLocation()
+	if location == nil {
+		location = time.UTC
+	}
+	if !rec.GetIntervalUnit().IsSubDaily() {
+		// Retain time of day, convert back to UTC for storage
+		return AddLocalDays(last, intervalDays(rec), timeOfDay, location).UTC()
+	}
+
+	step := rec.IntervalStep()
+
+	if rec.ActiveWindow == nil {
+		return nextGridStep(last, timeOfDay, step).UTC()
+	}
+
+	day := last.In(location)
+	for range 3 {
+		start, end := rec.ActiveWindow.Bounds(day)
+		if candidate := nextGridStep(last, start, step); !candidate.After(end) {
+			return candidate.UTC()
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, location)
+	}
+
+	// Unreachable for a valid window, which always fits at least its start
+	return last.Add(step).UTC()
+}
+
+// nextGridStep returns the first anchor + k*step strictly after from, with k >= 0
+func nextGridStep(from, anchor time.Time, step time.Duration) time.Time {
+	if from.Before(anchor) {
+		return anchor
+	}
+	steps := from.Sub(anchor)/step + 1
+	return anchor.Add(steps * step)
+}
+
+// intervalDays returns the calendar days between two triggers of a day or week interval
+func intervalDays(rec *entities.Recurrence) int {
+	return int(rec.IntervalStep() / (24 * time.Hour))
+}
@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestNextIntervalTrigger(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	timeOfDay := time.Date(2025, 3, 10, 8, 0, 0, 0, loc)
+	window := &entities.ActiveWindow{From: "08:00", To: "22:00"}
+
+	tests := []struct {
+		name     string
+		interval int
+		unit     entities.IntervalUnit
+		window   *entities.ActiveWindow
+		last     time.Time
+		want     time.Time
+	}{
+		{"legacy days", 3, entities.IntervalUnitDefault, nil,
+			time.Date(2025, 3, 10, 8, 0, 0, 0, loc), time.Date(2025, 3, 13, 8, 0, 0, 0, loc)},
+		{"weeks", 2, entities.IntervalUnitWeeks, nil,
+			time.Date(2025, 3, 10, 8, 0, 0, 0, loc), time.Date(2025, 3, 24, 8, 0, 0, 0, loc)},
+		{"late pass keeps the time of day", 2, entities.IntervalUnitDefault, nil,
+			time.Date(2025, 3, 10, 8, 14, 0, 0, loc), time.Date(2025, 3, 12, 8, 0, 0, 0, loc)},
+		{"hours on the grid", 2, entities.IntervalUnitHours, nil,
+			time.Date(2025, 3, 10, 10, 0, 0, 0, loc), time.Date(2025, 3, 10, 12, 0, 0, 0, loc)},
+		{"late pass stays on the grid", 2, entities.IntervalUnitHours, nil,
+			time.Date(2025, 3, 10, 10, 0, 40, 0, loc), time.Date(2025, 3, 10, 12, 0, 0, 0, loc)},
+		{"minutes", 45, entities.IntervalUnitMinutes, nil,
+			time.Date(2025, 3, 10, 9, 30, 0, 0, loc), time.Date(2025, 3, 10, 10, 15, 0, 0, loc)},
+		{"before the first trigger", 2, entities.IntervalUnitHours, nil,
+			time.Date(2025, 3, 10, 6, 0, 0, 0, loc), time.Date(2025, 3, 10, 8, 0, 0, 0, loc)},
+		{"inside the window", 2, entities.IntervalUnitHours, window,
+			time.Date(2025, 3, 10, 20, 0, 0, 0, loc), time.Date(2025, 3, 10, 22, 0, 0, 0, loc)},
+		{"past the window end", 2, entities.IntervalUnitHours, window,
+			time.Date(2025, 3, 10, 22, 0, 0, 0, loc), time.Date(2025, 3, 11, 8, 0, 0, 0, loc)},
+		{"before the window start", 5, entities.IntervalUnitHours, window,
+			time.Date(2025, 3, 11, 3, 0, 0, 0, loc), time.Date(2025, 3, 11, 8, 0, 0, 0, loc)},
+		{"window restarts every day", 5, entities.IntervalUnitHours, window,
+			time.Date(2025, 3, 10, 18, 0, 0, 0, loc), time.Date(2025, 3, 11, 8, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := entities.IntervalEvery(tt.interval, tt.unit, tt.window, timeOfDay, loc)
+			got := NextForRecurrence(tt.last, timeOfDay, rec)
+			if got == nil || !got.Equal(tt.want) {
+				t.Fatalf("NextForRecurrence(%v) = %v, want %v", tt.last, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstIntervalTrigger(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2025, 3, 10, 13, 10, 0, 0, loc)
+	timeOfDay := time.Date(2025, 3, 10, 9, 0, 0, 0, loc)
+
+	hourly := entities.IntervalEvery(2, entities.IntervalUnitHours, nil, timeOfDay, loc)
+	if got, want := FirstIntervalTrigger(now, timeOfDay, hourly), time.Date(2025, 3, 10, 15, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("hourly first trigger = %v, want %v", got, want)
+	}
+
+	daily := entities.IntervalEvery(3, entities.IntervalUnitDays, nil, timeOfDay, loc)
+	if got, want := FirstIntervalTrigger(now, timeOfDay, daily), time.Date(2025, 3, 13, 9, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("every 3 days first trigger = %v, want %v", got, want)
+	}
+}
+
+func TestNextAfter(t *testing.T) {
+	loc := time.UTC
+	timeOfDay := time.Date(2025, 3, 10, 23, 50, 0, 0, loc)
+	everyTwoDays := entities.IntervalEvery(2, entities.IntervalUnitDays, nil, timeOfDay, loc)
+
+	// A pass delayed past midnight keeps the schedule of the missed occurrence
+	scheduledAt := time.Date(2025, 3, 10, 23, 50, 0, 0, loc)
+	if got, want := NextAfter(scheduledAt, time.Date(2025, 3, 11, 0, 10, 0, 0, loc), timeOfDay, everyTwoDays), time.Date(2025, 3, 12, 23, 50, 0, 0, loc); got == nil || !got.Equal(want) {
+		t.Fatalf("delayed pass = %v, want %v", got, want)
+	}
+	// Occurrences missed as well are skipped to the first one after now
+	if got, want := NextAfter(scheduledAt, time.Date(2025, 3, 15, 9, 0, 0, 0, loc), timeOfDay, everyTwoDays), time.Date(2025, 3, 16, 23, 50, 0, 0, loc); got == nil || !got.Equal(want) {
+		t.Fatalf("several missed occurrences = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import "time"
+
+// LocalTime returns the instant at which the wall clock in loc reads the given date and HH:MM.
+// Calendar fields out of range are normalized as by time.Date, so day+1 is always the next day.
+//
+// Wall clock times that do not occur or occur twice around a DST transition are resolved
+// as in RFC 5545:
+//   - a nonexistent time, inside a spring-forward gap, is moved forward by the length of the
+//     gap, e.g. 02:30 on a day clocks jump from 02:00 to 03:00 fires at 03:30
+//   - an ambiguous time, inside a fall-back overlap, fires once at its first occurrence,
+//     e.g. 01:30 on a day clocks fall back from 02:00 to 01:00 fires at 01:30 summer time
+func LocalTime(year int, month time.Month, day, hour, minute int, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	wall := time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+
+	// Offsets in force a day either side cover any single transition near the wall time
+	_, before := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, after := wall.Add(24 * time.Hour).In(loc).Zone()
+
+	best := time.Time{}
+	for _, offset := range []int{before, after} {
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if sameWallClock(candidate, wall) && (best.IsZero() || candidate.Before(best)) {
+			best = candidate
+		}
+	}
+	if !best.IsZero() {
+		return best
+	}
+
+	// The wall time falls in a gap: read it with the offset before the transition
+	return wall.Add(-time.Duration(before) * time.Second).In(loc)
+}
+
+// AddLocalDays moves t by the given number of calendar days in loc at the wall clock of
+// timeOfDay, so days lasting 23 or 25 hours across DST transitions keep the time of day.
+func AddLocalDays(t time.Time, days int, timeOfDay time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	tod := timeOfDay.In(loc)
+	return LocalTime(local.Year(), local.Month(), local.Day()+days, tod.Hour(), tod.Minute(), loc)
+}
+
+func sameWallClock(t, wall time.Time) bool {
+	return t.Year() == wall.Year() && t.Month() == wall.Month() && t.Day() == wall.Day() &&
+		t.Hour() == wall.Hour() && t.Minute() == wall.Minute()
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextMonthlyTriggerClamped(t *testing.T) {
+	loc := time.UTC
+	timeOfDay := time.Date(2025, 1, 1, 9, 0, 0, 0, loc)
+
+	tests := []struct {
+		name  string
+		days  []int
+		clamp bool
+		from  time.Time
+		want  time.Time
+	}{
+		{"31st skips February", []int{31}, false,
+			time.Date(2025, 1, 31, 10, 0, 0, 0, loc), time.Date(2025, 3, 31, 9, 0, 0, 0, loc)},
+		{"31st clamped to February end", []int{31}, true,
+			time.Date(2025, 1, 31, 10, 0, 0, 0, loc), time.Date(2025, 2, 28, 9, 0, 0, 0, loc)},
+		{"30th clamped in a leap year", []int{30}, true,
+			time.Date(2024, 2, 1, 10, 0, 0, 0, loc), time.Date(2024, 2, 29, 9, 0, 0, 0, loc)},
+		{"last day", []int{-1}, false,
+			time.Date(2025, 4, 1, 10, 0, 0, 0, loc), time.Date(2025, 4, 30, 9, 0, 0, 0, loc)},
+		{"last day of February", []int{-1}, false,
+			time.Date(2025, 2, 1, 10, 0, 0, 0, loc), time.Date(2025, 2, 28, 9, 0, 0, 0, loc)},
+		{"second to last day", []int{-2}, false,
+			time.Date(2025, 2, 27, 10, 0, 0, 0, loc), time.Date(2025, 3, 30, 9, 0, 0, 0, loc)},
+		{"first and last day", []int{1, -1}, false,
+			time.Date(2025, 6, 2, 10, 0, 0, 0, loc), time.Date(2025, 6, 30, 9, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextMonthlyTriggerClamped(tt.from, tt.days, tt.clamp, timeOfDay, loc)
+			if !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -7,8 +7,12 @@ import (
 	"time"
 
 	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+	"github.com/ivanenkomaksym/remindme_bot/holidays"
 )
 
+// maxSkippedOccurrences bounds the search for an occurrence on a business day
+const maxSkippedOccurrences = 1000
+
 func ParseHourMinute(timeStr string) (int, int, bool) {
 	parts := strings.Split(timeStr, ":")
 	if len(parts) != 2 {
@@ -26,22 +30,32 @@ func ParseHourMinute(timeStr string) (int, int, bool) {
 }
 
 // NextDailyTrigger returns the next occurrence of the provided HH:MM from the reference time.
-func NextDailyTrigger(from time.Time, timeOfDay time.Time, location *time.Location) time.Time {
+// With more times of day it returns the earliest upcoming one.
+func NextDailyTrigger(from time.Time, timeOfDay time.Time, location *time.Location, moreTimes ...time.Time) time.Time {
 	fromInLocal := from.In(location)
-	timeOfDayInLocal := timeOfDay.In(location)
-	candidate := time.Date(from.Year(), from.Month(), from.Day(), timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), 0, 0, location)
-	if !candidate.After(fromInLocal) {
-		candidate = candidate.Add(24 * time.Hour)
+
+	best := time.Time{}
+	for _, tod := range append([]time.Time{timeOfDay}, moreTimes...) {
+		timeOfDayInLocal := tod.In(location)
+		candidate := LocalTime(fromInLocal.Year(), fromInLocal.Month(), fromInLocal.Day(), timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), location)
+		if !candidate.After(fromInLocal) {
+			// The next calendar day, which is not always 24 hours away
+			candidate = LocalTime(fromInLocal.Year(), fromInLocal.Month(), fromInLocal.Day()+1, timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), location)
+		}
+		if best.IsZero() || candidate.Before(best) {
+			best = candidate
+		}
 	}
 
 	// Convert back to UTC for storage
-	return candidate.UTC()
+	return best.UTC()
 }
 
-// NextWeeklyTrigger returns the next occurrence on any of the provided weekdays at HH:MM.
-func NextWeeklyTrigger(from time.Time, days []time.Weekday, timeOfDay time.Time, location *time.Location) time.Time {
+// NextWeeklyTrigger returns the next occurrence on any of the provided weekdays at HH:MM,
+// or at the earliest upcoming one of several times of day.
+func NextWeeklyTrigger(from time.Time, days []time.Weekday, timeOfDay time.Time, location *time.Location, moreTimes ...time.Time) time.Time {
 	if len(days) == 0 {
-		return NextDailyTrigger(from, timeOfDay, location)
+		return NextDailyTrigger(from, timeOfDay, location, moreTimes...)
 	}
 	seen := map[time.Weekday]struct{}{}
 	uniqueDays := make([]time.Weekday, 0, len(days))
@@ -53,22 +67,26 @@ func NextWeeklyTrigger(from time.Time, days []time.Weekday, timeOfDay time.Time,
 	}
 
 	fromInLocal := from.In(location)
-	timeOfDayInLocal := timeOfDay.In(location)
+	timesOfDay := append([]time.Time{timeOfDay}, moreTimes...)
 
+	// Eight days so that the same weekday a week later is covered when from is past its last time
 	best := time.Time{}
-	for i := range 7 {
-		day := fromInLocal.Add(time.Duration(i) * 24 * time.Hour)
+	for i := range 8 {
+		day := fromInLocal.AddDate(0, 0, i)
 		for _, d := range uniqueDays {
 			if day.Weekday() == d {
-				candidate := time.Date(day.Year(), day.Month(), day.Day(), timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), 0, 0, location)
-				if candidate.After(fromInLocal) && (best.IsZero() || candidate.Before(best)) {
-					best = candidate
+				for _, tod := range timesOfDay {
+					timeOfDayInLocal := tod.In(location)
+					candidate := LocalTime(day.Year(), day.Month(), day.Day(), timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), location)
+					if candidate.After(fromInLocal) && (best.IsZero() || candidate.Before(best)) {
+						best = candidate
+					}
 				}
 			}
 		}
 	}
 	if best.IsZero() {
-		return NextWeeklyTrigger(from.Add(7*24*time.Hour), uniqueDays, timeOfDay, location)
+		return NextWeeklyTrigger(fromInLocal.AddDate(0, 0, 7), uniqueDays, timeOfDay, location, moreTimes...)
 	}
 
 	// Convert back to UTC for storage
@@ -79,15 +97,23 @@ func daysIn(month time.Month, year int) int {
 	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
 }
 
-// NextMonthlyTrigger returns the next occurrence on any of the provided days-of-month at HH:MM.
-func NextMonthlyTrigger(from time.Time, daysOfMonth []int, timeOfDay time.Time, location *time.Location) time.Time {
+// NextMonthlyTrigger returns the next occurrence on any of the provided days-of-month at HH:MM,
+// or at the earliest upcoming one of several times of day.
+// Negative days count from the month end (-1 is the last day); days a month lacks are skipped.
+func NextMonthlyTrigger(from time.Time, daysOfMonth []int, timeOfDay time.Time, location *time.Location, moreTimes ...time.Time) time.Time {
+	return NextMonthlyTriggerClamped(from, daysOfMonth, false, timeOfDay, location, moreTimes...)
+}
+
+// NextMonthlyTriggerClamped is NextMonthlyTrigger where clamp moves days a month lacks,
+// e.g. the 31st in April, to its last day instead of skipping them.
+func NextMonthlyTriggerClamped(from time.Time, daysOfMonth []int, clamp bool, timeOfDay time.Time, location *time.Location, moreTimes ...time.Time) time.Time {
 	if len(daysOfMonth) == 0 {
-		return NextDailyTrigger(from, timeOfDay, location)
+		return NextDailyTrigger(from, timeOfDay, location, moreTimes...)
 	}
 	uniq := map[int]struct{}{}
 	days := make([]int, 0, len(daysOfMonth))
 	for _, d := range daysOfMonth {
-		if d >= 1 && d <= 31 {
+		if entities.IsValidDayOfMonth(d) {
 			if _, exists := uniq[d]; !exists {
 				uniq[d] = struct{}{}
 				days = append(days, d)
@@ -95,24 +121,29 @@ func NextMonthlyTrigger(from time.Time, daysOfMonth []int, timeOfDay time.Time,
 		}
 	}
 	if len(days) == 0 {
-		return NextDailyTrigger(from, timeOfDay, location)
+		return NextDailyTrigger(from, timeOfDay, location, moreTimes...)
 	}
 	sort.Ints(days)
 
 	fromInLocal := from.In(location)
-	timeOfDayInLocal := timeOfDay.In(location)
+	timesOfDay := append([]time.Time{timeOfDay}, moreTimes...)
 
 	best := time.Time{}
 	for m := 0; m < 3; m++ {
-		t := fromInLocal.AddDate(0, m, 0)
+		// Step from the first of the month, AddDate would roll Jan 31 over into March
+		t := time.Date(fromInLocal.Year(), fromInLocal.Month()+time.Month(m), 1, 0, 0, 0, 0, location)
 		dim := daysIn(t.Month(), t.Year())
 		for _, d := range days {
-			if d > dim {
+			day, ok := resolveDayOfMonth(d, dim, clamp)
+			if !ok {
 				continue
 			}
-			candidate := time.Date(t.Year(), t.Month(), d, timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), 0, 0, location)
-			if candidate.After(fromInLocal) && (best.IsZero() || candidate.Before(best)) {
-				best = candidate
+			for _, tod := range timesOfDay {
+				timeOfDayInLocal := tod.In(location)
+				candidate := LocalTime(t.Year(), t.Month(), day, timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), location)
+				if candidate.After(fromInLocal) && (best.IsZero() || candidate.Before(best)) {
+					best = candidate
+				}
 			}
 		}
 		if !best.IsZero() {
@@ -120,61 +151,252 @@ func NextMonthlyTrigger(from time.Time, daysOfMonth []int, timeOfDay time.Time,
 		}
 	}
 	if best.IsZero() {
-		best = from.Add(24 * time.Hour)
+		best = fromInLocal.AddDate(0, 0, 1)
+	}
+
+	// Convert back to UTC for storage
+	return best.UTC()
+}
+
+// NextNthWeekdayTrigger returns the next occurrence on any of the provided weekdays of the month,
+// such as the first Monday or the last Thursday, at HH:MM or the earliest of several times of day.
+func NextNthWeekdayTrigger(from time.Time, nthWeekdays []entities.NthWeekday, timeOfDay time.Time, location *time.Location, moreTimes ...time.Time) time.Time {
+	var valid []entities.NthWeekday
+	for _, n := range nthWeekdays {
+		if n.IsValid() {
+			valid = append(valid, n)
+		}
+	}
+	if len(valid) == 0 {
+		return NextDailyTrigger(from, timeOfDay, location, moreTimes...)
+	}
+
+	fromInLocal := from.In(location)
+	timesOfDay := append([]time.Time{timeOfDay}, moreTimes...)
+
+	// Every month has each of its first four and last weekdays, so two months always suffice
+	best := time.Time{}
+	for m := 0; m < 2 && best.IsZero(); m++ {
+		t := time.Date(fromInLocal.Year(), fromInLocal.Month()+time.Month(m), 1, 0, 0, 0, 0, location)
+		for _, n := range valid {
+			day := n.DayIn(t.Year(), t.Month())
+			for _, tod := range timesOfDay {
+				timeOfDayInLocal := tod.In(location)
+				candidate := LocalTime(t.Year(), t.Month(), day, timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), location)
+				if candidate.After(fromInLocal) && (best.IsZero() || candidate.Before(best)) {
+					best = candidate
+				}
+			}
+		}
 	}
 
 	// Convert back to UTC for storage
 	return best.UTC()
 }
 
+// NextYearlyTrigger returns the next occurrence of the month and day at HH:MM.
+// February 29 falls on February 28 in non-leap years.
+func NextYearlyTrigger(from time.Time, month time.Month, day int, timeOfDay time.Time, location *time.Location) time.Time {
+	fromInLocal := from.In(location)
+	timeOfDayInLocal := timeOfDay.In(location)
+
+	for year := fromInLocal.Year(); ; year++ {
+		candidate := LocalTime(year, month, min(day, daysIn(month, year)), timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), location)
+		if candidate.After(fromInLocal) {
+			// Convert back to UTC for storage
+			return candidate.UTC()
+		}
+	}
+}
+
+// NextYearlyForRecurrence returns the next trigger of a yearly recurrence on the month and day of its start date
+func NextYearlyForRecurrence(last time.Time, timeOfDay time.Time, rec *entities.Recurrence) *time.Time {
+	if rec.StartDate == nil {
+		return nil
+	}
+	location := rec.GetLocation()
+	if location == nil {
+		location = time.UTC
+	}
+	start := rec.StartDate.In(location)
+	result := NextYearlyTrigger(last, start.Month(), start.Day(), timeOfDay, location)
+	return &result
+}
+
+// resolveDayOfMonth maps a day-of-month setting to a calendar day of a month with daysInMonth days
+func resolveDayOfMonth(day, daysInMonth int, clamp bool) (int, bool) {
+	if day < 0 {
+		day = daysInMonth + 1 + day
+		return day, day >= 1
+	}
+	if day > daysInMonth {
+		return daysInMonth, clamp
+	}
+	return day, day >= 1
+}
+
 func NextForSpacedBasedRepetition(last time.Time, timeOfDay time.Time, rec *entities.Recurrence) *time.Time {
-	if rec.Type != entities.SpacedBasedRepetition || len(rec.SpacedBasedRepetitionDays) == 0 {
+	if rec.Type != entities.SpacedBasedRepetition {
+		return nil
+	}
+	if rec.Recall != nil {
+		return NextRecallTrigger(last, timeOfDay, rec)
+	}
+	// The ladder is kept intact, only the step advances
+	if rec.LadderStep >= len(rec.SpacedBasedRepetitionDays) {
 		return nil
 	}
 
 	next := NextDailyTrigger(last, timeOfDay, rec.GetLocation())
 
-	var nextInterval = rec.SpacedBasedRepetitionDays[0]
-	rec.SpacedBasedRepetitionDays = rec.SpacedBasedRepetitionDays[1:]
+	var nextInterval = rec.SpacedBasedRepetitionDays[rec.LadderStep]
+	rec.LadderStep++
 
-	// Advance by the next interval, retain time of day
-	result := next.Add(time.Duration(nextInterval) * 24 * time.Hour).UTC()
+	// Advance by the next interval in calendar days, retain time of day
+	result := AddLocalDays(next, nextInterval, timeOfDay, rec.GetLocation()).UTC()
 	return &result
 }
 
+// NextRecallTrigger returns the next adaptive review: the current interval in days after the
+// calendar day of from, at HH:MM. Ungraded reviews repeat at the same interval.
+// Returns nil once the item is learned.
+func NextRecallTrigger(from time.Time, timeOfDay time.Time, rec *entities.Recurrence) *time.Time {
+	if rec.Recall == nil || rec.Recall.IsLearned() {
+		return nil
+	}
+	loc := rec.GetLocation()
+	fromInLocal := from.In(loc)
+	timeOfDayInLocal := timeOfDay.In(loc)
+	days := max(rec.Recall.IntervalDays, 1)
+	result := LocalTime(fromInLocal.Year(), fromInLocal.Month(), fromInLocal.Day()+days, timeOfDayInLocal.Hour(), timeOfDayInLocal.Minute(), loc).UTC()
+	return &result
+}
+
+// TimesOfDay converts the HH:MM times of a recurrence into times on the date of timeOfDay.
+// Invalid entries are skipped.
+func TimesOfDay(timeOfDay time.Time, rec *entities.Recurrence) []time.Time {
+	location := rec.GetLocation()
+	if location == nil {
+		location = time.UTC
+	}
+	day := timeOfDay.In(location)
+
+	var times []time.Time
+	for _, s := range rec.TimesOfDay {
+		hour, minute, ok := ParseHourMinute(s)
+		if !ok {
+			continue
+		}
+		times = append(times, LocalTime(day.Year(), day.Month(), day.Day(), hour, minute, location))
+	}
+	return times
+}
+
 // NextForRecurrence advances from last trigger according to the recurrence configuration.
+// It returns nil once the recurrence is exhausted or the next trigger would pass its end date.
 func NextForRecurrence(last time.Time, timeOfDay time.Time, rec *entities.Recurrence) *time.Time {
+	if rec.IsExhausted() {
+		return nil
+	}
+	next := nextForType(last, timeOfDay, rec)
+	if next != nil && rec.BusinessDays.IsActive() && rec.Type != entities.Once {
+		next = ApplyHolidayPolicy(*next, timeOfDay, rec)
+	}
+	if next != nil && rec.EndsBefore(*next) {
+		return nil
+	}
+	return next
+}
+
+// ApplyHolidayPolicy applies the holiday policy of rec to an occurrence: occurrences on weekends,
+// holidays and excluded dates are either skipped or moved to the next business day.
+// Spaced repetition always shifts, skipping would drop steps of its ladder.
+func ApplyHolidayPolicy(next time.Time, timeOfDay time.Time, rec *entities.Recurrence) *time.Time {
+	loc := rec.GetLocation()
+	shift := rec.BusinessDays.Policy == entities.HolidayPolicyShift || rec.Type == entities.SpacedBasedRepetition
+	for range maxSkippedOccurrences {
+		if rec.EndsBefore(next) {
+			return nil
+		}
+		local := next.In(loc)
+		if holidays.IsBusinessDay(local, rec.BusinessDays) {
+			return &next
+		}
+		if shift {
+			next = LocalTime(local.Year(), local.Month(), local.Day()+1, local.Hour(), local.Minute(), loc).UTC()
+			continue
+		}
+		following := nextForType(next, timeOfDay, rec)
+		if following == nil || !following.After(next) {
+			return nil
+		}
+		next = *following
+	}
+	return nil
+}
+
+// nextForType advances from last trigger according to the recurrence type
+func nextForType(last time.Time, timeOfDay time.Time, rec *entities.Recurrence) *time.Time {
 	switch rec.Type {
 	case entities.Once:
 		return nil
 	case entities.Daily:
 		// Maintain the same clock time as last trigger, add 24h
-		result := NextDailyTrigger(last, timeOfDay, rec.GetLocation())
+		result := NextDailyTrigger(last, timeOfDay, rec.GetLocation(), TimesOfDay(timeOfDay, rec)...)
 		return &result
 	case entities.Weekly:
-		result := NextWeeklyTrigger(last, rec.Weekdays, timeOfDay, rec.GetLocation())
+		result := NextWeeklyTrigger(last, rec.Weekdays, timeOfDay, rec.GetLocation(), TimesOfDay(timeOfDay, rec)...)
 		return &result
 	case entities.Monthly:
-		result := NextMonthlyTrigger(last, rec.DayOfMonth, timeOfDay, rec.GetLocation())
+		if len(rec.NthWeekdays) > 0 {
+			result := NextNthWeekdayTrigger(last, rec.NthWeekdays, timeOfDay, rec.GetLocation(), TimesOfDay(timeOfDay, rec)...)
+			return &result
+		}
+		result := NextMonthlyTriggerClamped(last, rec.DayOfMonth, rec.ClampToMonthEnd, timeOfDay, rec.GetLocation(), TimesOfDay(timeOfDay, rec)...)
 		return &result
 	case entities.Interval:
-		// For interval reminders, we need to calculate the next trigger in the user's timezone
-		// Convert last trigger to user's timezone
-		lastInTz := last.In(rec.GetLocation())
-
-		// Advance by configured number of days, retain time of day
-		days := rec.Interval
-		if days <= 0 {
-			days = 1
-		}
-		// Convert back to UTC for storage
-		result := lastInTz.Add(time.Duration(days) * 24 * time.Hour).UTC()
-
+		result := NextIntervalTrigger(last, timeOfDay, rec)
 		return &result
 	case entities.SpacedBasedRepetition:
 		return NextForSpacedBasedRepetition(last, timeOfDay, rec)
+	case entities.RRule:
+		return NextRRuleTrigger(last, rec)
+	case entities.Yearly:
+		return NextYearlyForRecurrence(last, timeOfDay, rec)
 	default:
 		result := NextDailyTrigger(last, timeOfDay, rec.GetLocation())
 		return &result
 	}
 }
+
+// OccurrencesUntil lists the triggers of a recurrence from first (inclusive) up to and including
+// until, keeping at most limit of them, and returns the first trigger after until.
+// Spaced repetition recurrences consume their ladder while being advanced.
+func OccurrencesUntil(first, until time.Time, timeOfDay time.Time, rec *entities.Recurrence, limit int) ([]time.Time, *time.Time) {
+	var occurrences []time.Time
+	next := &first
+	for next != nil && !next.After(until) {
+		if len(occurrences) < limit {
+			occurrences = append(occurrences, *next)
+		}
+		following := NextForRecurrence(*next, timeOfDay, rec)
+		if following != nil && !following.After(*next) {
+			// Guard against recurrences that fail to advance
+			return occurrences, nil
+		}
+		next = following
+	}
+	return occurrences, next
+}
+
+// NextAfter returns the first trigger of a recurrence after now, advancing from the occurrence
+// at scheduledAt so that a late pass keeps the schedule instead of restarting it at now.
+// Spaced repetition takes a single step of its ladder from now instead, as advancing from
+// scheduledAt would consume one step for every missed review.
+func NextAfter(scheduledAt, now time.Time, timeOfDay time.Time, rec *entities.Recurrence) *time.Time {
+	if rec.Type == entities.SpacedBasedRepetition {
+		return NextForRecurrence(now, timeOfDay, rec)
+	}
+	_, next := OccurrencesUntil(scheduledAt, now, timeOfDay, rec, 0)
+	return next
+}
@@ -167,9 +167,8 @@ func TestNextForRecurrence(t *testing.T) {
 			t.Fatal("Expected non-nil result for interval recurrence")
 		}
 		
-		// Should advance by 3 days from the from time
-		fromInTz := from.In(loc)
-		wantLocal := fromInTz.Add(3 * 24 * time.Hour)
+		// Should advance by 3 days from the from date, at the time of day
+		wantLocal := time.Date(2025, 1, 13, 9, 0, 0, 0, loc)
 		wantUTC := wantLocal.UTC()
 		
 		if !gotUTC.Equal(wantUTC) {
@@ -186,4 +185,27 @@ func TestNextForRecurrence(t *testing.T) {
 			t.Errorf("Once: expected nil, got %v", *gotUTC)
 		}
 	})
-}
\ No newline at end of file
+}
+func TestOccurrencesUntil(t *testing.T) {
+	tod := time.Date(2025, 3, 7, 9, 0, 0, 0, time.UTC)
+	rec := entities.DailyAt(tod, time.UTC)
+	until := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	occurrences, next := OccurrencesUntil(tod, until, tod, rec, 10)
+	if len(occurrences) != 4 || !occurrences[3].Equal(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected 4 daily occurrences, got %v", occurrences)
+	}
+	if next == nil || !next.Equal(time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected next trigger on 2025-03-11 09:00, got %v", next)
+	}
+
+	occurrences, next = OccurrencesUntil(tod, until, tod, rec, 2)
+	if len(occurrences) != 2 || next == nil || !next.After(until) {
+		t.Fatalf("expected limited occurrences and a future next trigger, got %v, %v", occurrences, next)
+	}
+
+	occurrences, next = OccurrencesUntil(tod, until, tod, entities.OnceAt(tod, time.UTC), 10)
+	if len(occurrences) != 1 || next != nil {
+		t.Fatalf("expected a single one-time occurrence, got %v, %v", occurrences, next)
+	}
+}
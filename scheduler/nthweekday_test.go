@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestNextNthWeekdayTrigger(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	timeOfDay := time.Date(2025, 1, 1, 18, 0, 0, 0, loc)
+	firstMonday := entities.NthWeekday{Ordinal: 1, Weekday: time.Monday}
+	lastThursday := entities.NthWeekday{Ordinal: entities.OrdinalLast, Weekday: time.Thursday}
+
+	tests := []struct {
+		name string
+		nth  []entities.NthWeekday
+		from time.Time
+		want time.Time
+	}{
+		{"first Monday later this month", []entities.NthWeekday{firstMonday},
+			time.Date(2025, 10, 1, 12, 0, 0, 0, loc), time.Date(2025, 10, 6, 18, 0, 0, 0, loc)},
+		{"first Monday rolls over to next month", []entities.NthWeekday{firstMonday},
+			time.Date(2025, 10, 6, 18, 0, 0, 0, loc), time.Date(2025, 11, 3, 18, 0, 0, 0, loc)},
+		{"last Thursday", []entities.NthWeekday{lastThursday},
+			time.Date(2025, 11, 1, 0, 0, 0, 0, loc), time.Date(2025, 11, 27, 18, 0, 0, 0, loc)},
+		{"earliest of several", []entities.NthWeekday{lastThursday, firstMonday},
+			time.Date(2025, 11, 4, 0, 0, 0, 0, loc), time.Date(2025, 11, 27, 18, 0, 0, 0, loc)},
+		{"last Thursday rolls over the year", []entities.NthWeekday{lastThursday},
+			time.Date(2025, 12, 26, 0, 0, 0, 0, loc), time.Date(2026, 1, 29, 18, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextNthWeekdayTrigger(tt.from, tt.nth, timeOfDay, loc)
+			if !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNextForRecurrence_NthWeekday(t *testing.T) {
+	loc := time.UTC
+	timeOfDay := time.Date(2025, 1, 1, 9, 0, 0, 0, loc)
+	rec := entities.New(entities.Monthly, &timeOfDay, loc,
+		entities.WithNthWeekdays([]entities.NthWeekday{{Ordinal: 2, Weekday: time.Tuesday}}))
+
+	next := NextForRecurrence(time.Date(2025, 10, 1, 0, 0, 0, 0, loc), timeOfDay, rec)
+	if next == nil || !next.Equal(time.Date(2025, 10, 14, 9, 0, 0, 0, loc)) {
+		t.Fatalf("expected the second Tuesday of October, got %v", next)
+	}
+}
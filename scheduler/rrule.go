@@ -0,0 +1,443 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// Frequency is the FREQ part of an RRULE
+type Frequency int
+
+const (
+	FreqDaily Frequency = iota
+	FreqWeekly
+	FreqMonthly
+	FreqYearly
+)
+
+// maxEmptyRRulePeriods bounds the search for rules that never match, e.g. February 30th
+const maxEmptyRRulePeriods = 1000
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum is a BYDAY entry such as "MO", "2TU" (second Tuesday) or "-1FR" (last Friday)
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int // 0 matches every such weekday of the period
+}
+
+// RRule is the subset of an RFC 5545 recurrence rule supported for reminders:
+// FREQ=DAILY|WEEKLY|MONTHLY|YEARLY with INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY,
+// BYMONTH, BYSETPOS and WKST. The time of day always comes from the start date.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// ParseRRule parses a rule such as "FREQ=MONTHLY;BYDAY=-1FR", with or without the "RRULE:" prefix
+func ParseRRule(s string) (*RRule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return nil, errors.New("empty rule")
+	}
+
+	rule := &RRule{Freq: -1, Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate rule part %s", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq, err = parseFrequency(value)
+		case "INTERVAL":
+			rule.Interval, err = parseRRuleInt(value, 1, 1000)
+		case "COUNT":
+			rule.Count, err = parseRRuleInt(value, 1, 10000)
+		case "UNTIL":
+			rule.Until, err = parseUntil(value)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseRRuleList(value, 1, 31, true)
+		case "BYMONTH":
+			var months []int
+			months, err = parseRRuleList(value, 1, 12, false)
+			for _, m := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(m))
+			}
+		case "BYSETPOS":
+			rule.BySetPos, err = parseRRuleList(value, 1, 366, true)
+		case "WKST":
+			weekday, ok := rruleWeekdays[value]
+			if !ok {
+				err = fmt.Errorf("unknown weekday %q", value)
+			}
+			rule.WeekStart = weekday
+		case "BYHOUR", "BYMINUTE", "BYSECOND", "BYWEEKNO", "BYYEARDAY":
+			err = errors.New("not supported")
+		default:
+			err = errors.New("unknown rule part")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *RRule) validate() error {
+	if r.Freq < 0 {
+		return errors.New("FREQ is required")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("COUNT and UNTIL cannot be combined")
+	}
+	if r.Freq == FreqWeekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	if r.Freq != FreqMonthly && r.Freq != FreqYearly {
+		for _, day := range r.ByDay {
+			if day.N != 0 {
+				return errors.New("numbered BYDAY is only allowed with FREQ=MONTHLY or FREQ=YEARLY")
+			}
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByMonth) == 0 {
+		return errors.New("BYSETPOS requires another BY rule part")
+	}
+	return nil
+}
+
+func parseFrequency(value string) (Frequency, error) {
+	switch value {
+	case "DAILY":
+		return FreqDaily, nil
+	case "WEEKLY":
+		return FreqWeekly, nil
+	case "MONTHLY":
+		return FreqMonthly, nil
+	case "YEARLY":
+		return FreqYearly, nil
+	case "HOURLY", "MINUTELY", "SECONDLY":
+		return 0, errors.New("not supported, use an interval reminder instead")
+	default:
+		return 0, fmt.Errorf("unknown frequency %q", value)
+	}
+}
+
+func parseRRuleInt(value string, lo, hi int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("expected a number from %d to %d, got %q", lo, hi, value)
+	}
+	return n, nil
+}
+
+// parseRRuleList parses a comma separated list of numbers within [lo, hi],
+// or within [-hi, -lo] as well when negative values are allowed
+func parseRRuleList(value string, lo, hi int, allowNegative bool) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		abs := n
+		if allowNegative && n < 0 {
+			abs = -n
+		}
+		if err != nil || abs < lo || abs > hi {
+			return nil, fmt.Errorf("invalid value %q", item)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+		weekday, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("invalid weekday %q", item)
+			}
+		}
+		days = append(days, WeekdayNum{Weekday: weekday, N: n})
+	}
+	return days, nil
+}
+
+// parseUntil accepts UTC date-times ("20261231T235959Z"), floating date-times, read as UTC,
+// and dates, which include the whole day
+func parseUntil(value string) (*time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		end := t.Add(24*time.Hour - time.Second)
+		return &end, nil
+	}
+	return nil, fmt.Errorf("invalid date %q", value)
+}
+
+// After returns the first occurrence strictly after the given time for a rule starting at dtstart,
+// or nil when the rule has ended. Occurrences are computed in dtstart's location at its time of day.
+func (r *RRule) After(dtstart, after time.Time) *time.Time {
+	period := 0
+	if r.Count == 0 {
+		// Without COUNT nothing before the current period matters, so skip straight to it
+		period = r.periodsUntil(dtstart, after) / r.Interval * r.Interval
+	}
+
+	count := 0
+	for empty := 0; empty < maxEmptyRRulePeriods; period += r.Interval {
+		occurrences := r.expand(dtstart, period)
+		if len(occurrences) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+		for _, occurrence := range occurrences {
+			if occurrence.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && occurrence.After(*r.Until) {
+				return nil
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return nil
+			}
+			if occurrence.After(after) {
+				result := occurrence.UTC()
+				return &result
+			}
+		}
+	}
+	return nil
+}
+
+// periodsUntil counts the whole periods between the ones containing dtstart and t
+func (r *RRule) periodsUntil(dtstart, t time.Time) int {
+	if !t.After(dtstart) {
+		return 0
+	}
+	start := dateOf(dtstart)
+	end := dateOf(t.In(dtstart.Location()))
+	switch r.Freq {
+	case FreqDaily:
+		return daysBetween(start, end)
+	case FreqWeekly:
+		return daysBetween(weekStart(start, r.WeekStart), weekStart(end, r.WeekStart)) / 7
+	case FreqMonthly:
+		return (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	default:
+		return end.Year() - start.Year()
+	}
+}
+
+// expand lists the occurrences of the given period in chronological order
+func (r *RRule) expand(dtstart time.Time, period int) []time.Time {
+	start := dateOf(dtstart)
+	var days []time.Time
+	switch r.Freq {
+	case FreqDaily:
+		days = []time.Time{start.AddDate(0, 0, period)}
+	case FreqWeekly:
+		first := weekStart(start, r.WeekStart).AddDate(0, 0, 7*period)
+		for i := range 7 {
+			day := first.AddDate(0, 0, i)
+			if len(r.ByDay) > 0 || day.Weekday() == start.Weekday() {
+				days = append(days, day)
+			}
+		}
+	case FreqMonthly:
+		month := time.Date(start.Year(), start.Month()+time.Month(period), 1, 0, 0, 0, 0, time.UTC)
+		days = r.expandMonth(month, start.Day())
+	case FreqYearly:
+		days = r.expandYear(start.Year()+period, start)
+	}
+
+	days = slices.DeleteFunc(days, func(day time.Time) bool {
+		return !r.matchesMonth(day) || !r.matchesMonthDay(day) || !r.matchesWeekday(day, r.Freq == FreqYearly && len(r.ByMonth) == 0)
+	})
+	days = r.applySetPos(days)
+
+	occurrences := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		occurrence := LocalTime(day.Year(), day.Month(), day.Day(), dtstart.Hour(), dtstart.Minute(), dtstart.Location())
+		occurrences = append(occurrences, occurrence.Add(time.Duration(dtstart.Second())*time.Second))
+	}
+	return occurrences
+}
+
+// expandMonth lists the candidate days of a month; without BYDAY and BYMONTHDAY it is the start day
+func (r *RRule) expandMonth(month time.Time, startDay int) []time.Time {
+	dim := daysIn(month.Month(), month.Year())
+	if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		if startDay > dim {
+			return nil
+		}
+		return []time.Time{month.AddDate(0, 0, startDay-1)}
+	}
+	days := make([]time.Time, 0, dim)
+	for d := range dim {
+		days = append(days, month.AddDate(0, 0, d))
+	}
+	return days
+}
+
+// expandYear lists the candidate days of a year
+func (r *RRule) expandYear(year int, start time.Time) []time.Time {
+	if len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if start.Month() == time.February && start.Day() == 29 && daysIn(time.February, year) < 29 {
+			return nil
+		}
+		return []time.Time{time.Date(year, start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)}
+	}
+
+	var days []time.Time
+	for month := time.January; month <= time.December; month++ {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		if len(r.ByMonth) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			// BYMONTH alone keeps the start day in each listed month
+			days = append(days, r.expandMonth(first, start.Day())...)
+			continue
+		}
+		for d := range daysIn(month, year) {
+			days = append(days, first.AddDate(0, 0, d))
+		}
+	}
+	return days
+}
+
+func (r *RRule) matchesMonth(day time.Time) bool {
+	return len(r.ByMonth) == 0 || slices.Contains(r.ByMonth, day.Month())
+}
+
+func (r *RRule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	dim := daysIn(day.Month(), day.Year())
+	for _, d := range r.ByMonthDay {
+		if d == day.Day() || dim+1+d == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday checks BYDAY; numbered entries count within the month, or within the year
+// for yearly rules without BYMONTH
+func (r *RRule) matchesWeekday(day time.Time, withinYear bool) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	index, length := day.Day(), daysIn(day.Month(), day.Year())
+	if withinYear {
+		index, length = day.YearDay(), time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	}
+	for _, wd := range r.ByDay {
+		if wd.Weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case wd.N == 0:
+			return true
+		case wd.N > 0 && (index-1)/7+1 == wd.N:
+			return true
+		case wd.N < 0 && (length-index)/7+1 == -wd.N:
+			return true
+		}
+	}
+	return false
+}
+
+// applySetPos keeps the BYSETPOS-th days of the period, counting from the end when negative
+func (r *RRule) applySetPos(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 || len(days) == 0 {
+		return days
+	}
+	var selected []time.Time
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) && !slices.Contains(selected, days[i]) {
+			selected = append(selected, days[i])
+		}
+	}
+	slices.SortFunc(selected, func(a, b time.Time) int { return a.Compare(b) })
+	return selected
+}
+
+// dateOf returns the calendar date of t as midnight UTC, which keeps date arithmetic free of DST
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+func weekStart(day time.Time, wkst time.Weekday) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(wkst) + 7) % 7))
+}
+
+// NextRRuleTrigger returns the first occurrence of the recurrence's rule after last,
+// or nil when the rule is invalid or has ended
+func NextRRuleTrigger(last time.Time, rec *entities.Recurrence) *time.Time {
+	rule, err := ParseRRule(rec.RRule)
+	if err != nil || rec.StartDate == nil {
+		return nil
+	}
+	location := rec.GetLocation()
+	if location == nil {
+		location = time.UTC
+	}
+	return rule.After(rec.StartDate.In(location), last)
+}
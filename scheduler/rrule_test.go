@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestRRuleAfter(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	// Thursday, January 1st 2026 at 09:30
+	dtstart := time.Date(2026, 1, 1, 9, 30, 0, 0, loc)
+	at := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 9, 30, 0, 0, loc) }
+
+	tests := []struct {
+		name  string
+		rule  string
+		after time.Time
+		want  []time.Time
+	}{
+		{"every 2nd Tuesday", "FREQ=MONTHLY;BYDAY=2TU", dtstart,
+			[]time.Time{at(2026, 1, 13), at(2026, 2, 10), at(2026, 3, 10)}},
+		{"last Friday of the month", "RRULE:FREQ=MONTHLY;BYDAY=-1FR", dtstart,
+			[]time.Time{at(2026, 1, 30), at(2026, 2, 27), at(2026, 3, 27)}},
+		{"every other week on Mon/Wed", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE", dtstart,
+			[]time.Time{at(2026, 1, 12), at(2026, 1, 14), at(2026, 1, 26), at(2026, 1, 28)}},
+		{"first weekday of the quarter", "FREQ=MONTHLY;BYMONTH=1,4,7,10;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1", dtstart,
+			[]time.Time{at(2026, 4, 1), at(2026, 7, 1), at(2026, 10, 1), at(2027, 1, 1)}},
+		{"last day of the month", "FREQ=MONTHLY;BYMONTHDAY=-1", dtstart,
+			[]time.Time{at(2026, 1, 31), at(2026, 2, 28), at(2026, 3, 31)}},
+		{"count includes past occurrences", "FREQ=DAILY;COUNT=3", dtstart,
+			[]time.Time{at(2026, 1, 2), at(2026, 1, 3)}},
+		{"until is inclusive", "FREQ=WEEKLY;UNTIL=20260115", dtstart,
+			[]time.Time{at(2026, 1, 8), at(2026, 1, 15)}},
+		{"skips ahead across DST", "FREQ=DAILY;INTERVAL=3", at(2026, 3, 7),
+			[]time.Time{at(2026, 3, 8), at(2026, 3, 11)}},
+		{"leap day", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29", dtstart,
+			[]time.Time{at(2028, 2, 29), at(2032, 2, 29)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) failed: %v", tt.rule, err)
+			}
+			after := tt.after
+			for _, want := range tt.want {
+				got := rule.After(dtstart, after)
+				if got == nil || !got.Equal(want) {
+					t.Fatalf("After(%v) = %v, want %v", after, got, want)
+				}
+				after = *got
+			}
+			if rule.Count > 0 || rule.Until != nil {
+				if got := rule.After(dtstart, after); got != nil {
+					t.Fatalf("expected the rule to end after %v, got %v", after, got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRRule_Invalid(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=WEEKLY;BYDAY=2MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;COUNT=3;UNTIL=20261231",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+	} {
+		if _, err := ParseRRule(rule); err == nil {
+			t.Errorf("ParseRRule(%q) should fail", rule)
+		}
+	}
+}
+
+func TestNextForRecurrence_RRule(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rec := entities.RRuleFrom("FREQ=MONTHLY;BYDAY=-1FR", start, time.UTC)
+
+	got := NextForRecurrence(time.Date(2026, 1, 30, 9, 0, 0, 0, time.UTC), start, rec)
+	if want := time.Date(2026, 2, 27, 9, 0, 0, 0, time.UTC); got == nil || !got.Equal(want) {
+		t.Fatalf("NextForRecurrence() = %v, want %v", got, want)
+	}
+
+	rec.RRule = "FREQ=SOMETIMES"
+	if got := NextForRecurrence(start, start, rec); got != nil {
+		t.Fatalf("an invalid rule should have no next trigger, got %v", got)
+	}
+}
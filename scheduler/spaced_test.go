@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestNextForSpacedBasedRepetition_Ladder(t *testing.T) {
+	loc := time.UTC
+	tod := time.Date(2025, 3, 1, 9, 0, 0, 0, loc)
+	rec := entities.New(entities.SpacedBasedRepetition, &tod, loc, entities.WithSpacedRepetitionLadder([]int{1, 3}))
+
+	want := []time.Time{
+		time.Date(2025, 3, 1, 9, 0, 0, 0, loc),
+		time.Date(2025, 3, 2, 9, 0, 0, 0, loc),
+		time.Date(2025, 3, 5, 9, 0, 0, 0, loc),
+	}
+	last := time.Date(2025, 3, 1, 8, 0, 0, 0, loc)
+	for i, w := range want {
+		next := NextForRecurrence(last, tod, rec)
+		if next == nil || !next.Equal(w) {
+			t.Fatalf("review %d: expected %v, got %v", i, w, next)
+		}
+		last = *next
+	}
+	if next := NextForRecurrence(last, tod, rec); next != nil {
+		t.Fatalf("expected no review after the ladder, got %v", next)
+	}
+	if !slices.Equal(rec.SpacedBasedRepetitionDays, []int{0, 0, 2}) {
+		t.Fatalf("expected the ladder to be kept, got %v", rec.SpacedBasedRepetitionDays)
+	}
+}
+
+func TestNextForSpacedBasedRepetition_Adaptive(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	tod := time.Date(2025, 3, 1, 20, 0, 0, 0, loc)
+	state := entities.NewRecallState()
+	rec := entities.New(entities.SpacedBasedRepetition, &tod, loc, entities.WithRecall(state))
+
+	// Ungraded reviews repeat at the current interval
+	next := NextForRecurrence(time.Date(2025, 3, 1, 20, 0, 0, 0, loc), tod, rec)
+	if next == nil || !next.Equal(time.Date(2025, 3, 2, 20, 0, 0, 0, loc)) {
+		t.Fatalf("expected the next day, got %v", next)
+	}
+
+	state.IntervalDays = 6
+	// Graded late in the evening, still counted from the local calendar day
+	next = NextForRecurrence(time.Date(2025, 3, 2, 23, 30, 0, 0, loc), tod, rec)
+	if next == nil || !next.Equal(time.Date(2025, 3, 8, 20, 0, 0, 0, loc)) {
+		t.Fatalf("expected six days later, got %v", next)
+	}
+
+	state.IntervalDays = 400
+	if next := NextForRecurrence(tod, tod, rec); next != nil {
+		t.Fatalf("expected no review once learned, got %v", next)
+	}
+}
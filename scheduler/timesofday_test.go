@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestNextForRecurrence_MultipleTimesOfDay(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	timeOfDay := time.Date(2025, 3, 10, 8, 0, 0, 0, loc)
+	times := entities.WithTimesOfDay([]string{"08:00", "14:00", "20:00"})
+
+	tests := []struct {
+		name string
+		rec  *entities.Recurrence
+		last time.Time
+		want time.Time
+	}{
+		{"daily picks the next time today", entities.New(entities.Daily, &timeOfDay, loc, times),
+			time.Date(2025, 3, 10, 8, 0, 0, 0, loc), time.Date(2025, 3, 10, 14, 0, 0, 0, loc)},
+		{"daily wraps to tomorrow morning", entities.New(entities.Daily, &timeOfDay, loc, times),
+			time.Date(2025, 3, 10, 20, 0, 0, 0, loc), time.Date(2025, 3, 11, 8, 0, 0, 0, loc)},
+		{"weekly stays on the same day", entities.New(entities.Weekly, &timeOfDay, loc, times, entities.WithWeekdays([]time.Weekday{time.Monday})),
+			time.Date(2025, 3, 10, 14, 0, 0, 0, loc), time.Date(2025, 3, 10, 20, 0, 0, 0, loc)},
+		{"weekly moves to the next weekday", entities.New(entities.Weekly, &timeOfDay, loc, times, entities.WithWeekdays([]time.Weekday{time.Monday})),
+			time.Date(2025, 3, 10, 20, 0, 0, 0, loc), time.Date(2025, 3, 17, 8, 0, 0, 0, loc)},
+		{"monthly stays on the same day", entities.New(entities.Monthly, &timeOfDay, loc, times, entities.WithDaysOfMonth([]int{10})),
+			time.Date(2025, 3, 10, 8, 0, 0, 0, loc), time.Date(2025, 3, 10, 14, 0, 0, 0, loc)},
+		{"monthly moves to the next month", entities.New(entities.Monthly, &timeOfDay, loc, times, entities.WithDaysOfMonth([]int{10})),
+			time.Date(2025, 3, 10, 20, 0, 0, 0, loc), time.Date(2025, 4, 10, 8, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextForRecurrence(tt.last, timeOfDay, tt.rec)
+			if got == nil || !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
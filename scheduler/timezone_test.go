@@ -168,8 +168,8 @@ func TestNextForRecurrenceEdgeCases(t *testing.T) {
 			t.Fatal("Expected non-nil result")
 		}
 
-		// Should advance by 1 day
-		expected := from.Add(24 * time.Hour)
+		// Should advance by 1 day, at the time of day
+		expected := time.Date(2025, 1, 11, 15, 0, 0, 0, loc)
 		if !result.Equal(expected) {
 			t.Errorf("Expected %v, got %v", expected, *result)
 		}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+// UpcomingOccurrences lists the triggers of an active reminder from (inclusive) to (exclusive),
+// keeping at most limit of them. The reminder itself is left untouched.
+func UpcomingOccurrences(reminder entities.Reminder, from, to time.Time, limit int) []time.Time {
+	if !reminder.IsActive || reminder.NextTrigger == nil || reminder.Recurrence == nil || !reminder.NextTrigger.Before(to) {
+		return nil
+	}
+	if reminder.Recurrence.Type == entities.Once || reminder.Recurrence.StartDate == nil {
+		if reminder.NextTrigger.Before(from) || limit <= 0 {
+			return nil
+		}
+		return []time.Time{*reminder.NextTrigger}
+	}
+
+	// Advance a copy, expanding spaced repetition consumes its ladder
+	rec := *reminder.Recurrence
+	remaining := rec.RemainingOccurrences()
+
+	// Skip what is still due before the window; it counts towards a limited recurrence
+	first := *reminder.NextTrigger
+	for first.Before(from) {
+		if remaining > 0 {
+			remaining--
+		}
+		if remaining == 0 {
+			return nil
+		}
+		next := NextForRecurrence(first, *rec.StartDate, &rec)
+		if next == nil || !next.After(first) {
+			return nil
+		}
+		first = *next
+	}
+
+	if remaining >= 0 {
+		limit = min(limit, remaining)
+	}
+	occurrences, _ := OccurrencesUntil(first, to, *rec.StartDate, &rec, limit)
+	// The window excludes its end
+	if n := len(occurrences); n > 0 && !occurrences[n-1].Before(to) {
+		occurrences = occurrences[:n-1]
+	}
+	return occurrences
+}
+
+// NextDigest returns when a daily digest sent at HH:MM in loc is due next after from
+func NextDigest(from time.Time, digestTime string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	tod, err := time.Parse("15:04", digestTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid digest time %q: %w", digestTime, err)
+	}
+	local := from.In(loc)
+	next := LocalTime(local.Year(), local.Month(), local.Day(), tod.Hour(), tod.Minute(), loc)
+	if !next.After(from) {
+		// The next calendar day, which is not always 24 hours away
+		next = LocalTime(local.Year(), local.Month(), local.Day()+1, tod.Hour(), tod.Minute(), loc)
+	}
+	return next.UTC(), nil
+}
@@ -0,0 +1,35 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDigest(t *testing.T) {
+	kyiv := loadLocation(t, "Europe/Kyiv")
+
+	tests := []struct {
+		name string
+		from time.Time
+		want time.Time
+	}{
+		{"later today", time.Date(2026, 10, 20, 4, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 5, 0, 0, 0, time.UTC)},
+		{"at the digest moves to tomorrow", time.Date(2026, 10, 20, 5, 0, 0, 0, time.UTC), time.Date(2026, 10, 21, 5, 0, 0, 0, time.UTC)},
+		{"keeps 08:00 over fall back", time.Date(2026, 10, 24, 6, 0, 0, 0, time.UTC), time.Date(2026, 10, 25, 6, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextDigest(tt.from, "08:00", kyiv)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := NextDigest(time.Now(), "8am", kyiv); err == nil {
+		t.Fatalf("expected an invalid digest time to be rejected")
+	}
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivanenkomaksym/remindme_bot/domain/entities"
+)
+
+func TestNextYearlyTrigger(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	timeOfDay := time.Date(2024, 2, 29, 9, 0, 0, 0, loc)
+
+	tests := []struct {
+		name  string
+		month time.Month
+		day   int
+		from  time.Time
+		want  time.Time
+	}{
+		{"later this year", time.March, 15,
+			time.Date(2025, 1, 10, 12, 0, 0, 0, loc), time.Date(2025, 3, 15, 9, 0, 0, 0, loc)},
+		{"already passed this year", time.March, 15,
+			time.Date(2025, 3, 15, 9, 0, 0, 0, loc), time.Date(2026, 3, 15, 9, 0, 0, 0, loc)},
+		{"Feb 29 in a non-leap year", time.February, 29,
+			time.Date(2025, 1, 1, 0, 0, 0, 0, loc), time.Date(2025, 2, 28, 9, 0, 0, 0, loc)},
+		{"Feb 29 in a leap year", time.February, 29,
+			time.Date(2027, 3, 1, 0, 0, 0, 0, loc), time.Date(2028, 2, 29, 9, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextYearlyTrigger(tt.from, tt.month, tt.day, timeOfDay, loc)
+			if !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNextForRecurrence_Yearly(t *testing.T) {
+	loc := time.UTC
+	birthday := time.Date(2024, 2, 29, 8, 30, 0, 0, loc)
+	rec := entities.YearlyOn(birthday, loc)
+
+	next := NextForRecurrence(birthday, birthday, rec)
+	if next == nil || !next.Equal(time.Date(2025, 2, 28, 8, 30, 0, 0, loc)) {
+		t.Fatalf("expected Feb 28 2025 08:30, got %v", next)
+	}
+	// The month and day come from the start date, not from the clamped trigger
+	next = NextForRecurrence(*next, birthday, rec)
+	next = NextForRecurrence(*next, birthday, rec)
+	next = NextForRecurrence(*next, birthday, rec)
+	if next == nil || !next.Equal(time.Date(2028, 2, 29, 8, 30, 0, 0, loc)) {
+		t.Fatalf("expected Feb 29 2028 08:30, got %v", next)
+	}
+}